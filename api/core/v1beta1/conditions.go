@@ -172,6 +172,19 @@ const (
 
 	// OpenStackControlPlaneBackupConfigReadyCondition Status=True condition which indicates if OpenStackBackupConfig is reconciled
 	OpenStackControlPlaneBackupConfigReadyCondition condition.Type = "OpenStackControlPlaneBackupConfigReady"
+
+	// OpenStackControlPlaneServicesManagedCondition Status=False condition which indicates enabled services the
+	// openstack-operator doesn't manage, it is removed when all of them are managed
+	OpenStackControlPlaneServicesManagedCondition condition.Type = "OpenStackControlPlaneServicesManaged"
+
+	// OpenStackControlPlaneSizingReadyCondition Status=False condition which indicates the sizing profile can't
+	// be resolved, it is removed when the profile is applied
+	OpenStackControlPlaneSizingReadyCondition condition.Type = "OpenStackControlPlaneSizingReady"
+
+	// OpenStackControlPlaneAutoscalingReadyCondition Status=False condition which indicates autoscaled APIs
+	// left out of the autoscaling, their maxReplicas being lower than their minimum replicas. It is removed
+	// when all of them are autoscaled
+	OpenStackControlPlaneAutoscalingReadyCondition condition.Type = "OpenStackControlPlaneAutoscalingReady"
 )
 
 // Common Messages used by API objects.
@@ -551,6 +564,30 @@ const (
 
 	// OpenStackControlPlaneInfrastructureReadyErrorMessage
 	OpenStackControlPlaneInfrastructureReadyErrorMessage = "OpenStackControlPlane Infrastructure error occured %s"
+
+	// OpenStackControlPlaneServiceDependenciesWaitingMessage
+	OpenStackControlPlaneServiceDependenciesWaitingMessage = "OpenStackControlPlane %s waiting for: %s"
+
+	// OpenStackControlPlaneScalingInProgressMessage
+	OpenStackControlPlaneScalingInProgressMessage = "OpenStackControlPlane %s %s scaling to %d replicas, at %d replicas waiting for the cluster to be healthy"
+
+	// OpenStackControlPlaneSizingReadyErrorMessage
+	OpenStackControlPlaneSizingReadyErrorMessage = "OpenStackControlPlane sizing profile error occured %s"
+
+	// OpenStackControlPlaneAutoscalingReadyConflictMessage
+	OpenStackControlPlaneAutoscalingReadyConflictMessage = "OpenStackControlPlane APIs not autoscaled, maxReplicas lower than the minimum replicas: %s"
+
+	// OpenStackControlPlaneServicesUnmanagedMessage
+	OpenStackControlPlaneServicesUnmanagedMessage = "OpenStackControlPlane services not managed, left out of minor updates: %s"
+
+	// OpenStackControlPlaneServiceUnmanagedReadyMessage
+	OpenStackControlPlaneServiceUnmanagedReadyMessage = "OpenStackControlPlane %s not managed, ready"
+
+	// OpenStackControlPlaneServiceUnmanagedNotReadyMessage
+	OpenStackControlPlaneServiceUnmanagedNotReadyMessage = "OpenStackControlPlane %s not managed, not ready"
+
+	// OpenStackControlPlaneServiceUnmanagedNotFoundMessage
+	OpenStackControlPlaneServiceUnmanagedNotFoundMessage = "OpenStackControlPlane %s not managed, %s not found"
 )
 
 // Version Conditions used by to drive minor updates
@@ -606,3 +643,18 @@ const (
 	// OpenStackVersionMinorUpdateAvailableMessage
 	OpenStackVersionMinorUpdateAvailableMessage = "update available"
 )
+
+// OpenStackControlPlanePlan Messages used by API objects.
+const (
+	// OpenStackControlPlanePlanReadyInitMessage
+	OpenStackControlPlanePlanReadyInitMessage = "OpenStackControlPlanePlan not started"
+
+	// OpenStackControlPlanePlanReadyMessage
+	OpenStackControlPlanePlanReadyMessage = "OpenStackControlPlanePlan computed"
+
+	// OpenStackControlPlanePlanReadyWaitingMessage
+	OpenStackControlPlanePlanReadyWaitingMessage = "OpenStackControlPlanePlan waiting for the OpenStackControlPlane %s"
+
+	// OpenStackControlPlanePlanReadyErrorMessage
+	OpenStackControlPlanePlanReadyErrorMessage = "OpenStackControlPlanePlan error occured %s"
+)
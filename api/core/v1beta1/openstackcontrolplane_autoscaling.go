@@ -0,0 +1,147 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// DefaultAutoscalingTargetCPUUtilizationPercentage - the CPU utilization
+	// target of the autoscaled APIs not setting any target
+	DefaultAutoscalingTargetCPUUtilizationPercentage = 80
+)
+
+// AutoscaledAPI - an API of the OpenStackControlPlane which can be autoscaled.
+// Only the APIs running as a Deployment are, the other ones run a StatefulSet.
+type AutoscaledAPI struct {
+	// Name - the name of the service, its path in the spec and its key in
+	// status.autoscaling
+	Name string
+	// Enabled - true when the service is enabled and managed
+	Enabled bool
+	// Autoscaling - the autoscaling of the API, nil when not autoscaled
+	Autoscaling *AutoscalingSection
+	// Replicas - the replicas of the API in the service template, nil without
+	// a template
+	Replicas **int32
+}
+
+// autoscaledAPI - returns the API of a service with a management state
+func (r *OpenStackControlPlane) autoscaledAPI(service string, autoscaling *AutoscalingSection) AutoscaledAPI {
+	managed := r.managedServices()[service]
+	return AutoscaledAPI{
+		Name:        strings.ToLower(service),
+		Enabled:     managed.enabled && managed.state != ManagementStateUnmanaged,
+		Autoscaling: autoscaling,
+	}
+}
+
+// GetAutoscaledAPIs - returns the APIs which can be autoscaled
+func (r *OpenStackControlPlane) GetAutoscaledAPIs() []AutoscaledAPI {
+	barbican := r.autoscaledAPI("Barbican", r.Spec.Barbican.Autoscaling)
+	if r.Spec.Barbican.Template != nil {
+		barbican.Replicas = &r.Spec.Barbican.Template.BarbicanAPI.Replicas
+	}
+	designate := r.autoscaledAPI("Designate", r.Spec.Designate.Autoscaling)
+	if r.Spec.Designate.Template != nil {
+		designate.Replicas = &r.Spec.Designate.Template.DesignateAPI.Replicas
+	}
+	heat := r.autoscaledAPI("Heat", r.Spec.Heat.Autoscaling)
+	if r.Spec.Heat.Template != nil {
+		heat.Replicas = &r.Spec.Heat.Template.HeatAPI.Replicas
+	}
+	horizon := r.autoscaledAPI("Horizon", r.Spec.Horizon.Autoscaling)
+	if r.Spec.Horizon.Template != nil {
+		horizon.Replicas = &r.Spec.Horizon.Template.Replicas
+	}
+	keystone := r.autoscaledAPI("Keystone", r.Spec.Keystone.Autoscaling)
+	if r.Spec.Keystone.Template != nil {
+		keystone.Replicas = &r.Spec.Keystone.Template.Replicas
+	}
+	neutron := r.autoscaledAPI("Neutron", r.Spec.Neutron.Autoscaling)
+	if r.Spec.Neutron.Template != nil {
+		neutron.Replicas = &r.Spec.Neutron.Template.Replicas
+	}
+	octavia := r.autoscaledAPI("Octavia", r.Spec.Octavia.Autoscaling)
+	if r.Spec.Octavia.Template != nil {
+		octavia.Replicas = &r.Spec.Octavia.Template.OctaviaAPI.Replicas
+	}
+	placement := r.autoscaledAPI("Placement", r.Spec.Placement.Autoscaling)
+	if r.Spec.Placement.Template != nil {
+		placement.Replicas = &r.Spec.Placement.Template.Replicas
+	}
+	swift := r.autoscaledAPI("Swift", r.Spec.Swift.Autoscaling)
+	if r.Spec.Swift.Template != nil {
+		swift.Replicas = &r.Spec.Swift.Template.SwiftProxy.Replicas
+	}
+
+	return []AutoscaledAPI{barbican, designate, heat, horizon, keystone, neutron, octavia, placement, swift}
+}
+
+// IsAutoscaled - returns true if the API is enabled and autoscaled. An API
+// scaled to zero replicas by its template is stopped, not autoscaled.
+func (a AutoscaledAPI) IsAutoscaled() bool {
+	return a.Enabled && a.Autoscaling != nil && a.Replicas != nil && a.GetTemplateReplicas() > 0
+}
+
+// GetTemplateReplicas - returns the replicas of the API in the service
+// template, 1 when unset
+func (a AutoscaledAPI) GetTemplateReplicas() int32 {
+	if a.Replicas == nil {
+		return 1
+	}
+	return ptr.Deref(*a.Replicas, 1)
+}
+
+// GetMinReplicas - returns the minimum replicas of the autoscaled API, the
+// replicas of the template being the floor. 0 when the template scales the
+// API to zero replicas, which isn't raised.
+func (a AutoscaledAPI) GetMinReplicas() int32 {
+	replicas := a.GetTemplateReplicas()
+	if replicas == 0 {
+		return 0
+	}
+	return max(ptr.Deref(a.Autoscaling.MinReplicas, 1), replicas)
+}
+
+// ValidateAutoscaling - returns an error for each autoscaled API with less
+// maximum replicas than the minimum ones, or than the replicas of its template
+func (r *OpenStackControlPlane) ValidateAutoscaling(basePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for _, api := range r.GetAutoscaledAPIs() {
+		if api.Autoscaling == nil {
+			continue
+		}
+		path := basePath.Child(api.Name).Child("autoscaling").Child("maxReplicas")
+		if api.Autoscaling.MinReplicas != nil && api.Autoscaling.MaxReplicas < *api.Autoscaling.MinReplicas {
+			allErrs = append(allErrs, field.Invalid(path, api.Autoscaling.MaxReplicas,
+				"maxReplicas must not be lower than minReplicas"))
+			continue
+		}
+		if api.Autoscaling.MaxReplicas < api.GetTemplateReplicas() {
+			allErrs = append(allErrs, field.Invalid(path, api.Autoscaling.MaxReplicas,
+				"maxReplicas must not be lower than the replicas of the template"))
+		}
+	}
+
+	return allErrs
+}
@@ -0,0 +1,227 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"strings"
+
+	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+)
+
+// controlPlaneService - a service of the OpenStackControlPlane a dependency can refer to
+type controlPlaneService struct {
+	// enabled - returns true when the service is enabled in the spec
+	enabled func(spec *OpenStackControlPlaneSpec) bool
+	// readyCondition - the condition of the OpenStackControlPlane reflecting the service is ready
+	readyCondition condition.Type
+}
+
+// serviceDependency - the services a service requires
+type serviceDependency struct {
+	// name - name of the service, components of a service are named <service>.<component>
+	name string
+	// path - path of the field enabling the service, relative to the spec
+	path []string
+	// requires - names of the required services
+	requires []string
+}
+
+// controlPlaneServices - the services dependencies refer to
+var controlPlaneServices = map[string]controlPlaneService{
+	"Galera": {
+		enabled:        func(spec *OpenStackControlPlaneSpec) bool { return spec.Galera.Enabled },
+		readyCondition: OpenStackControlPlaneMariaDBReadyCondition,
+	},
+	"Memcached": {
+		enabled:        func(spec *OpenStackControlPlaneSpec) bool { return spec.Memcached.Enabled },
+		readyCondition: OpenStackControlPlaneMemcachedReadyCondition,
+	},
+	"RabbitMQ": {
+		enabled:        func(spec *OpenStackControlPlaneSpec) bool { return spec.Rabbitmq.Enabled },
+		readyCondition: OpenStackControlPlaneRabbitMQReadyCondition,
+	},
+	"Keystone": {
+		enabled:        func(spec *OpenStackControlPlaneSpec) bool { return spec.Keystone.Enabled },
+		readyCondition: OpenStackControlPlaneKeystoneAPIReadyCondition,
+	},
+	"Glance": {
+		enabled:        func(spec *OpenStackControlPlaneSpec) bool { return spec.Glance.Enabled },
+		readyCondition: OpenStackControlPlaneGlanceReadyCondition,
+	},
+	"Cinder": {
+		enabled:        func(spec *OpenStackControlPlaneSpec) bool { return spec.Cinder.Enabled },
+		readyCondition: OpenStackControlPlaneCinderReadyCondition,
+	},
+	"Placement": {
+		enabled:        func(spec *OpenStackControlPlaneSpec) bool { return spec.Placement.Enabled },
+		readyCondition: OpenStackControlPlanePlacementAPIReadyCondition,
+	},
+	"Neutron": {
+		enabled:        func(spec *OpenStackControlPlaneSpec) bool { return spec.Neutron.Enabled },
+		readyCondition: OpenStackControlPlaneNeutronReadyCondition,
+	},
+	"Nova": {
+		enabled:        func(spec *OpenStackControlPlaneSpec) bool { return spec.Nova.Enabled },
+		readyCondition: OpenStackControlPlaneNovaReadyCondition,
+	},
+	"Heat": {
+		enabled:        func(spec *OpenStackControlPlaneSpec) bool { return spec.Heat.Enabled },
+		readyCondition: OpenStackControlPlaneHeatReadyCondition,
+	},
+	"Swift": {
+		enabled:        func(spec *OpenStackControlPlaneSpec) bool { return spec.Swift.Enabled },
+		readyCondition: OpenStackControlPlaneSwiftReadyCondition,
+	},
+	"Horizon": {
+		enabled:        func(spec *OpenStackControlPlaneSpec) bool { return spec.Horizon.Enabled },
+		readyCondition: OpenStackControlPlaneHorizonReadyCondition,
+	},
+	"Barbican": {
+		enabled:        func(spec *OpenStackControlPlaneSpec) bool { return spec.Barbican.Enabled },
+		readyCondition: OpenStackControlPlaneBarbicanReadyCondition,
+	},
+	"Octavia": {
+		enabled:        func(spec *OpenStackControlPlaneSpec) bool { return spec.Octavia.Enabled },
+		readyCondition: OpenStackControlPlaneOctaviaReadyCondition,
+	},
+	"Telemetry": {
+		enabled:        func(spec *OpenStackControlPlaneSpec) bool { return spec.Telemetry.Enabled },
+		readyCondition: OpenStackControlPlaneTelemetryReadyCondition,
+	},
+	"Telemetry.Autoscaling": {
+		enabled: func(spec *OpenStackControlPlaneSpec) bool {
+			return spec.Telemetry.Enabled && spec.Telemetry.Template != nil &&
+				spec.Telemetry.Template.Autoscaling.Enabled != nil && *spec.Telemetry.Template.Autoscaling.Enabled
+		},
+		readyCondition: OpenStackControlPlaneTelemetryReadyCondition,
+	},
+	"Telemetry.Ceilometer": {
+		enabled: func(spec *OpenStackControlPlaneSpec) bool {
+			return spec.Telemetry.Enabled && spec.Telemetry.Template != nil &&
+				spec.Telemetry.Template.Ceilometer.Enabled != nil && *spec.Telemetry.Template.Ceilometer.Enabled
+		},
+		readyCondition: OpenStackControlPlaneTelemetryReadyCondition,
+	},
+	"Telemetry.MetricStorage": {
+		enabled: func(spec *OpenStackControlPlaneSpec) bool {
+			return spec.Telemetry.Enabled && spec.Telemetry.Template != nil &&
+				spec.Telemetry.Template.MetricStorage.Enabled != nil && *spec.Telemetry.Template.MetricStorage.Enabled
+		},
+		readyCondition: OpenStackControlPlaneTelemetryReadyCondition,
+	},
+	"Watcher": {
+		enabled:        func(spec *OpenStackControlPlaneSpec) bool { return spec.Watcher.Enabled },
+		readyCondition: OpenStackControlPlaneWatcherReadyCondition,
+	},
+}
+
+// serviceDependencies - the dependency graph of the services, checked by the
+// webhook when a service is enabled and by the reconcile before reconciling it
+var serviceDependencies = []serviceDependency{
+	{name: "Keystone", path: []string{"keystone", "enabled"},
+		requires: []string{"Galera", "Memcached", "RabbitMQ"}},
+	{name: "Glance", path: []string{"glance", "enabled"},
+		requires: []string{"Galera", "Memcached", "Keystone"}},
+	{name: "Cinder", path: []string{"cinder", "enabled"},
+		requires: []string{"Galera", "Memcached", "RabbitMQ", "Keystone"}},
+	{name: "Placement", path: []string{"placement", "enabled"},
+		requires: []string{"Galera", "Memcached", "Keystone"}},
+	{name: "Neutron", path: []string{"neutron", "enabled"},
+		requires: []string{"Galera", "Memcached", "RabbitMQ", "Keystone"}},
+	{name: "Nova", path: []string{"nova", "enabled"},
+		requires: []string{"Galera", "Memcached", "RabbitMQ", "Keystone", "Glance", "Neutron", "Placement"}},
+	{name: "Heat", path: []string{"heat", "enabled"},
+		requires: []string{"Galera", "Memcached", "RabbitMQ", "Keystone"}},
+	{name: "Swift", path: []string{"swift", "enabled"},
+		requires: []string{"Memcached", "Keystone"}},
+	{name: "Horizon", path: []string{"horizon", "enabled"},
+		requires: []string{"Galera", "Memcached", "Keystone"}},
+	// TODO(beagles): So far we haven't declared Redis as dependency for Octavia, but we might.
+	{name: "Octavia", path: []string{"octavia", "enabled"},
+		requires: []string{"Galera", "Memcached", "RabbitMQ", "Keystone", "Glance", "Neutron", "Nova"}},
+	{name: "Barbican", path: []string{"barbican", "enabled"},
+		requires: []string{"Galera", "Keystone"}},
+	{name: "Telemetry.Ceilometer", path: []string{"telemetry", "template", "ceilometer", "enabled"},
+		requires: []string{"RabbitMQ", "Keystone"}},
+	{name: "Telemetry.Autoscaling", path: []string{"telemetry", "template", "autoscaling", "enabled"},
+		requires: []string{"Galera", "Heat", "RabbitMQ", "Keystone"}},
+	{name: "Watcher", path: []string{"watcher", "enabled"},
+		requires: []string{"Galera", "Memcached", "RabbitMQ", "Keystone", "Telemetry", "Telemetry.Ceilometer", "Telemetry.MetricStorage"}},
+}
+
+// isServiceEnabled - returns true if the service is enabled in the spec
+func (r *OpenStackControlPlane) isServiceEnabled(name string) bool {
+	service, ok := controlPlaneServices[name]
+	return ok && service.enabled(&r.Spec)
+}
+
+// GetServiceDependencies - returns the services required by the enabled
+// service and its enabled components, e.g. Telemetry.Autoscaling for Telemetry
+func (r *OpenStackControlPlane) GetServiceDependencies(name string) []string {
+	requires := []string{}
+	for _, dep := range serviceDependencies {
+		if (dep.name != name && !strings.HasPrefix(dep.name, name+".")) || !r.isServiceEnabled(dep.name) {
+			continue
+		}
+		for _, required := range dep.requires {
+			// a component of the service itself is not a dependency
+			if required == name || strings.HasPrefix(required, name+".") {
+				continue
+			}
+			found := false
+			for _, existing := range requires {
+				if existing == required {
+					found = true
+					break
+				}
+			}
+			if !found {
+				requires = append(requires, required)
+			}
+		}
+	}
+	return requires
+}
+
+// GetServiceDependenciesNotReady - returns the services required by the
+// service which are disabled, and when checkReady is set the ones whose Ready
+// condition is not true yet
+func (r *OpenStackControlPlane) GetServiceDependenciesNotReady(name string, checkReady bool) []string {
+	notReady := []string{}
+	for _, required := range r.GetServiceDependencies(name) {
+		if !r.isServiceEnabled(required) {
+			notReady = append(notReady, required+" (disabled)")
+		} else if checkReady &&
+			!r.Status.Conditions.IsTrue(controlPlaneServices[required].readyCondition) {
+			notReady = append(notReady, required)
+		}
+	}
+	return notReady
+}
+
+// GetServiceReadyCondition - returns the condition of the OpenStackControlPlane
+// reflecting the service is ready, the empty string for an unknown service
+func GetServiceReadyCondition(name string) condition.Type {
+	return controlPlaneServices[name].readyCondition
+}
+
+// IsWaitingForDependencies - returns true unless the
+// WaitForDependenciesAnnotation is set to "false", reconciling a service is
+// then held back until its dependencies are ready
+func (r *OpenStackControlPlane) IsWaitingForDependencies() bool {
+	return !strings.EqualFold(r.GetAnnotations()[WaitForDependenciesAnnotation], "false")
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"sort"
+)
+
+// ManagementState - whether the openstack-operator manages a service
+type ManagementState string
+
+const (
+	// ManagementStateManaged - the openstack-operator creates and updates the
+	// service CR from the spec
+	ManagementStateManaged ManagementState = "Managed"
+	// ManagementStateUnmanaged - the openstack-operator leaves the service CR
+	// as it is and only reports its status. The service is left out of minor
+	// updates, its images are not updated.
+	ManagementStateUnmanaged ManagementState = "Unmanaged"
+)
+
+// managedService - a service of the OpenStackControlPlane with a management state
+type managedService struct {
+	// enabled - true when the service is enabled in the spec
+	enabled bool
+	// state - the management state of the service in the spec
+	state ManagementState
+}
+
+// managedServices - returns the services with a management state by name
+func (r *OpenStackControlPlane) managedServices() map[string]managedService {
+	return map[string]managedService{
+		"Barbican":  {r.Spec.Barbican.Enabled, r.Spec.Barbican.ManagementState},
+		"Cinder":    {r.Spec.Cinder.Enabled, r.Spec.Cinder.ManagementState},
+		"DNS":       {r.Spec.DNS.Enabled, r.Spec.DNS.ManagementState},
+		"Designate": {r.Spec.Designate.Enabled, r.Spec.Designate.ManagementState},
+		"Galera":    {r.Spec.Galera.Enabled, r.Spec.Galera.ManagementState},
+		"Glance":    {r.Spec.Glance.Enabled, r.Spec.Glance.ManagementState},
+		"Heat":      {r.Spec.Heat.Enabled, r.Spec.Heat.ManagementState},
+		"Horizon":   {r.Spec.Horizon.Enabled, r.Spec.Horizon.ManagementState},
+		"Ironic":    {r.Spec.Ironic.Enabled, r.Spec.Ironic.ManagementState},
+		"Keystone":  {r.Spec.Keystone.Enabled, r.Spec.Keystone.ManagementState},
+		"Manila":    {r.Spec.Manila.Enabled, r.Spec.Manila.ManagementState},
+		"Memcached": {r.Spec.Memcached.Enabled, r.Spec.Memcached.ManagementState},
+		"Neutron":   {r.Spec.Neutron.Enabled, r.Spec.Neutron.ManagementState},
+		"Nova":      {r.Spec.Nova.Enabled, r.Spec.Nova.ManagementState},
+		"OVN":       {r.Spec.Ovn.Enabled, r.Spec.Ovn.ManagementState},
+		"Octavia":   {r.Spec.Octavia.Enabled, r.Spec.Octavia.ManagementState},
+		"Placement": {r.Spec.Placement.Enabled, r.Spec.Placement.ManagementState},
+		"RabbitMQ":  {r.Spec.Rabbitmq.Enabled, r.Spec.Rabbitmq.ManagementState},
+		"Redis":     {r.Spec.Redis.Enabled, r.Spec.Redis.ManagementState},
+		"Swift":     {r.Spec.Swift.Enabled, r.Spec.Swift.ManagementState},
+		"Telemetry": {r.Spec.Telemetry.Enabled, r.Spec.Telemetry.ManagementState},
+		"Watcher":   {r.Spec.Watcher.Enabled, r.Spec.Watcher.ManagementState},
+	}
+}
+
+// IsServiceUnmanaged - returns true if the service is enabled and the
+// openstack-operator must not change it
+func (r *OpenStackControlPlane) IsServiceUnmanaged(name string) bool {
+	service, ok := r.managedServices()[name]
+	return ok && service.enabled && service.state == ManagementStateUnmanaged
+}
+
+// GetUnmanagedServices - returns the sorted names of the enabled services the
+// openstack-operator must not change
+func (r *OpenStackControlPlane) GetUnmanagedServices() []string {
+	unmanaged := []string{}
+	for name := range r.managedServices() {
+		if r.IsServiceUnmanaged(name) {
+			unmanaged = append(unmanaged, name)
+		}
+	}
+	sort.Strings(unmanaged)
+	return unmanaged
+}
@@ -0,0 +1,389 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+
+	barbicanv1 "github.com/openstack-k8s-operators/barbican-operator/api/v1beta1"
+	cinderv1 "github.com/openstack-k8s-operators/cinder-operator/api/v1beta1"
+	designatev1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	glancev1 "github.com/openstack-k8s-operators/glance-operator/api/v1beta1"
+	heatv1 "github.com/openstack-k8s-operators/heat-operator/api/v1beta1"
+	rabbitmqv1 "github.com/openstack-k8s-operators/infra-operator/apis/rabbitmq/v1beta1"
+	ironicv1 "github.com/openstack-k8s-operators/ironic-operator/api/v1beta1"
+	keystonev1 "github.com/openstack-k8s-operators/keystone-operator/api/v1beta1"
+	manilav1 "github.com/openstack-k8s-operators/manila-operator/api/v1beta1"
+	neutronv1 "github.com/openstack-k8s-operators/neutron-operator/api/v1beta1"
+	novav1 "github.com/openstack-k8s-operators/nova-operator/api/nova/v1beta1"
+	octaviav1 "github.com/openstack-k8s-operators/octavia-operator/api/v1beta1"
+	swiftv1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+	telemetryv1 "github.com/openstack-k8s-operators/telemetry-operator/api/v1beta1"
+	watcherv1 "github.com/openstack-k8s-operators/watcher-operator/api/v1beta1"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// messagingService - the messaging bus fields of the template of a service,
+// the accessors initialize the template when it is not set
+type messagingService struct {
+	// enabled - returns true when the service is enabled in the spec
+	enabled func(spec *OpenStackControlPlaneSpec) bool
+	// rpc - returns the bus used for RPC, nil when the service has none
+	rpc func(spec *OpenStackControlPlaneSpec) *rabbitmqv1.RabbitMqConfig
+	// notifications - returns the bus used for notifications, nil when the
+	// service has none
+	notifications func(spec *OpenStackControlPlaneSpec) **rabbitmqv1.RabbitMqConfig
+}
+
+// messagingServices - the services a messaging topology can bind, keyed by the
+// name used in MessagingTopologySection.Services
+var messagingServices = map[string]messagingService{
+	"barbican": {
+		enabled: func(spec *OpenStackControlPlaneSpec) bool { return spec.Barbican.Enabled },
+		rpc: func(spec *OpenStackControlPlaneSpec) *rabbitmqv1.RabbitMqConfig {
+			if spec.Barbican.Template == nil {
+				spec.Barbican.Template = &barbicanv1.BarbicanSpecCore{}
+			}
+			return &spec.Barbican.Template.MessagingBus
+		},
+		notifications: func(spec *OpenStackControlPlaneSpec) **rabbitmqv1.RabbitMqConfig {
+			if spec.Barbican.Template == nil {
+				spec.Barbican.Template = &barbicanv1.BarbicanSpecCore{}
+			}
+			return &spec.Barbican.Template.NotificationsBus
+		},
+	},
+	"cinder": {
+		enabled: func(spec *OpenStackControlPlaneSpec) bool { return spec.Cinder.Enabled },
+		rpc: func(spec *OpenStackControlPlaneSpec) *rabbitmqv1.RabbitMqConfig {
+			if spec.Cinder.Template == nil {
+				spec.Cinder.Template = &cinderv1.CinderSpecCore{}
+			}
+			return &spec.Cinder.Template.MessagingBus
+		},
+		notifications: func(spec *OpenStackControlPlaneSpec) **rabbitmqv1.RabbitMqConfig {
+			if spec.Cinder.Template == nil {
+				spec.Cinder.Template = &cinderv1.CinderSpecCore{}
+			}
+			return &spec.Cinder.Template.NotificationsBus
+		},
+	},
+	"designate": {
+		enabled: func(spec *OpenStackControlPlaneSpec) bool { return spec.Designate.Enabled },
+		rpc: func(spec *OpenStackControlPlaneSpec) *rabbitmqv1.RabbitMqConfig {
+			if spec.Designate.Template == nil {
+				spec.Designate.Template = &designatev1.DesignateSpecCore{}
+			}
+			return &spec.Designate.Template.MessagingBus
+		},
+		notifications: func(spec *OpenStackControlPlaneSpec) **rabbitmqv1.RabbitMqConfig {
+			if spec.Designate.Template == nil {
+				spec.Designate.Template = &designatev1.DesignateSpecCore{}
+			}
+			return &spec.Designate.Template.NotificationsBus
+		},
+	},
+	"glance": {
+		enabled: func(spec *OpenStackControlPlaneSpec) bool { return spec.Glance.Enabled },
+		notifications: func(spec *OpenStackControlPlaneSpec) **rabbitmqv1.RabbitMqConfig {
+			if spec.Glance.Template == nil {
+				spec.Glance.Template = &glancev1.GlanceSpecCore{}
+			}
+			return &spec.Glance.Template.NotificationsBus
+		},
+	},
+	"heat": {
+		enabled: func(spec *OpenStackControlPlaneSpec) bool { return spec.Heat.Enabled },
+		rpc: func(spec *OpenStackControlPlaneSpec) *rabbitmqv1.RabbitMqConfig {
+			if spec.Heat.Template == nil {
+				spec.Heat.Template = &heatv1.HeatSpecCore{}
+			}
+			return &spec.Heat.Template.MessagingBus
+		},
+		notifications: func(spec *OpenStackControlPlaneSpec) **rabbitmqv1.RabbitMqConfig {
+			if spec.Heat.Template == nil {
+				spec.Heat.Template = &heatv1.HeatSpecCore{}
+			}
+			return &spec.Heat.Template.NotificationsBus
+		},
+	},
+	"ironic": {
+		enabled: func(spec *OpenStackControlPlaneSpec) bool { return spec.Ironic.Enabled },
+		// the ironic neutron agent inherits the bus of ironic
+		rpc: func(spec *OpenStackControlPlaneSpec) *rabbitmqv1.RabbitMqConfig {
+			if spec.Ironic.Template == nil {
+				spec.Ironic.Template = &ironicv1.IronicSpecCore{}
+			}
+			return &spec.Ironic.Template.MessagingBus
+		},
+	},
+	"keystone": {
+		enabled: func(spec *OpenStackControlPlaneSpec) bool { return spec.Keystone.Enabled },
+		notifications: func(spec *OpenStackControlPlaneSpec) **rabbitmqv1.RabbitMqConfig {
+			if spec.Keystone.Template == nil {
+				spec.Keystone.Template = &keystonev1.KeystoneAPISpecCore{}
+			}
+			return &spec.Keystone.Template.NotificationsBus
+		},
+	},
+	"manila": {
+		enabled: func(spec *OpenStackControlPlaneSpec) bool { return spec.Manila.Enabled },
+		rpc: func(spec *OpenStackControlPlaneSpec) *rabbitmqv1.RabbitMqConfig {
+			if spec.Manila.Template == nil {
+				spec.Manila.Template = &manilav1.ManilaSpecCore{}
+			}
+			return &spec.Manila.Template.MessagingBus
+		},
+		notifications: func(spec *OpenStackControlPlaneSpec) **rabbitmqv1.RabbitMqConfig {
+			if spec.Manila.Template == nil {
+				spec.Manila.Template = &manilav1.ManilaSpecCore{}
+			}
+			return &spec.Manila.Template.NotificationsBus
+		},
+	},
+	"neutron": {
+		enabled: func(spec *OpenStackControlPlaneSpec) bool { return spec.Neutron.Enabled },
+		rpc: func(spec *OpenStackControlPlaneSpec) *rabbitmqv1.RabbitMqConfig {
+			if spec.Neutron.Template == nil {
+				spec.Neutron.Template = &neutronv1.NeutronAPISpecCore{}
+			}
+			return &spec.Neutron.Template.MessagingBus
+		},
+		notifications: func(spec *OpenStackControlPlaneSpec) **rabbitmqv1.RabbitMqConfig {
+			if spec.Neutron.Template == nil {
+				spec.Neutron.Template = &neutronv1.NeutronAPISpecCore{}
+			}
+			return &spec.Neutron.Template.NotificationsBus
+		},
+	},
+	"nova": {
+		enabled: func(spec *OpenStackControlPlaneSpec) bool { return spec.Nova.Enabled },
+		// the bus of the Nova API, cell0 inherits it
+		rpc: func(spec *OpenStackControlPlaneSpec) *rabbitmqv1.RabbitMqConfig {
+			if spec.Nova.Template == nil {
+				spec.Nova.Template = &novav1.NovaSpecCore{}
+			}
+			return &spec.Nova.Template.MessagingBus
+		},
+		notifications: func(spec *OpenStackControlPlaneSpec) **rabbitmqv1.RabbitMqConfig {
+			if spec.Nova.Template == nil {
+				spec.Nova.Template = &novav1.NovaSpecCore{}
+			}
+			return &spec.Nova.Template.NotificationsBus
+		},
+	},
+	"octavia": {
+		enabled: func(spec *OpenStackControlPlaneSpec) bool { return spec.Octavia.Enabled },
+		rpc: func(spec *OpenStackControlPlaneSpec) *rabbitmqv1.RabbitMqConfig {
+			if spec.Octavia.Template == nil {
+				spec.Octavia.Template = &octaviav1.OctaviaSpecCore{}
+			}
+			return &spec.Octavia.Template.MessagingBus
+		},
+		notifications: func(spec *OpenStackControlPlaneSpec) **rabbitmqv1.RabbitMqConfig {
+			if spec.Octavia.Template == nil {
+				spec.Octavia.Template = &octaviav1.OctaviaSpecCore{}
+			}
+			return &spec.Octavia.Template.NotificationsBus
+		},
+	},
+	"swift": {
+		enabled: func(spec *OpenStackControlPlaneSpec) bool { return spec.Swift.Enabled },
+		notifications: func(spec *OpenStackControlPlaneSpec) **rabbitmqv1.RabbitMqConfig {
+			if spec.Swift.Template == nil {
+				spec.Swift.Template = &swiftv1.SwiftSpecCore{}
+			}
+			return &spec.Swift.Template.SwiftProxy.NotificationsBus
+		},
+	},
+	"watcher": {
+		enabled: func(spec *OpenStackControlPlaneSpec) bool { return spec.Watcher.Enabled },
+		rpc: func(spec *OpenStackControlPlaneSpec) *rabbitmqv1.RabbitMqConfig {
+			if spec.Watcher.Template == nil {
+				spec.Watcher.Template = &watcherv1.WatcherSpecCore{}
+			}
+			return &spec.Watcher.Template.MessagingBus
+		},
+		notifications: func(spec *OpenStackControlPlaneSpec) **rabbitmqv1.RabbitMqConfig {
+			if spec.Watcher.Template == nil {
+				spec.Watcher.Template = &watcherv1.WatcherSpecCore{}
+			}
+			return &spec.Watcher.Template.NotificationsBus
+		},
+	},
+	"aodh": {
+		enabled: func(spec *OpenStackControlPlaneSpec) bool { return spec.Telemetry.Enabled },
+		notifications: func(spec *OpenStackControlPlaneSpec) **rabbitmqv1.RabbitMqConfig {
+			if spec.Telemetry.Template == nil {
+				spec.Telemetry.Template = &telemetryv1.TelemetrySpecCore{}
+			}
+			return &spec.Telemetry.Template.Autoscaling.Aodh.NotificationsBus
+		},
+	},
+	"ceilometer": {
+		enabled: func(spec *OpenStackControlPlaneSpec) bool { return spec.Telemetry.Enabled },
+		notifications: func(spec *OpenStackControlPlaneSpec) **rabbitmqv1.RabbitMqConfig {
+			if spec.Telemetry.Template == nil {
+				spec.Telemetry.Template = &telemetryv1.TelemetrySpecCore{}
+			}
+			return &spec.Telemetry.Template.Ceilometer.NotificationsBus
+		},
+	},
+	"cloudkitty": {
+		enabled: func(spec *OpenStackControlPlaneSpec) bool { return spec.Telemetry.Enabled },
+		rpc: func(spec *OpenStackControlPlaneSpec) *rabbitmqv1.RabbitMqConfig {
+			if spec.Telemetry.Template == nil {
+				spec.Telemetry.Template = &telemetryv1.TelemetrySpecCore{}
+			}
+			return &spec.Telemetry.Template.CloudKitty.MessagingBus
+		},
+	},
+}
+
+// ApplyMessagingTopology - propagates the buses of the messaging topology into
+// the templates of the enabled services and Nova cells not setting their own.
+// The reconcile applies it in memory, the spec stored is left unchanged.
+func (r *OpenStackControlPlane) ApplyMessagingTopology() {
+	topology := r.Spec.MessagingTopology
+	if topology == nil {
+		return
+	}
+
+	for _, name := range sortedKeys(messagingServices) {
+		service := messagingServices[name]
+		if !service.enabled(&r.Spec) {
+			continue
+		}
+		binding := topology.Services[name]
+
+		if bus, ok := topology.Buses[binding.RPC]; ok && service.rpc != nil {
+			if rpc := service.rpc(&r.Spec); rpc.Cluster == "" {
+				*rpc = bus
+			}
+		}
+
+		notificationsBus := binding.Notifications
+		if notificationsBus == "" {
+			notificationsBus = topology.Notifications
+		}
+		if bus, ok := topology.Buses[notificationsBus]; ok && service.notifications != nil {
+			if notifications := service.notifications(&r.Spec); *notifications == nil || (*notifications).Cluster == "" {
+				*notifications = bus.DeepCopy()
+			}
+		}
+	}
+
+	if r.Spec.Nova.Enabled && r.Spec.Nova.Template != nil {
+		for cellName, busName := range topology.NovaCells {
+			cellTemplate, exists := r.Spec.Nova.Template.CellTemplates[cellName]
+			bus, ok := topology.Buses[busName]
+			if !exists || !ok || cellTemplate.MessagingBus.Cluster != "" {
+				continue
+			}
+			cellTemplate.MessagingBus = bus
+			r.Spec.Nova.Template.CellTemplates[cellName] = cellTemplate
+		}
+	}
+}
+
+// ValidateMessagingTopology - returns an error for each bus of the messaging
+// topology not matching a rabbitmq template and for each reference to an
+// unknown bus, service or Nova cell
+func (r *OpenStackControlPlane) ValidateMessagingTopology(basePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	topology := r.Spec.MessagingTopology
+	if topology == nil {
+		return allErrs
+	}
+	topologyPath := basePath.Child("messagingTopology")
+
+	for _, name := range sortedKeys(topology.Buses) {
+		bus := topology.Buses[name]
+		busPath := topologyPath.Child("buses").Key(name)
+		if bus.User != "" {
+			allErrs = append(allErrs, field.Forbidden(
+				busPath.Child("user"),
+				"user field is not allowed in a messaging topology bus. Each service operator creates its own TransportURL with a unique user."))
+		}
+		if bus.Cluster == "" {
+			allErrs = append(allErrs, field.Required(busPath.Child("cluster"), "the cluster of a bus is required"))
+			continue
+		}
+		if r.Spec.Rabbitmq.Templates == nil {
+			allErrs = append(allErrs, field.Invalid(busPath.Child("cluster"), bus.Cluster,
+				"cluster must match an existing RabbitMQ instance name"))
+			continue
+		}
+		if _, exists := (*r.Spec.Rabbitmq.Templates)[bus.Cluster]; !exists {
+			allErrs = append(allErrs, field.Invalid(busPath.Child("cluster"), bus.Cluster,
+				"cluster must match an existing RabbitMQ instance name"))
+		}
+	}
+
+	validateBus := func(path *field.Path, name string) {
+		if _, exists := topology.Buses[name]; !exists {
+			allErrs = append(allErrs, field.Invalid(path, name,
+				"must match a bus of messagingTopology.buses"))
+		}
+	}
+
+	for _, name := range sortedKeys(topology.Services) {
+		binding := topology.Services[name]
+		servicePath := topologyPath.Child("services").Key(name)
+		service, known := messagingServices[name]
+		if !known {
+			allErrs = append(allErrs, field.NotSupported(servicePath, name, sortedKeys(messagingServices)))
+			continue
+		}
+		if binding.RPC != "" {
+			if service.rpc == nil {
+				allErrs = append(allErrs, field.Forbidden(servicePath.Child("rpc"),
+					fmt.Sprintf("%s does not use a messaging bus for RPC", name)))
+			} else {
+				validateBus(servicePath.Child("rpc"), binding.RPC)
+			}
+		}
+		if binding.Notifications != "" {
+			if service.notifications == nil {
+				allErrs = append(allErrs, field.Forbidden(servicePath.Child("notifications"),
+					fmt.Sprintf("%s does not use a messaging bus for notifications", name)))
+			} else {
+				validateBus(servicePath.Child("notifications"), binding.Notifications)
+			}
+		}
+	}
+
+	for _, cellName := range sortedKeys(topology.NovaCells) {
+		cellPath := topologyPath.Child("novaCells").Key(cellName)
+		validateBus(cellPath, topology.NovaCells[cellName])
+		if r.Spec.Nova.Template == nil {
+			continue
+		}
+		if _, exists := r.Spec.Nova.Template.CellTemplates[cellName]; !exists {
+			allErrs = append(allErrs, field.Invalid(cellPath, cellName,
+				"must match a cell of nova.template.cellTemplates"))
+		}
+	}
+
+	if topology.Notifications != "" {
+		validateBus(topologyPath.Child("notifications"), topology.Notifications)
+	}
+
+	return allErrs
+}
@@ -0,0 +1,235 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"strings"
+
+	rabbitmqv1 "github.com/openstack-k8s-operators/infra-operator/apis/rabbitmq/v1beta1"
+	mariadbv1 "github.com/openstack-k8s-operators/mariadb-operator/api/v1beta1"
+	novav1 "github.com/openstack-k8s-operators/nova-operator/api/nova/v1beta1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// NovaCell0Name - the name of the cell0 of Nova
+	NovaCell0Name = "cell0"
+
+	// novaCellSharedMinReplicas - the replicas of a Galera or RabbitMQ
+	// instance shared by several Nova cells below which they all go down
+	// together with a single pod
+	novaCellSharedMinReplicas = 3
+)
+
+// GetCellGalera - returns the name of the Galera instance hosting the
+// database of the declared cell
+func (n *NovaSection) GetCellGalera(cellName string) string {
+	if cell := n.Cells[cellName]; cell.Galera != "" {
+		return cell.Galera
+	}
+	if cellName == NovaCell0Name {
+		return "openstack"
+	}
+	return "openstack-" + cellName
+}
+
+// GetCellRabbitmq - returns the name of the RabbitMQ instance used by the
+// declared cell, the empty string for a cell0 using the one of the Nova API
+func (n *NovaSection) GetCellRabbitmq(cellName string) string {
+	if cell := n.Cells[cellName]; cell.Rabbitmq != "" {
+		return cell.Rabbitmq
+	}
+	if cellName == NovaCell0Name {
+		return ""
+	}
+	return "rabbitmq-" + cellName
+}
+
+// getNovaCellGalera - returns the name of the Galera instance hosting the
+// database of the declared cell, the cellDatabaseInstance of its cell template
+// when the cell doesn't set one
+func (r *OpenStackControlPlane) getNovaCellGalera(cellName string) string {
+	if r.Spec.Nova.Cells[cellName].Galera == "" && r.Spec.Nova.Template != nil {
+		if galera := r.Spec.Nova.Template.CellTemplates[cellName].CellDatabaseInstance; galera != "" {
+			return galera
+		}
+	}
+	return r.Spec.Nova.GetCellGalera(cellName)
+}
+
+// getNovaCellBus - returns the bus of the messaging topology the Nova cell is
+// bound to, if any
+func (r *OpenStackControlPlane) getNovaCellBus(cellName string) (string, bool) {
+	if r.Spec.MessagingTopology == nil {
+		return "", false
+	}
+	busName, bound := r.Spec.MessagingTopology.NovaCells[cellName]
+	return busName, bound
+}
+
+// DefaultNovaCells - generates the cell templates of the declared Nova cells
+// and creates the galera and rabbitmq templates they use when missing. The
+// database instance of a cell is only defaulted when the cell template doesn't
+// set it, the messaging bus when neither the cell template nor the messaging
+// topology set it. An explicit galera or rabbitmq of the cell always applies.
+func (r *OpenStackControlPlane) DefaultNovaCells() {
+	if len(r.Spec.Nova.Cells) == 0 || (!r.Spec.Nova.Enabled && r.Spec.Nova.Template == nil) {
+		return
+	}
+	if r.Spec.Nova.Template == nil {
+		r.Spec.Nova.Template = &novav1.NovaSpecCore{}
+	}
+	if r.Spec.Nova.Template.CellTemplates == nil {
+		r.Spec.Nova.Template.CellTemplates = map[string]novav1.NovaCellTemplate{}
+	}
+
+	for _, cellName := range sortedKeys(r.Spec.Nova.Cells) {
+		cell := r.Spec.Nova.Cells[cellName]
+
+		cellTemplate, exists := r.Spec.Nova.Template.CellTemplates[cellName]
+		if !exists {
+			cellTemplate = novav1.NovaCellTemplate{
+				CellDatabaseAccount: "nova-" + cellName,
+				HasAPIAccess:        true,
+			}
+		}
+
+		galera := r.getNovaCellGalera(cellName)
+		cellTemplate.CellDatabaseInstance = galera
+		if r.Spec.Galera.Enabled {
+			if r.Spec.Galera.Templates == nil {
+				r.Spec.Galera.Templates = ptr.To(map[string]mariadbv1.GaleraSpecCore{})
+			}
+			if _, exists := (*r.Spec.Galera.Templates)[galera]; !exists {
+				(*r.Spec.Galera.Templates)[galera] = mariadbv1.GaleraSpecCore{}
+			}
+		}
+
+		rabbitmq := r.Spec.Nova.GetCellRabbitmq(cellName)
+		if _, bound := r.getNovaCellBus(cellName); cell.Rabbitmq == "" && (bound ||
+			cellTemplate.MessagingBus.Cluster != "" || cellTemplate.CellMessageBusInstance != "") {
+			rabbitmq = ""
+		}
+		if rabbitmq != "" {
+			cellTemplate.MessagingBus.Cluster = rabbitmq
+			cellTemplate.CellMessageBusInstance = ""
+			if r.Spec.Rabbitmq.Enabled {
+				if r.Spec.Rabbitmq.Templates == nil {
+					r.Spec.Rabbitmq.Templates = &map[string]rabbitmqv1.RabbitMqSpecCore{}
+				}
+				if _, exists := (*r.Spec.Rabbitmq.Templates)[rabbitmq]; !exists {
+					(*r.Spec.Rabbitmq.Templates)[rabbitmq] = rabbitmqv1.RabbitMqSpecCore{}
+				}
+			}
+		}
+
+		if cell.NoVNCProxy != nil {
+			if cell.NoVNCProxy.Enabled != nil {
+				cellTemplate.NoVNCProxyServiceTemplate.Enabled = ptr.To(*cell.NoVNCProxy.Enabled)
+			}
+			if cell.NoVNCProxy.Override != nil {
+				if r.Spec.Nova.CellOverride == nil {
+					r.Spec.Nova.CellOverride = map[string]NovaCellOverrideSpec{}
+				}
+				// Merge into the override of the cell, keeping what is set
+				// on it directly
+				cellOverride := r.Spec.Nova.CellOverride[cellName]
+				if cell.NoVNCProxy.Override.Route != nil {
+					cellOverride.NoVNCProxy.Route = cell.NoVNCProxy.Override.Route.DeepCopy()
+				}
+				if cell.NoVNCProxy.Override.TLS != nil {
+					cellOverride.NoVNCProxy.TLS = cell.NoVNCProxy.Override.TLS.DeepCopy()
+				}
+				r.Spec.Nova.CellOverride[cellName] = cellOverride
+			}
+		}
+
+		// By-value copy, need to update
+		r.Spec.Nova.Template.CellTemplates[cellName] = cellTemplate
+	}
+}
+
+// ValidateNovaCells - returns a warning for each Galera or RabbitMQ instance
+// shared by several declared Nova cells without the replicas to survive the
+// loss of a pod, and an error for a noVNCProxy declared for cell0 or a
+// rabbitmq declared for a cell bound by messagingTopology.novaCells
+func (r *OpenStackControlPlane) ValidateNovaCells(basePath *field.Path) ([]string, field.ErrorList) {
+	var allWarn []string
+	var allErrs field.ErrorList
+
+	if !r.Spec.Nova.Enabled || len(r.Spec.Nova.Cells) == 0 {
+		return allWarn, allErrs
+	}
+	cellsPath := basePath.Child("nova").Child("cells")
+
+	galeraCells := map[string][]string{}
+	rabbitmqCells := map[string][]string{}
+	for _, cellName := range sortedKeys(r.Spec.Nova.Cells) {
+		cell := r.Spec.Nova.Cells[cellName]
+		galera := r.getNovaCellGalera(cellName)
+		galeraCells[galera] = append(galeraCells[galera], cellName)
+		rabbitmq := r.Spec.Nova.GetCellRabbitmq(cellName)
+		if busName, bound := r.getNovaCellBus(cellName); bound {
+			if cell.Rabbitmq != "" {
+				allErrs = append(allErrs, field.Invalid(cellsPath.Key(cellName).Child("rabbitmq"), cell.Rabbitmq,
+					fmt.Sprintf("conflicts with the bus %s of messagingTopology.novaCells", busName)))
+			}
+			rabbitmq = r.Spec.MessagingTopology.Buses[busName].Cluster
+		}
+		if rabbitmq != "" {
+			rabbitmqCells[rabbitmq] = append(rabbitmqCells[rabbitmq], cellName)
+		}
+
+		if cellName == NovaCell0Name && cell.NoVNCProxy != nil &&
+			(cell.NoVNCProxy.Override != nil || ptr.Deref(cell.NoVNCProxy.Enabled, false)) {
+			allErrs = append(allErrs, field.Forbidden(cellsPath.Key(cellName).Child("noVNCProxy"),
+				"cell0 has no compute nodes and therefore no noVNCProxy"))
+		}
+	}
+
+	if r.Spec.Galera.Enabled && r.Spec.Galera.Templates != nil {
+		for _, galera := range sortedKeys(galeraCells) {
+			template, exists := (*r.Spec.Galera.Templates)[galera]
+			if exists {
+				allWarn = append(allWarn, novaCellCapacityWarning(
+					"galera", galera, ptr.Deref(template.Replicas, 1), galeraCells[galera])...)
+			}
+		}
+	}
+	if r.Spec.Rabbitmq.Enabled && r.Spec.Rabbitmq.Templates != nil {
+		for _, rabbitmq := range sortedKeys(rabbitmqCells) {
+			template, exists := (*r.Spec.Rabbitmq.Templates)[rabbitmq]
+			if exists {
+				allWarn = append(allWarn, novaCellCapacityWarning(
+					"rabbitmq", rabbitmq, ptr.Deref(template.Replicas, 1), rabbitmqCells[rabbitmq])...)
+			}
+		}
+	}
+
+	return allWarn, allErrs
+}
+
+func novaCellCapacityWarning(kind string, name string, replicas int32, cells []string) []string {
+	if len(cells) < 2 || replicas >= novaCellSharedMinReplicas {
+		return nil
+	}
+	return []string{fmt.Sprintf(
+		"%s instance %s is shared by the nova cells %s with %d replicas, at least %d replicas are recommended",
+		kind, name, strings.Join(cells, ", "), replicas, novaCellSharedMinReplicas)}
+}
@@ -0,0 +1,223 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// referenceMatcher returns the name of the instance referenced by the key of
+// an object, the empty string when the key is not a reference
+type referenceMatcher func(parentKey string, key string, value interface{}) string
+
+// isGaleraReference matches the databaseInstance, apiDatabaseInstance,
+// cellDatabaseInstance, ... fields of the service templates
+func isGaleraReference(_ string, key string, value interface{}) string {
+	if !strings.HasSuffix(strings.ToLower(key), "databaseinstance") {
+		return ""
+	}
+	name, _ := value.(string)
+	return name
+}
+
+// isRabbitMqReference matches the messagingBus.cluster and
+// notificationsBus.cluster fields as well as the deprecated *BusInstance and
+// rabbitMqClusterName fields of the service templates
+func isRabbitMqReference(parentKey string, key string, value interface{}) string {
+	lowerKey := strings.ToLower(key)
+	if !strings.HasSuffix(lowerKey, "businstance") && lowerKey != "rabbitmqclustername" &&
+		(key != "cluster" || !strings.HasSuffix(strings.ToLower(parentKey), "bus")) {
+		return ""
+	}
+	name, _ := value.(string)
+	return name
+}
+
+// GetGaleraReferences returns, for each Galera instance referenced by the
+// enabled services, the paths of the fields referencing it
+func (r *OpenStackControlPlane) GetGaleraReferences() (map[string][]string, error) {
+	return r.getInstanceReferences(isGaleraReference)
+}
+
+// GetRabbitMqReferences returns, for each RabbitMQ instance referenced by the
+// enabled services, the paths of the fields referencing it
+func (r *OpenStackControlPlane) GetRabbitMqReferences() (map[string][]string, error) {
+	refs, err := r.getInstanceReferences(isRabbitMqReference)
+	if err != nil || r.Spec.MessagingTopology == nil {
+		return refs, err
+	}
+	// The buses of the messaging topology are keyed by their name, they don't
+	// match isRabbitMqReference
+	busesPath := field.NewPath("spec").Child("messagingTopology").Child("buses")
+	for _, name := range sortedKeys(r.Spec.MessagingTopology.Buses) {
+		if cluster := r.Spec.MessagingTopology.Buses[name].Cluster; cluster != "" {
+			refs[cluster] = append(refs[cluster], busesPath.Key(name).Child("cluster").String())
+			sort.Strings(refs[cluster])
+		}
+	}
+	return refs, nil
+}
+
+// IsDeletionConfirmed returns true when the ConfirmDeleteAnnotation lists the
+// Galera or RabbitMQ instance
+func (r *OpenStackControlPlane) IsDeletionConfirmed(name string) bool {
+	confirmed, ok := r.GetAnnotations()[ConfirmDeleteAnnotation]
+	if !ok {
+		return false
+	}
+	for _, confirmedName := range strings.Split(confirmed, ",") {
+		if strings.TrimSpace(confirmedName) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateInstanceDeletion - returns an error for each Galera or RabbitMQ
+// template removed while still referenced by a service, or without its
+// deletion being confirmed with the ConfirmDeleteAnnotation
+func (r *OpenStackControlPlane) ValidateInstanceDeletion(old OpenStackControlPlaneSpec, basePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	// Galera instances are only deleted while galera is enabled
+	if r.Spec.Galera.Enabled && old.Galera.Templates != nil {
+		var templates map[string]struct{}
+		if r.Spec.Galera.Templates != nil {
+			templates = make(map[string]struct{}, len(*r.Spec.Galera.Templates))
+			for name := range *r.Spec.Galera.Templates {
+				templates[name] = struct{}{}
+			}
+		}
+		refs, err := r.GetGaleraReferences()
+		if err != nil {
+			return append(allErrs, field.InternalError(basePath, err))
+		}
+		allErrs = append(allErrs, r.validateInstanceDeletion(
+			"galera", sortedKeys(*old.Galera.Templates), templates, refs,
+			basePath.Child("galera").Child("templates"))...)
+	}
+
+	if old.Rabbitmq.Templates != nil {
+		var templates map[string]struct{}
+		if r.Spec.Rabbitmq.Templates != nil {
+			templates = make(map[string]struct{}, len(*r.Spec.Rabbitmq.Templates))
+			for name := range *r.Spec.Rabbitmq.Templates {
+				templates[name] = struct{}{}
+			}
+		}
+		refs, err := r.GetRabbitMqReferences()
+		if err != nil {
+			return append(allErrs, field.InternalError(basePath, err))
+		}
+		allErrs = append(allErrs, r.validateInstanceDeletion(
+			"rabbitmq", sortedKeys(*old.Rabbitmq.Templates), templates, refs,
+			basePath.Child("rabbitmq").Child("templates"))...)
+	}
+
+	return allErrs
+}
+
+func (r *OpenStackControlPlane) validateInstanceDeletion(
+	kind string,
+	oldNames []string,
+	templates map[string]struct{},
+	refs map[string][]string,
+	templatesPath *field.Path,
+) field.ErrorList {
+	var allErrs field.ErrorList
+	for _, name := range oldNames {
+		if _, exists := templates[name]; exists {
+			continue
+		}
+		if paths, referenced := refs[name]; referenced {
+			allErrs = append(allErrs, field.Forbidden(templatesPath.Key(name), fmt.Sprintf(
+				"%s instance %s is still referenced by %s", kind, name, strings.Join(paths, ", "))))
+		} else if !r.IsDeletionConfirmed(name) {
+			allErrs = append(allErrs, field.Forbidden(templatesPath.Key(name), fmt.Sprintf(
+				"deleting %s instance %s requires the %s annotation to list it",
+				kind, name, ConfirmDeleteAnnotation)))
+		}
+	}
+	return allErrs
+}
+
+// getInstanceReferences walks the spec of the services, skipping the disabled
+// ones, and collects the fields matched by isReference
+func (r *OpenStackControlPlane) getInstanceReferences(isReference referenceMatcher) (map[string][]string, error) {
+	refs := map[string][]string{}
+
+	data, err := json.Marshal(r.Spec)
+	if err != nil {
+		return refs, err
+	}
+	spec := map[string]interface{}{}
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return refs, err
+	}
+	// The instances themselves are not references
+	delete(spec, "galera")
+	delete(spec, "rabbitmq")
+	// The messaging topology is collected by GetRabbitMqReferences
+	delete(spec, "messagingTopology")
+
+	collectInstanceReferences(spec, "", field.NewPath("spec"), isReference, refs)
+	for _, paths := range refs {
+		sort.Strings(paths)
+	}
+	return refs, nil
+}
+
+func collectInstanceReferences(
+	value interface{},
+	key string,
+	path *field.Path,
+	isReference referenceMatcher,
+	refs map[string][]string,
+) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if enabled, ok := v["enabled"].(bool); ok && !enabled {
+			return
+		}
+		for _, childKey := range sortedKeys(v) {
+			childPath := path.Child(childKey)
+			if name := isReference(key, childKey, v[childKey]); name != "" {
+				refs[name] = append(refs[name], childPath.String())
+				continue
+			}
+			collectInstanceReferences(v[childKey], childKey, childPath, isReference, refs)
+		}
+	case []interface{}:
+		for i, item := range v {
+			collectInstanceReferences(item, key, path.Index(i), isReference, refs)
+		}
+	}
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
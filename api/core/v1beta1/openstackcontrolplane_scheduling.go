@@ -0,0 +1,251 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"maps"
+
+	topologyv1 "github.com/openstack-k8s-operators/infra-operator/apis/topology/v1beta1"
+	k8s_corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PodAntiAffinityPreset - a pod anti-affinity spreading the pods of an
+// instance across the worker nodes
+type PodAntiAffinityPreset string
+
+const (
+	// PodAntiAffinityPreferred - the pods of an instance run on different
+	// worker nodes when possible
+	PodAntiAffinityPreferred PodAntiAffinityPreset = "Preferred"
+	// PodAntiAffinityRequired - the pods of an instance always run on
+	// different worker nodes
+	PodAntiAffinityRequired PodAntiAffinityPreset = "Required"
+)
+
+// The scheduling of the pods of a template is set with, in order of precedence:
+//   - the value set in the template
+//   - the value set in spec.scheduling
+//   - the deprecated spec.nodeSelector and spec.topologyRef
+
+// GetSchedulingNodeSelector - returns the nodeSelector of the templates not
+// setting their own
+func (r *OpenStackControlPlane) GetSchedulingNodeSelector() *map[string]string {
+	if r.Spec.Scheduling != nil && r.Spec.Scheduling.NodeSelector != nil {
+		return r.Spec.Scheduling.NodeSelector
+	}
+	return &r.Spec.NodeSelector
+}
+
+// GetSchedulingTopologyRef - returns the topologyRef of the templates not
+// referencing their own
+func (r *OpenStackControlPlane) GetSchedulingTopologyRef() *topologyv1.TopoRef {
+	if r.Spec.Scheduling != nil && r.Spec.Scheduling.TopologyRef != nil {
+		return r.Spec.Scheduling.TopologyRef
+	}
+	return r.Spec.TopologyRef
+}
+
+// GetSchedulingTolerations - returns the tolerations of the templates not
+// setting their own
+func (r *OpenStackControlPlane) GetSchedulingTolerations() []k8s_corev1.Toleration {
+	if r.Spec.Scheduling == nil {
+		return nil
+	}
+	return r.Spec.Scheduling.Tolerations
+}
+
+// GetSchedulingPodAntiAffinity - returns the pod anti-affinity preset of the
+// templates not setting their own affinity
+func (r *OpenStackControlPlane) GetSchedulingPodAntiAffinity() PodAntiAffinityPreset {
+	if r.Spec.Scheduling == nil {
+		return ""
+	}
+	return r.Spec.Scheduling.PodAntiAffinity
+}
+
+// GetPodAntiAffinity - returns the affinity of the preset spreading the pods
+// matching labels across the worker nodes, nil without a preset
+func GetPodAntiAffinity(preset PodAntiAffinityPreset, labels map[string]string) *k8s_corev1.Affinity {
+	term := k8s_corev1.PodAffinityTerm{
+		LabelSelector: &metav1.LabelSelector{MatchLabels: labels},
+		TopologyKey:   k8s_corev1.LabelHostname,
+	}
+	switch preset {
+	case PodAntiAffinityPreferred:
+		return &k8s_corev1.Affinity{
+			PodAntiAffinity: &k8s_corev1.PodAntiAffinity{
+				PreferredDuringSchedulingIgnoredDuringExecution: []k8s_corev1.WeightedPodAffinityTerm{
+					{Weight: 100, PodAffinityTerm: term},
+				},
+			},
+		}
+	case PodAntiAffinityRequired:
+		return &k8s_corev1.Affinity{
+			PodAntiAffinity: &k8s_corev1.PodAntiAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: []k8s_corev1.PodAffinityTerm{term},
+			},
+		}
+	}
+	return nil
+}
+
+// ignoredSchedulingFields - returns the fields of spec.scheduling set but not
+// applied to a template, the tolerations and the pod anti-affinity preset when
+// the template has no tolerations and affinity
+func (r *OpenStackControlPlane) ignoredSchedulingFields(supportsAffinity bool) []string {
+	ignored := []string{}
+	if !supportsAffinity {
+		if len(r.GetSchedulingTolerations()) > 0 {
+			ignored = append(ignored, "tolerations")
+		}
+		if r.GetSchedulingPodAntiAffinity() != "" {
+			ignored = append(ignored, "podAntiAffinity")
+		}
+	}
+	if len(ignored) == 0 {
+		return nil
+	}
+	return ignored
+}
+
+// schedulingStatus - returns the effective scheduling of the pods of a
+// template setting nodeSelector and topologyRef only
+func (r *OpenStackControlPlane) schedulingStatus(nodeSelector *map[string]string, topologyRef *topologyv1.TopoRef) ServiceSchedulingStatus {
+	if nodeSelector == nil {
+		nodeSelector = r.GetSchedulingNodeSelector()
+	}
+	if topologyRef == nil {
+		topologyRef = r.GetSchedulingTopologyRef()
+	}
+	status := ServiceSchedulingStatus{
+		TopologyRef:   topologyRef.DeepCopy(),
+		IgnoredFields: r.ignoredSchedulingFields(false),
+	}
+	if len(*nodeSelector) > 0 {
+		status.NodeSelector = maps.Clone(*nodeSelector)
+	}
+	return status
+}
+
+// GetSchedulingStatus - returns the effective scheduling of the pods of the
+// enabled services, by service, and of the infrastructure instances, by
+// <service>/<instance>, with the fields of spec.scheduling they ignore. The
+// services without a template yet are left out.
+func (r *OpenStackControlPlane) GetSchedulingStatus() map[string]ServiceSchedulingStatus {
+	status := map[string]ServiceSchedulingStatus{}
+
+	if r.Spec.DNS.Enabled && r.Spec.DNS.Template != nil {
+		status["dns"] = r.schedulingStatus(r.Spec.DNS.Template.NodeSelector, r.Spec.DNS.Template.TopologyRef)
+	}
+	if r.Spec.Galera.Enabled && r.Spec.Galera.Templates != nil {
+		for name, t := range *r.Spec.Galera.Templates {
+			status["galera/"+name] = r.schedulingStatus(t.NodeSelector, t.TopologyRef)
+		}
+	}
+	if r.Spec.Memcached.Enabled && r.Spec.Memcached.Templates != nil {
+		for name, t := range *r.Spec.Memcached.Templates {
+			status["memcached/"+name] = r.schedulingStatus(t.NodeSelector, t.TopologyRef)
+		}
+	}
+	if r.Spec.Rabbitmq.Enabled && r.Spec.Rabbitmq.Templates != nil {
+		for name, t := range *r.Spec.Rabbitmq.Templates {
+			s := r.schedulingStatus(t.NodeSelector, t.TopologyRef)
+			tolerations := t.Tolerations
+			if len(tolerations) == 0 {
+				tolerations = r.GetSchedulingTolerations()
+			}
+			s.Tolerations = append([]k8s_corev1.Toleration(nil), tolerations...)
+			if t.Affinity == nil {
+				s.PodAntiAffinity = r.GetSchedulingPodAntiAffinity()
+			}
+			s.IgnoredFields = r.ignoredSchedulingFields(true)
+			status["rabbitmq/"+name] = s
+		}
+	}
+	if r.Spec.Redis.Enabled && r.Spec.Redis.Templates != nil {
+		for name, t := range *r.Spec.Redis.Templates {
+			status["redis/"+name] = r.schedulingStatus(t.NodeSelector, t.TopologyRef)
+		}
+	}
+	if r.Spec.Ovn.Enabled && r.Spec.Ovn.Template != nil {
+		for name, t := range r.Spec.Ovn.Template.OVNDBCluster {
+			status["ovn/"+name] = r.schedulingStatus(t.NodeSelector, t.TopologyRef)
+		}
+		northd := r.Spec.Ovn.Template.OVNNorthd
+		status["ovn/ovnnorthd"] = r.schedulingStatus(northd.NodeSelector, northd.TopologyRef)
+		controller := r.Spec.Ovn.Template.OVNController
+		status["ovn/ovncontroller"] = r.schedulingStatus(controller.NodeSelector, controller.TopologyRef)
+	}
+	if r.Spec.Keystone.Enabled {
+		// the openstackclient has no topologyRef
+		s := r.schedulingStatus(r.Spec.OpenStackClient.Template.NodeSelector, nil)
+		s.TopologyRef = nil
+		status["openstackclient"] = s
+	}
+
+	if r.Spec.Barbican.Enabled && r.Spec.Barbican.Template != nil {
+		status["barbican"] = r.schedulingStatus(r.Spec.Barbican.Template.NodeSelector, r.Spec.Barbican.Template.TopologyRef)
+	}
+	if r.Spec.Cinder.Enabled && r.Spec.Cinder.Template != nil {
+		status["cinder"] = r.schedulingStatus(r.Spec.Cinder.Template.NodeSelector, r.Spec.Cinder.Template.TopologyRef)
+	}
+	if r.Spec.Designate.Enabled && r.Spec.Designate.Template != nil {
+		status["designate"] = r.schedulingStatus(r.Spec.Designate.Template.NodeSelector, r.Spec.Designate.Template.TopologyRef)
+	}
+	if r.Spec.Glance.Enabled && r.Spec.Glance.Template != nil {
+		status["glance"] = r.schedulingStatus(r.Spec.Glance.Template.NodeSelector, r.Spec.Glance.Template.TopologyRef)
+	}
+	if r.Spec.Heat.Enabled && r.Spec.Heat.Template != nil {
+		status["heat"] = r.schedulingStatus(r.Spec.Heat.Template.NodeSelector, r.Spec.Heat.Template.TopologyRef)
+	}
+	if r.Spec.Horizon.Enabled && r.Spec.Horizon.Template != nil {
+		status["horizon"] = r.schedulingStatus(r.Spec.Horizon.Template.NodeSelector, r.Spec.Horizon.Template.TopologyRef)
+	}
+	if r.Spec.Ironic.Enabled && r.Spec.Ironic.Template != nil {
+		status["ironic"] = r.schedulingStatus(r.Spec.Ironic.Template.NodeSelector, r.Spec.Ironic.Template.TopologyRef)
+	}
+	if r.Spec.Keystone.Enabled && r.Spec.Keystone.Template != nil {
+		status["keystone"] = r.schedulingStatus(r.Spec.Keystone.Template.NodeSelector, r.Spec.Keystone.Template.TopologyRef)
+	}
+	if r.Spec.Manila.Enabled && r.Spec.Manila.Template != nil {
+		status["manila"] = r.schedulingStatus(r.Spec.Manila.Template.NodeSelector, r.Spec.Manila.Template.TopologyRef)
+	}
+	if r.Spec.Neutron.Enabled && r.Spec.Neutron.Template != nil {
+		status["neutron"] = r.schedulingStatus(r.Spec.Neutron.Template.NodeSelector, r.Spec.Neutron.Template.TopologyRef)
+	}
+	if r.Spec.Nova.Enabled && r.Spec.Nova.Template != nil {
+		status["nova"] = r.schedulingStatus(r.Spec.Nova.Template.NodeSelector, r.Spec.Nova.Template.TopologyRef)
+	}
+	if r.Spec.Octavia.Enabled && r.Spec.Octavia.Template != nil {
+		status["octavia"] = r.schedulingStatus(r.Spec.Octavia.Template.NodeSelector, r.Spec.Octavia.Template.TopologyRef)
+	}
+	if r.Spec.Placement.Enabled && r.Spec.Placement.Template != nil {
+		status["placement"] = r.schedulingStatus(r.Spec.Placement.Template.NodeSelector, r.Spec.Placement.Template.TopologyRef)
+	}
+	if r.Spec.Swift.Enabled && r.Spec.Swift.Template != nil {
+		status["swift"] = r.schedulingStatus(r.Spec.Swift.Template.NodeSelector, r.Spec.Swift.Template.TopologyRef)
+	}
+	if r.Spec.Telemetry.Enabled && r.Spec.Telemetry.Template != nil {
+		status["telemetry"] = r.schedulingStatus(r.Spec.Telemetry.Template.NodeSelector, r.Spec.Telemetry.Template.TopologyRef)
+	}
+	if r.Spec.Watcher.Enabled && r.Spec.Watcher.Template != nil {
+		status["watcher"] = r.schedulingStatus(r.Spec.Watcher.Template.NodeSelector, r.Spec.Watcher.Template.TopologyRef)
+	}
+
+	return status
+}
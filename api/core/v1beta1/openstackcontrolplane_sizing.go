@@ -0,0 +1,388 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	k8s_corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// SizingProfileSmall - a single replica of every service, for labs and CI
+	SizingProfileSmall = "small"
+	// SizingProfileMedium - highly available services with moderate resources
+	SizingProfileMedium = "medium"
+	// SizingProfileLarge - highly available services sized for production
+	SizingProfileLarge = "large"
+
+	// sizingDefaultReplicas - the replicas the CRD schema defaults the
+	// templates to, sized by the profiles like unset replicas
+	sizingDefaultReplicas int32 = 1
+	// sizingDefaultWorkers - the keystone httpd process number the CRD
+	// schema defaults to, sized by the profiles like an unset one
+	sizingDefaultWorkers int32 = 3
+)
+
+// The sizing of a service template is set with, in order of precedence:
+//   - the value set in the template
+//   - the value of the service in the sizing profile
+//   - the default value of the sizing profile
+//
+// The CRD schema defaults the replicas of the templates to 1 and the keystone
+// httpd process number to 3, so the templates at these values are sized like
+// the ones leaving them unset: an explicit single replica or 3 workers is
+// replaced too, and reported in the replacedFields of status.sizing. A single
+// replica under a larger profile is set by a custom profile.
+//
+// The reconcile applies the profile in memory, the spec stored is left
+// unchanged so a change of profile applies to every template. The effective
+// sizing is reported in status.sizing.
+//
+// The profiles size the API and control services, the storage backends
+// (cinderVolumes, manilaShares, swiftStorage) and the compute services are
+// left out as their replicas depend on the deployment.
+
+// ServiceSizing - the replicas, resources and worker count of a service
+// +kubebuilder:object:generate=false
+type ServiceSizing struct {
+	// Replicas - the replicas of each component of the service
+	Replicas *int32 `json:"replicas,omitempty"`
+	// Resources - the resource requests and limits of each component of the
+	// service
+	Resources *k8s_corev1.ResourceRequirements `json:"resources,omitempty"`
+	// Workers - the number of worker processes of the services running a
+	// configurable number of them, keystone
+	Workers *int32 `json:"workers,omitempty"`
+}
+
+// SizingProfile - the sizing of the services, the format of the custom
+// profiles in the sizing ConfigMap
+// +kubebuilder:object:generate=false
+type SizingProfile struct {
+	// Default - the sizing of the services not in services
+	Default ServiceSizing `json:"default,omitempty"`
+	// Services - the sizing by service name (barbican, cinder, galera, glance,
+	// heat, horizon, keystone, memcached, neutron, nova, placement, rabbitmq)
+	Services map[string]ServiceSizing `json:"services,omitempty"`
+}
+
+// forService - returns the sizing of a service, the fields it doesn't set
+// taken from the default
+func (p *SizingProfile) forService(name string) ServiceSizing {
+	sizing := p.Services[name]
+	if sizing.Replicas == nil {
+		sizing.Replicas = p.Default.Replicas
+	}
+	if sizing.Resources == nil {
+		sizing.Resources = p.Default.Resources
+	}
+	if sizing.Workers == nil {
+		sizing.Workers = p.Default.Workers
+	}
+	return sizing
+}
+
+// apply - sets the replicas left unset or at the CRD default, and the
+// resource requests and limits left unset, and returns the effective sizing
+func (s ServiceSizing) apply(replicas **int32, resources *k8s_corev1.ResourceRequirements) ServiceSizingStatus {
+	status := ServiceSizingStatus{}
+	if s.Replicas != nil && (*replicas == nil || **replicas == sizingDefaultReplicas) {
+		if *replicas != nil && *s.Replicas != sizingDefaultReplicas {
+			status.ReplacedFields = append(status.ReplacedFields, "replicas")
+		}
+		*replicas = ptr.To(*s.Replicas)
+	}
+	if *replicas != nil {
+		status.Replicas = ptr.To(**replicas)
+	}
+	if resources == nil || s.Resources == nil {
+		return status
+	}
+	if len(resources.Requests) == 0 && len(s.Resources.Requests) != 0 {
+		resources.Requests = s.Resources.Requests.DeepCopy()
+	}
+	if len(resources.Limits) == 0 && len(s.Resources.Limits) != 0 {
+		resources.Limits = s.Resources.Limits.DeepCopy()
+	}
+	return status
+}
+
+// sizingResources - returns the resource requirements requesting cpu and
+// memory, limited to memoryLimit
+func sizingResources(cpu string, memory string, memoryLimit string) *k8s_corev1.ResourceRequirements {
+	return &k8s_corev1.ResourceRequirements{
+		Requests: k8s_corev1.ResourceList{
+			k8s_corev1.ResourceCPU:    resource.MustParse(cpu),
+			k8s_corev1.ResourceMemory: resource.MustParse(memory),
+		},
+		Limits: k8s_corev1.ResourceList{
+			k8s_corev1.ResourceMemory: resource.MustParse(memoryLimit),
+		},
+	}
+}
+
+// GetBuiltinSizingProfile - returns the built-in sizing profile of the name,
+// nil if there is none
+func GetBuiltinSizingProfile(name string) *SizingProfile {
+	switch name {
+	case SizingProfileSmall:
+		return &SizingProfile{
+			Default: ServiceSizing{
+				Replicas:  ptr.To[int32](1),
+				Resources: sizingResources("100m", "256Mi", "1Gi"),
+				Workers:   ptr.To[int32](1),
+			},
+			Services: map[string]ServiceSizing{
+				"galera":    {Resources: sizingResources("250m", "512Mi", "2Gi")},
+				"memcached": {Resources: sizingResources("50m", "128Mi", "512Mi")},
+			},
+		}
+	case SizingProfileMedium:
+		return &SizingProfile{
+			Default: ServiceSizing{
+				Replicas:  ptr.To[int32](2),
+				Resources: sizingResources("250m", "512Mi", "2Gi"),
+				Workers:   ptr.To[int32](3),
+			},
+			Services: map[string]ServiceSizing{
+				"galera": {
+					Replicas:  ptr.To[int32](3),
+					Resources: sizingResources("500m", "1Gi", "4Gi"),
+				},
+				"memcached": {
+					Replicas:  ptr.To[int32](3),
+					Resources: sizingResources("100m", "256Mi", "1Gi"),
+				},
+				"rabbitmq": {Replicas: ptr.To[int32](3)},
+			},
+		}
+	case SizingProfileLarge:
+		return &SizingProfile{
+			Default: ServiceSizing{
+				Replicas:  ptr.To[int32](3),
+				Resources: sizingResources("500m", "1Gi", "4Gi"),
+				Workers:   ptr.To[int32](6),
+			},
+			Services: map[string]ServiceSizing{
+				"galera":    {Resources: sizingResources("1", "4Gi", "8Gi")},
+				"memcached": {Resources: sizingResources("250m", "512Mi", "2Gi")},
+			},
+		}
+	}
+	return nil
+}
+
+// GetSizingProfileName - returns the name of the sizing profile, empty if none is set
+func (r *OpenStackControlPlane) GetSizingProfileName() string {
+	if r.Spec.Sizing == nil {
+		return ""
+	}
+	return r.Spec.Sizing.Profile
+}
+
+// GetCustomSizingProfile - returns the custom sizing profile read from the
+// sizing ConfigMap, nil when the profile is a built-in one or none is set
+func (r *OpenStackControlPlane) GetCustomSizingProfile(ctx context.Context, c client.Client) (*SizingProfile, error) {
+	name := r.GetSizingProfileName()
+	if name == "" || GetBuiltinSizingProfile(name) != nil || r.Spec.Sizing.ConfigMap == "" {
+		return nil, nil
+	}
+
+	cm := &k8s_corev1.ConfigMap{}
+	if err := c.Get(ctx, types.NamespacedName{Name: r.Spec.Sizing.ConfigMap, Namespace: r.Namespace}, cm); err != nil {
+		return nil, fmt.Errorf("error getting sizing ConfigMap %s: %w", r.Spec.Sizing.ConfigMap, err)
+	}
+	data, ok := cm.Data[name]
+	if !ok {
+		return nil, fmt.Errorf("sizing profile %s not found in ConfigMap %s", name, r.Spec.Sizing.ConfigMap)
+	}
+	profile := &SizingProfile{}
+	if err := yaml.UnmarshalStrict([]byte(data), profile); err != nil {
+		return nil, fmt.Errorf("error parsing sizing profile %s of ConfigMap %s: %w", name, r.Spec.Sizing.ConfigMap, err)
+	}
+	return profile, nil
+}
+
+// GetSizingProfile - returns the sizing profile, the built-in one of the
+// name or the custom one read from the sizing ConfigMap, nil when none is set
+func (r *OpenStackControlPlane) GetSizingProfile(ctx context.Context, c client.Client) (*SizingProfile, error) {
+	if profile := GetBuiltinSizingProfile(r.GetSizingProfileName()); profile != nil {
+		return profile, nil
+	}
+	return r.GetCustomSizingProfile(ctx, c)
+}
+
+// ValidateSizing - returns an error if the sizing profile is neither a
+// built-in one nor possibly a custom one of a ConfigMap
+func (r *OpenStackControlPlane) ValidateSizing(basePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	name := r.GetSizingProfileName()
+	if name == "" || GetBuiltinSizingProfile(name) != nil || r.Spec.Sizing.ConfigMap != "" {
+		return allErrs
+	}
+	allErrs = append(allErrs, field.NotSupported(
+		basePath.Child("sizing").Child("profile"), name,
+		[]string{SizingProfileSmall, SizingProfileMedium, SizingProfileLarge}))
+
+	return allErrs
+}
+
+// ValidateCustomSizingProfile - returns an error if the custom sizing profile
+// can't be parsed, and a warning if its ConfigMap doesn't exist yet, the
+// reconcile waits for it
+func (r *OpenStackControlPlane) ValidateCustomSizingProfile(ctx context.Context, c client.Client, basePath *field.Path) (admission.Warnings, field.ErrorList) {
+	var allWarn admission.Warnings
+	var allErrs field.ErrorList
+
+	if _, err := r.GetCustomSizingProfile(ctx, c); err != nil {
+		if apierrors.IsNotFound(err) {
+			allWarn = append(allWarn, err.Error())
+		} else {
+			allErrs = append(allErrs, field.Invalid(
+				basePath.Child("sizing").Child("profile"), r.GetSizingProfileName(), err.Error()))
+		}
+	}
+
+	return allWarn, allErrs
+}
+
+// ApplySizing - sets the replicas, resources and worker counts the templates
+// of the enabled services leave unset from the sizing profile, and returns the
+// effective sizing of the templates, by service, and by <service>/<template>
+// for the services with several templates
+func (r *OpenStackControlPlane) ApplySizing(profile *SizingProfile) map[string]ServiceSizingStatus {
+	status := map[string]ServiceSizingStatus{}
+
+	if r.Spec.Barbican.Enabled && r.Spec.Barbican.Template != nil {
+		s := profile.forService("barbican")
+		t := r.Spec.Barbican.Template
+		status["barbican/barbicanAPI"] = s.apply(&t.BarbicanAPI.Replicas, &t.BarbicanAPI.Resources)
+		status["barbican/barbicanWorker"] = s.apply(&t.BarbicanWorker.Replicas, &t.BarbicanWorker.Resources)
+		status["barbican/barbicanKeystoneListener"] = s.apply(&t.BarbicanKeystoneListener.Replicas, &t.BarbicanKeystoneListener.Resources)
+	}
+
+	if r.Spec.Cinder.Enabled && r.Spec.Cinder.Template != nil {
+		s := profile.forService("cinder")
+		t := r.Spec.Cinder.Template
+		status["cinder/cinderAPI"] = s.apply(&t.CinderAPI.Replicas, &t.CinderAPI.Resources)
+		status["cinder/cinderScheduler"] = s.apply(&t.CinderScheduler.Replicas, &t.CinderScheduler.Resources)
+	}
+
+	if r.Spec.Galera.Enabled && r.Spec.Galera.Templates != nil {
+		s := profile.forService("galera")
+		for name, t := range *r.Spec.Galera.Templates {
+			status["galera/"+name] = s.apply(&t.Replicas, &t.Resources)
+			// By-value copy, need to update
+			(*r.Spec.Galera.Templates)[name] = t
+		}
+	}
+
+	if r.Spec.Glance.Enabled && r.Spec.Glance.Template != nil {
+		s := profile.forService("glance")
+		for name, t := range r.Spec.Glance.Template.GlanceAPIs {
+			status["glance/"+name] = s.apply(&t.Replicas, &t.Resources)
+			// By-value copy, need to update
+			r.Spec.Glance.Template.GlanceAPIs[name] = t
+		}
+	}
+
+	if r.Spec.Heat.Enabled && r.Spec.Heat.Template != nil {
+		s := profile.forService("heat")
+		t := r.Spec.Heat.Template
+		status["heat/heatAPI"] = s.apply(&t.HeatAPI.Replicas, &t.HeatAPI.Resources)
+		status["heat/heatCfnAPI"] = s.apply(&t.HeatCfnAPI.Replicas, &t.HeatCfnAPI.Resources)
+		status["heat/heatEngine"] = s.apply(&t.HeatEngine.Replicas, &t.HeatEngine.Resources)
+	}
+
+	if r.Spec.Horizon.Enabled && r.Spec.Horizon.Template != nil {
+		s := profile.forService("horizon")
+		status["horizon"] = s.apply(&r.Spec.Horizon.Template.Replicas, &r.Spec.Horizon.Template.Resources)
+	}
+
+	if r.Spec.Keystone.Enabled && r.Spec.Keystone.Template != nil {
+		s := profile.forService("keystone")
+		t := r.Spec.Keystone.Template
+		keystone := s.apply(&t.Replicas, &t.Resources)
+		if s.Workers != nil && (t.HttpdCustomization.ProcessNumber == nil ||
+			*t.HttpdCustomization.ProcessNumber == sizingDefaultWorkers) {
+			if t.HttpdCustomization.ProcessNumber != nil && *s.Workers != sizingDefaultWorkers {
+				keystone.ReplacedFields = append(keystone.ReplacedFields, "httpdCustomization.processNumber")
+			}
+			t.HttpdCustomization.ProcessNumber = ptr.To(*s.Workers)
+		}
+		if t.HttpdCustomization.ProcessNumber != nil {
+			keystone.Workers = ptr.To(*t.HttpdCustomization.ProcessNumber)
+		}
+		status["keystone"] = keystone
+	}
+
+	if r.Spec.Memcached.Enabled && r.Spec.Memcached.Templates != nil {
+		s := profile.forService("memcached")
+		for name, t := range *r.Spec.Memcached.Templates {
+			status["memcached/"+name] = s.apply(&t.Replicas, &t.Resources)
+			// By-value copy, need to update
+			(*r.Spec.Memcached.Templates)[name] = t
+		}
+	}
+
+	if r.Spec.Neutron.Enabled && r.Spec.Neutron.Template != nil {
+		s := profile.forService("neutron")
+		status["neutron"] = s.apply(&r.Spec.Neutron.Template.Replicas, &r.Spec.Neutron.Template.Resources)
+	}
+
+	if r.Spec.Nova.Enabled && r.Spec.Nova.Template != nil {
+		s := profile.forService("nova")
+		t := r.Spec.Nova.Template
+		status["nova/apiServiceTemplate"] = s.apply(&t.APIServiceTemplate.Replicas, &t.APIServiceTemplate.Resources)
+		status["nova/schedulerServiceTemplate"] = s.apply(&t.SchedulerServiceTemplate.Replicas, &t.SchedulerServiceTemplate.Resources)
+		status["nova/metadataServiceTemplate"] = s.apply(&t.MetadataServiceTemplate.Replicas, &t.MetadataServiceTemplate.Resources)
+		for name, cell := range t.CellTemplates {
+			status["nova/"+name] = s.apply(&cell.ConductorServiceTemplate.Replicas, &cell.ConductorServiceTemplate.Resources)
+			// By-value copy, need to update
+			t.CellTemplates[name] = cell
+		}
+	}
+
+	if r.Spec.Placement.Enabled && r.Spec.Placement.Template != nil {
+		s := profile.forService("placement")
+		status["placement"] = s.apply(&r.Spec.Placement.Template.Replicas, &r.Spec.Placement.Template.Resources)
+	}
+
+	if r.Spec.Rabbitmq.Enabled && r.Spec.Rabbitmq.Templates != nil {
+		s := profile.forService("rabbitmq")
+		for name, t := range *r.Spec.Rabbitmq.Templates {
+			// only the replicas, the rabbitmq resources are left to the
+			// template
+			status["rabbitmq/"+name] = s.apply(&t.Replicas, nil)
+			// By-value copy, need to update
+			(*r.Spec.Rabbitmq.Templates)[name] = t
+		}
+	}
+
+	return status
+}
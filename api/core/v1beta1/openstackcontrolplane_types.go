@@ -49,6 +49,8 @@ import (
 	telemetryv1 "github.com/openstack-k8s-operators/telemetry-operator/api/v1beta1"
 	watcherv1 "github.com/openstack-k8s-operators/watcher-operator/api/v1beta1"
 
+	k8s_corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -71,6 +73,15 @@ const (
 	DeploymentStageAnnotation = "core.openstack.org/deployment-stage"
 	// DeploymentStageInfrastructureOnly - Annotation value to pause after infrastructure deployment
 	DeploymentStageInfrastructureOnly = "infrastructure-only"
+
+	// ConfirmDeleteAnnotation - Annotation key listing, comma separated, the
+	// Galera and RabbitMQ templates whose instance can be deleted
+	ConfirmDeleteAnnotation = "core.openstack.org/confirm-delete"
+
+	// WaitForDependenciesAnnotation - Annotation key, a service is only
+	// reconciled once the services it depends on are ready unless set to
+	// "false", which only requires them to be enabled
+	WaitForDependenciesAnnotation = "core.openstack.org/wait-for-dependencies"
 )
 
 // OpenStackControlPlaneSpec defines the desired state of OpenStackControlPlane
@@ -153,6 +164,13 @@ type OpenStackControlPlaneSpec struct {
 	// Avoid colocating with MessagingBus used for RPC.
 	NotificationsBus *rabbitmqv1.RabbitMqConfig `json:"notificationsBus,omitempty"`
 
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// MessagingTopology - named messaging buses and the services and Nova cells
+	// bound to them. The buses are propagated into the service templates not
+	// setting their own, taking precedence over messagingBus and notificationsBus.
+	MessagingTopology *MessagingTopologySection `json:"messagingTopology,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// Memcached - Parameters related to the Memcached service
@@ -240,6 +258,23 @@ type OpenStackControlPlaneSpec struct {
 	// by name
 	TopologyRef *topologyv1.TopoRef `json:"topologyRef,omitempty"`
 
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Scheduling - Placement and scheduling policy of the pods of all the services and infrastructure
+	// instances, applied to the templates not setting their own. Takes precedence over nodeSelector and
+	// topologyRef. The policy isn't uniform: nodeSelector and topologyRef reach all the templates,
+	// tolerations and podAntiAffinity reach the rabbitmq templates only.
+	Scheduling *SchedulingSection `json:"scheduling,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Sizing - Named sizing profile setting the replicas, resources and worker counts the service and
+	// infrastructure templates leave unset or at their defaults, 1 replica and 3 workers. An explicit
+	// 1 replica or 3 workers can't be told from the default and is replaced too. The profile is applied
+	// to the services at reconcile time, the templates of the spec are left unchanged, and the effective
+	// sizing and the replaced fields are reported in status.sizing.
+	Sizing *SizingSection `json:"sizing,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// Watcher - Parameters related to the Watcher service
@@ -383,6 +418,14 @@ type DNSMasqSection struct {
 	// Enabled - Whether DNSMasq service should be deployed and managed
 	Enabled bool `json:"enabled"`
 
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Managed
+	// +kubebuilder:validation:Enum=Managed;Unmanaged
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// ManagementState - Set to Unmanaged to stop the openstack-operator from changing the DNSMasq service,
+	// e.g. while it is hotfixed manually. Its readiness is still reported.
+	ManagementState ManagementState `json:"managementState,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// Template - Overrides to use when creating the DNSMasq service
@@ -397,6 +440,20 @@ type KeystoneSection struct {
 	// Enabled - Whether Keystone service should be deployed and managed
 	Enabled bool `json:"enabled"`
 
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Managed
+	// +kubebuilder:validation:Enum=Managed;Unmanaged
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// ManagementState - Set to Unmanaged to stop the openstack-operator from changing the Keystone service,
+	// e.g. while it is hotfixed manually. Its readiness is still reported.
+	ManagementState ManagementState `json:"managementState,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Autoscaling - Opt-in horizontal autoscaling of the Keystone API, the replicas of the template being the
+	// minimum
+	Autoscaling *AutoscalingSection `json:"autoscaling,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// Template - Overrides to use when creating the Keystone service
@@ -437,6 +494,20 @@ type PlacementSection struct {
 	// Enabled - Whether Placement service should be deployed and managed
 	Enabled bool `json:"enabled"`
 
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Managed
+	// +kubebuilder:validation:Enum=Managed;Unmanaged
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// ManagementState - Set to Unmanaged to stop the openstack-operator from changing the Placement service,
+	// e.g. while it is hotfixed manually. Its readiness is still reported.
+	ManagementState ManagementState `json:"managementState,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Autoscaling - Opt-in horizontal autoscaling of the Placement API, the replicas of the template being the
+	// minimum
+	Autoscaling *AutoscalingSection `json:"autoscaling,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// Template - Overrides to use when creating the Placement API
@@ -463,6 +534,14 @@ type GlanceSection struct {
 	// Enabled - Whether Glance service should be deployed and managed
 	Enabled bool `json:"enabled"`
 
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Managed
+	// +kubebuilder:validation:Enum=Managed;Unmanaged
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// ManagementState - Set to Unmanaged to stop the openstack-operator from changing the Glance service,
+	// e.g. while it is hotfixed manually. Its readiness is still reported.
+	ManagementState ManagementState `json:"managementState,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// Template - Overrides to use when creating the Glance Service
@@ -502,6 +581,14 @@ type CinderSection struct {
 	// Enabled - Whether Cinder service should be deployed and managed
 	Enabled bool `json:"enabled"`
 
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Managed
+	// +kubebuilder:validation:Enum=Managed;Unmanaged
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// ManagementState - Set to Unmanaged to stop the openstack-operator from changing the Cinder service,
+	// e.g. while it is hotfixed manually. Its readiness is still reported.
+	ManagementState ManagementState `json:"managementState,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// Template - Overrides to use when creating Cinder Resources
@@ -541,6 +628,14 @@ type GaleraSection struct {
 	// Enabled - Whether Galera services should be deployed and managed
 	Enabled bool `json:"enabled"`
 
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Managed
+	// +kubebuilder:validation:Enum=Managed;Unmanaged
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// ManagementState - Set to Unmanaged to stop the openstack-operator from changing the Galera instances,
+	// e.g. while they are hotfixed manually. Their readiness is still reported.
+	ManagementState ManagementState `json:"managementState,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// Templates - Overrides to use when creating the Galera databases
@@ -555,12 +650,60 @@ type RabbitmqSection struct {
 	// Enabled - Whether RabbitMQ services should be deployed and managed
 	Enabled bool `json:"enabled"`
 
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Managed
+	// +kubebuilder:validation:Enum=Managed;Unmanaged
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// ManagementState - Set to Unmanaged to stop the openstack-operator from changing the RabbitMQ instances,
+	// e.g. while they are hotfixed manually. Their readiness is still reported.
+	ManagementState ManagementState `json:"managementState,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// Templates - Overrides to use when creating the Rabbitmq clusters
 	Templates *map[string]rabbitmqv1.RabbitMqSpecCore `json:"templates"`
 }
 
+// MessagingTopologySection defines the messaging buses and the services using them
+type MessagingTopologySection struct {
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Buses - named messaging buses, the cluster of a bus is the name of one of
+	// the rabbitmq templates. The user can't be set, each service creates its own.
+	Buses map[string]rabbitmqv1.RabbitMqConfig `json:"buses,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Services - the buses used by a service, keyed by the name of its section,
+	// e.g. cinder, neutron or nova. The telemetry components are named aodh,
+	// ceilometer and cloudkitty.
+	Services map[string]MessagingTopologyBinding `json:"services,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// NovaCells - the bus used for RPC by a Nova cell, keyed by the name of the cell
+	NovaCells map[string]string `json:"novaCells,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Notifications - the bus notifications are fanned out to, used by all the
+	// services producing or consuming notifications without a binding of their own
+	Notifications string `json:"notifications,omitempty"`
+}
+
+// MessagingTopologyBinding defines the buses used by a service
+type MessagingTopologyBinding struct {
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// RPC - the name of the bus used for RPC
+	RPC string `json:"rpc,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Notifications - the name of the bus used for notifications
+	Notifications string `json:"notifications,omitempty"`
+}
+
 // MemcachedSection defines the desired state of Memcached services
 type MemcachedSection struct {
 	// +kubebuilder:validation:Optional
@@ -569,6 +712,14 @@ type MemcachedSection struct {
 	// Enabled - Whether Memcached services should be deployed and managed
 	Enabled bool `json:"enabled"`
 
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Managed
+	// +kubebuilder:validation:Enum=Managed;Unmanaged
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// ManagementState - Set to Unmanaged to stop the openstack-operator from changing the Memcached instances,
+	// e.g. while they are hotfixed manually. Their readiness is still reported.
+	ManagementState ManagementState `json:"managementState,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// Templates - Overrides to use when creating the Memcached databases
@@ -583,6 +734,14 @@ type OvnSection struct {
 	// Enabled - Whether OVN services should be deployed and managed
 	Enabled bool `json:"enabled"`
 
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Managed
+	// +kubebuilder:validation:Enum=Managed;Unmanaged
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// ManagementState - Set to Unmanaged to stop the openstack-operator from changing the OVN services,
+	// e.g. while they are hotfixed manually. Their readiness is still reported.
+	ManagementState ManagementState `json:"managementState,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// Template - Overrides to use when creating the OVN services
@@ -615,6 +774,20 @@ type NeutronSection struct {
 	// Enabled - Whether Neutron service should be deployed and managed
 	Enabled bool `json:"enabled"`
 
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Managed
+	// +kubebuilder:validation:Enum=Managed;Unmanaged
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// ManagementState - Set to Unmanaged to stop the openstack-operator from changing the Neutron service,
+	// e.g. while it is hotfixed manually. Its readiness is still reported.
+	ManagementState ManagementState `json:"managementState,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Autoscaling - Opt-in horizontal autoscaling of the Neutron API, the replicas of the template being the
+	// minimum
+	Autoscaling *AutoscalingSection `json:"autoscaling,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// Template - Overrides to use when creating the Neutron Service
@@ -641,6 +814,14 @@ type NovaSection struct {
 	// Enabled - Whether Nova services should be deployed and managed
 	Enabled bool `json:"enabled"`
 
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Managed
+	// +kubebuilder:validation:Enum=Managed;Unmanaged
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// ManagementState - Set to Unmanaged to stop the openstack-operator from changing the Nova service,
+	// e.g. while it is hotfixed manually. Its readiness is still reported.
+	ManagementState ManagementState `json:"managementState,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// Template - Overrides to use when creating the Nova services
@@ -658,6 +839,14 @@ type NovaSection struct {
 	// Providing an override for cell0 noVNCProxy does not have an effect.
 	CellOverride map[string]NovaCellOverrideSpec `json:"cellOverride,omitempty"`
 
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Cells - the Nova cells with the Galera and RabbitMQ instances they use and
+	// their noVNCProxy, declared in one place. The cells declared here take
+	// precedence over template.cellTemplates and are merged into cellOverride,
+	// the missing galera and rabbitmq templates of the cells are created.
+	Cells map[string]NovaCellSection `json:"cells,omitempty"`
+
 	// ApplicationCredential allows service-specific overrides of the global AC configuration.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +kubebuilder:validation:Optional
@@ -666,6 +855,43 @@ type NovaSection struct {
 	ApplicationCredential *ServiceAppCredSection `json:"applicationCredential"`
 }
 
+// NovaCellSection declares a Nova cell and the instances it uses
+type NovaCellSection struct {
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Galera - the name of the Galera instance hosting the database of the cell.
+	// Defaults to the cellDatabaseInstance of the cell template, then to
+	// openstack for cell0 and to openstack-<cell> for the other cells.
+	Galera string `json:"galera,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Rabbitmq - the name of the RabbitMQ instance used by the cell. Defaults to
+	// rabbitmq-<cell> unless the cell template or messagingTopology.novaCells
+	// set the bus of the cell, cell0 uses the one of the Nova API when not set.
+	// Can't be set for a cell of messagingTopology.novaCells.
+	Rabbitmq string `json:"rabbitmq,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// NoVNCProxy - the noVNCProxy of the cell, cell0 has none
+	NoVNCProxy *NovaCellNoVNCProxySection `json:"noVNCProxy,omitempty"`
+}
+
+// NovaCellNoVNCProxySection defines the noVNCProxy of a Nova cell
+type NovaCellNoVNCProxySection struct {
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	// Enabled - Whether the noVNCProxy of the cell should be deployed
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Override - overrides the generated manifest of the noVNCProxy route and
+	// endpoint
+	Override *Override `json:"override,omitempty"`
+}
+
 // NovaCellOverrideSpec to override the generated manifest of several child resources.
 type NovaCellOverrideSpec struct {
 	// +kubebuilder:validation:Optional
@@ -680,6 +906,20 @@ type HeatSection struct {
 	// Enabled - Whether Heat services should be deployed and managed
 	Enabled bool `json:"enabled"`
 
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Managed
+	// +kubebuilder:validation:Enum=Managed;Unmanaged
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// ManagementState - Set to Unmanaged to stop the openstack-operator from changing the Heat service,
+	// e.g. while it is hotfixed manually. Its readiness is still reported.
+	ManagementState ManagementState `json:"managementState,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Autoscaling - Opt-in horizontal autoscaling of the Heat API, the replicas of the template being the
+	// minimum
+	Autoscaling *AutoscalingSection `json:"autoscaling,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// Template - Overrides to use when creating the Heat services
@@ -711,6 +951,14 @@ type IronicSection struct {
 	// Enabled - Whether Ironic services should be deployed and managed
 	Enabled bool `json:"enabled"`
 
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Managed
+	// +kubebuilder:validation:Enum=Managed;Unmanaged
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// ManagementState - Set to Unmanaged to stop the openstack-operator from changing the Ironic service,
+	// e.g. while it is hotfixed manually. Its readiness is still reported.
+	ManagementState ManagementState `json:"managementState,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// Template - Overrides to use when creating the Ironic services
@@ -742,6 +990,14 @@ type ManilaSection struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
 	Enabled bool `json:"enabled"`
 
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Managed
+	// +kubebuilder:validation:Enum=Managed;Unmanaged
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// ManagementState - Set to Unmanaged to stop the openstack-operator from changing the Manila service,
+	// e.g. while it is hotfixed manually. Its readiness is still reported.
+	ManagementState ManagementState `json:"managementState,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// Template - Overrides to use when creating Manila Resources
@@ -768,6 +1024,20 @@ type HorizonSection struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
 	Enabled bool `json:"enabled"`
 
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Managed
+	// +kubebuilder:validation:Enum=Managed;Unmanaged
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// ManagementState - Set to Unmanaged to stop the openstack-operator from changing the Horizon service,
+	// e.g. while it is hotfixed manually. Its readiness is still reported.
+	ManagementState ManagementState `json:"managementState,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Autoscaling - Opt-in horizontal autoscaling of Horizon, the replicas of the template being the
+	// minimum
+	Autoscaling *AutoscalingSection `json:"autoscaling,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// Template - Overrides to use when creating the Horizon services
@@ -787,6 +1057,14 @@ type TelemetrySection struct {
 	// Enabled - Whether OpenStack Telemetry services should be deployed and managed
 	Enabled bool `json:"enabled"`
 
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Managed
+	// +kubebuilder:validation:Enum=Managed;Unmanaged
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// ManagementState - Set to Unmanaged to stop the openstack-operator from changing the Telemetry service,
+	// e.g. while it is hotfixed manually. Its readiness is still reported.
+	ManagementState ManagementState `json:"managementState,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// Template - Overrides to use when creating the OpenStack Telemetry services
@@ -842,6 +1120,20 @@ type SwiftSection struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
 	Enabled bool `json:"enabled"`
 
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Managed
+	// +kubebuilder:validation:Enum=Managed;Unmanaged
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// ManagementState - Set to Unmanaged to stop the openstack-operator from changing the Swift service,
+	// e.g. while it is hotfixed manually. Its readiness is still reported.
+	ManagementState ManagementState `json:"managementState,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Autoscaling - Opt-in horizontal autoscaling of the Swift proxy, the replicas of the template being the
+	// minimum
+	Autoscaling *AutoscalingSection `json:"autoscaling,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// Template - Overrides to use when creating Swift Resources
@@ -868,6 +1160,20 @@ type OctaviaSection struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
 	Enabled bool `json:"enabled"`
 
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Managed
+	// +kubebuilder:validation:Enum=Managed;Unmanaged
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// ManagementState - Set to Unmanaged to stop the openstack-operator from changing the Octavia service,
+	// e.g. while it is hotfixed manually. Its readiness is still reported.
+	ManagementState ManagementState `json:"managementState,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Autoscaling - Opt-in horizontal autoscaling of the Octavia API, the replicas of the template being the
+	// minimum
+	Autoscaling *AutoscalingSection `json:"autoscaling,omitempty"`
+
 	// +kubebuilder:valdiation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// Template - Overrides to use when creating Octavia Resources
@@ -894,6 +1200,20 @@ type DesignateSection struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
 	Enabled bool `json:"enabled"`
 
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Managed
+	// +kubebuilder:validation:Enum=Managed;Unmanaged
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// ManagementState - Set to Unmanaged to stop the openstack-operator from changing the Designate service,
+	// e.g. while it is hotfixed manually. Its readiness is still reported.
+	ManagementState ManagementState `json:"managementState,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Autoscaling - Opt-in horizontal autoscaling of the Designate API, the replicas of the template being the
+	// minimum
+	Autoscaling *AutoscalingSection `json:"autoscaling,omitempty"`
+
 	// +kubebuilder:valdiation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// Template - Overrides to use when creating Designate Resources
@@ -920,6 +1240,20 @@ type BarbicanSection struct {
 	// Enabled - Whether Barbican service should be deployed and managed
 	Enabled bool `json:"enabled"`
 
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Managed
+	// +kubebuilder:validation:Enum=Managed;Unmanaged
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// ManagementState - Set to Unmanaged to stop the openstack-operator from changing the Barbican service,
+	// e.g. while it is hotfixed manually. Its readiness is still reported.
+	ManagementState ManagementState `json:"managementState,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Autoscaling - Opt-in horizontal autoscaling of the Barbican API, the replicas of the template being the
+	// minimum
+	Autoscaling *AutoscalingSection `json:"autoscaling,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// Template - Overrides to use when creating the Barbican Service
@@ -946,6 +1280,14 @@ type RedisSection struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
 	Enabled bool `json:"enabled"`
 
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Managed
+	// +kubebuilder:validation:Enum=Managed;Unmanaged
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// ManagementState - Set to Unmanaged to stop the openstack-operator from changing the Redis instances,
+	// e.g. while they are hotfixed manually. Their readiness is still reported.
+	ManagementState ManagementState `json:"managementState,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	//+operator-sdk:csv:customresourcedefinitions:type=spec
 	// Templates - Overrides to use when creating the Redis Resources
@@ -968,6 +1310,14 @@ type WatcherSection struct {
 	// Enabled - Whether Watcher service should be deployed and managed
 	Enabled bool `json:"enabled"`
 
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Managed
+	// +kubebuilder:validation:Enum=Managed;Unmanaged
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// ManagementState - Set to Unmanaged to stop the openstack-operator from changing the Watcher service,
+	// e.g. while it is hotfixed manually. Its readiness is still reported.
+	ManagementState ManagementState `json:"managementState,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// Template - Overrides to use when creating the Watcher service
@@ -986,6 +1336,133 @@ type WatcherSection struct {
 	ApplicationCredential *ServiceAppCredSection `json:"applicationCredential"`
 }
 
+// SchedulingSection defines the placement and scheduling policy of the pods of
+// the services and infrastructure instances. nodeSelector and topologyRef
+// apply to all the templates, tolerations and podAntiAffinity to the rabbitmq
+// templates only.
+type SchedulingSection struct {
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// NodeSelector - Selects the worker nodes running the pods of the templates not setting their own
+	// nodeSelector
+	NodeSelector *map[string]string `json:"nodeSelector,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// TopologyRef - The Topology applied to the templates not referencing their own
+	TopologyRef *topologyv1.TopoRef `json:"topologyRef,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Tolerations - Tolerations of the pods of the templates supporting them and not setting their own.
+	// Only the rabbitmq templates support tolerations, the other templates report them in the ignoredFields
+	// of status.scheduling.
+	Tolerations []k8s_corev1.Toleration `json:"tolerations,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Preferred;Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// PodAntiAffinity - Spreads the pods of an instance across the worker nodes, Preferred when possible or
+	// Required, applied to the templates supporting an affinity and not setting their own. Required leaves
+	// the pods exceeding the worker nodes unscheduled. Only the rabbitmq templates support an affinity, the
+	// other templates report it in the ignoredFields of status.scheduling.
+	PodAntiAffinity PodAntiAffinityPreset `json:"podAntiAffinity,omitempty"`
+}
+
+// AutoscalingSection defines the horizontal autoscaling of the API of a
+// service
+type AutoscalingSection struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// MinReplicas - Minimum number of replicas, raised to the replicas of the template when lower
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// MaxReplicas - Maximum number of replicas
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// TargetCPUUtilizationPercentage - Target average CPU utilization of the pods, in percent of their
+	// CPU requests. Defaults to 80 when no target is set.
+	TargetCPUUtilizationPercentage *int32 `json:"targetCPUUtilizationPercentage,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// TargetRequestsPerSecond - Target average number of HTTP requests per second of the pods, read from
+	// the http_requests_per_second pods metric which a custom metrics adapter has to provide
+	TargetRequestsPerSecond *resource.Quantity `json:"targetRequestsPerSecond,omitempty"`
+}
+
+// AutoscalingStatus defines the replicas of an autoscaled API
+type AutoscalingStatus struct {
+	// MinReplicas - the minimum number of replicas of the HorizontalPodAutoscaler
+	MinReplicas int32 `json:"minReplicas"`
+
+	// MaxReplicas - the maximum number of replicas of the HorizontalPodAutoscaler
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// CurrentReplicas - the number of replicas last seen by the HorizontalPodAutoscaler
+	CurrentReplicas int32 `json:"currentReplicas"`
+
+	// DesiredReplicas - the number of replicas last calculated by the HorizontalPodAutoscaler
+	DesiredReplicas int32 `json:"desiredReplicas"`
+}
+
+// SizingSection defines the sizing profile applied to the service and
+// infrastructure templates
+type SizingSection struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Profile - Name of the sizing profile, one of the built-in small, medium and large profiles or a
+	// custom profile defined in configMap
+	Profile string `json:"profile"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// ConfigMap - Name of a ConfigMap in the namespace defining custom sizing profiles, one profile per
+	// key. Custom profiles can't reuse the names of the built-in profiles.
+	ConfigMap string `json:"configMap,omitempty"`
+}
+
+// ServiceSchedulingStatus defines the effective scheduling of the pods of a
+// service or infrastructure instance
+type ServiceSchedulingStatus struct {
+	// NodeSelector - the nodeSelector of the pods
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// TopologyRef - the Topology applied to the pods
+	TopologyRef *topologyv1.TopoRef `json:"topologyRef,omitempty"`
+
+	// Tolerations - the tolerations of the pods
+	Tolerations []k8s_corev1.Toleration `json:"tolerations,omitempty"`
+
+	// PodAntiAffinity - the pod anti-affinity preset applied to the pods
+	PodAntiAffinity PodAntiAffinityPreset `json:"podAntiAffinity,omitempty"`
+
+	// IgnoredFields - the fields of spec.scheduling the template doesn't support, not applied to the pods
+	IgnoredFields []string `json:"ignoredFields,omitempty"`
+}
+
+// ServiceSizingStatus defines the effective sizing of a template sized by the
+// sizing profile
+type ServiceSizingStatus struct {
+	// Replicas - the replicas of the template
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Workers - the number of worker processes, keystone only
+	Workers *int32 `json:"workers,omitempty"`
+
+	// ReplacedFields - the fields of the template at their CRD default, 1 replica and 3 workers, replaced
+	// by the sizing profile
+	ReplacedFields []string `json:"replacedFields,omitempty"`
+}
+
 // +kubebuilder:validation:XValidation:rule="self.gracePeriodDays < self.expirationDays",message="gracePeriodDays must be smaller than expirationDays"
 // ApplicationCredentialSection defines the desired configuration for ApplicationCredentials
 type ApplicationCredentialSection struct {
@@ -1091,6 +1568,20 @@ type OpenStackControlPlaneStatus struct {
 	// ContainerImages
 	ContainerImages ContainerImages `json:"containerImages,omitempty"`
 
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// Scheduling - the effective scheduling of the pods of the enabled services, by service, and of the
+	// infrastructure instances, by <service>/<instance>
+	Scheduling map[string]ServiceSchedulingStatus `json:"scheduling,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// Sizing - the effective sizing of the templates sized by the sizing profile, by service, and by
+	// <service>/<template> for the services with several templates
+	Sizing map[string]ServiceSizingStatus `json:"sizing,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// Autoscaling - the replicas of the autoscaled APIs, by service
+	Autoscaling map[string]AutoscalingStatus `json:"autoscaling,omitempty"`
+
 	//ObservedGeneration - the most recent generation observed for this object.
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
@@ -1117,7 +1608,10 @@ type TLSCAStatus struct {
 // +kubebuilder:metadata:labels=backup.openstack.org/category=controlplane
 // +kubebuilder:metadata:labels=backup.openstack.org/restore-order=30
 
-// OpenStackControlPlane is the Schema for the openstackcontrolplanes API
+// OpenStackControlPlane is the Schema for the openstackcontrolplanes API.
+// A service is only deployed once the services it depends on are ready, the
+// core.openstack.org/wait-for-dependencies: "false" annotation deploys it as
+// soon as they are enabled.
 type OpenStackControlPlane struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -1245,7 +1739,7 @@ func (instance *OpenStackControlPlane) InitConditions() {
 	}
 
 	// Init Topology condition if there's a reference
-	if instance.Spec.TopologyRef != nil {
+	if instance.GetSchedulingTopologyRef() != nil {
 		cl.Set(condition.UnknownCondition(condition.TopologyReadyCondition, condition.InitReason, condition.TopologyReadyInitMessage))
 	}
 
@@ -21,6 +21,7 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"fmt"
+	"reflect"
 	"slices"
 	"strings"
 
@@ -217,6 +218,26 @@ func (r *OpenStackControlPlane) ValidateCreate(ctx context.Context, c client.Cli
 		allErrs = append(allErrs, errs...)
 	}
 
+	if errs := r.ValidateMessagingTopology(basePath); len(errs) != 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := r.ValidateSizing(basePath); len(errs) != 0 {
+		allErrs = append(allErrs, errs...)
+	} else {
+		warns, errs := r.ValidateCustomSizingProfile(ctx, c, basePath)
+		allWarn = append(allWarn, warns...)
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := r.ValidateAutoscaling(basePath); len(errs) != 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	warns, errs = r.ValidateNovaCells(basePath)
+	allWarn = append(allWarn, warns...)
+	allErrs = append(allErrs, errs...)
+
 	if len(allErrs) != 0 {
 		return allWarn, apierrors.NewInvalid(
 			schema.GroupKind{Group: "core.openstack.org", Kind: "OpenStackControlPlane"},
@@ -267,6 +288,32 @@ func (r *OpenStackControlPlane) ValidateUpdate(ctx context.Context, old runtime.
 		allErrs = append(allErrs, errs...)
 	}
 
+	if errs := r.ValidateMessagingTopology(basePath); len(errs) != 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := r.ValidateSizing(basePath); len(errs) != 0 {
+		allErrs = append(allErrs, errs...)
+	} else if r.DeletionTimestamp.IsZero() && !reflect.DeepEqual(r.Spec.Sizing, oldControlPlane.Spec.Sizing) {
+		// Only read the sizing ConfigMap when the profile changes, to not
+		// block the updates of the instance, e.g. removing its finalizer
+		warns, errs := r.ValidateCustomSizingProfile(ctx, c, basePath)
+		allWarn = append(allWarn, warns...)
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := r.ValidateAutoscaling(basePath); len(errs) != 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	warns, errs = r.ValidateNovaCells(basePath)
+	allWarn = append(allWarn, warns...)
+	allErrs = append(allErrs, errs...)
+
+	if errs := r.ValidateInstanceDeletion(oldControlPlane.Spec, basePath); len(errs) != 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
 	if len(allErrs) != 0 {
 		return nil, apierrors.NewInvalid(
 			schema.GroupKind{Group: "core.openstack.org", Kind: "OpenStackControlPlane"},
@@ -285,89 +332,17 @@ func (r *OpenStackControlPlane) ValidateDelete(ctx context.Context, c client.Cli
 
 // checkDepsEnabled - returns a non-empty string if required services are missing (disabled) for "name" service
 func (r *OpenStackControlPlane) checkDepsEnabled(name string) string {
-	// "msg" will hold any dependency validation error we might find
-	msg := ""
-	// "reqs" will be set to the required services for "name" service
-	// if any of those required services are improperly disabled/missing
-	reqs := ""
-
-	switch name {
-	case "Keystone":
-		if !((r.Spec.Galera.Enabled) && r.Spec.Memcached.Enabled && r.Spec.Rabbitmq.Enabled) {
-			reqs = "Galera, Memcached, RabbitMQ"
+	for _, dep := range serviceDependencies {
+		if dep.name != name {
+			continue
 		}
-	case "Glance":
-		if !((r.Spec.Galera.Enabled) && r.Spec.Memcached.Enabled && r.Spec.Keystone.Enabled) {
-			reqs = "Galera, Memcached, Keystone"
-		}
-	case "Cinder":
-		if !((r.Spec.Galera.Enabled) && r.Spec.Memcached.Enabled && r.Spec.Rabbitmq.Enabled &&
-			r.Spec.Keystone.Enabled) {
-			reqs = "Galera, Memcached, RabbitMQ, Keystone"
-		}
-	case "Placement":
-		if !((r.Spec.Galera.Enabled) && r.Spec.Memcached.Enabled && r.Spec.Keystone.Enabled) {
-			reqs = "Galera, Memcached, Keystone"
-		}
-	case "Neutron":
-		if !((r.Spec.Galera.Enabled) && r.Spec.Memcached.Enabled && r.Spec.Rabbitmq.Enabled &&
-			r.Spec.Keystone.Enabled) {
-			reqs = "Galera, RabbitMQ, Keystone"
-		}
-	case "Nova":
-		if !((r.Spec.Galera.Enabled) && r.Spec.Memcached.Enabled && r.Spec.Rabbitmq.Enabled &&
-			r.Spec.Keystone.Enabled && r.Spec.Placement.Enabled && r.Spec.Neutron.Enabled && r.Spec.Glance.Enabled) {
-			reqs = "Galera, Memcached, RabbitMQ, Keystone, Glance, Neutron, Placement"
-		}
-	case "Heat":
-		if !((r.Spec.Galera.Enabled) && r.Spec.Memcached.Enabled && r.Spec.Rabbitmq.Enabled &&
-			r.Spec.Keystone.Enabled) {
-			reqs = "Galera, Memcached, RabbitMQ, Keystone"
-		}
-	case "Swift":
-		if !(r.Spec.Memcached.Enabled && r.Spec.Keystone.Enabled) {
-			reqs = "Memcached, Keystone"
-		}
-	case "Horizon":
-		if !((r.Spec.Galera.Enabled) && r.Spec.Memcached.Enabled && r.Spec.Keystone.Enabled) {
-			reqs = "Galera, Memcached, Keystone"
-		}
-	case "Barbican":
-		if !((r.Spec.Galera.Enabled) && r.Spec.Keystone.Enabled) {
-			reqs = "Galera, Keystone"
-		}
-	case "Octavia":
-		// TODO(beagles): So far we haven't declared Redis as dependency for Octavia, but we might.
-		if !((r.Spec.Galera.Enabled) && r.Spec.Memcached.Enabled && r.Spec.Rabbitmq.Enabled &&
-			r.Spec.Keystone.Enabled && r.Spec.Neutron.Enabled && r.Spec.Glance.Enabled && r.Spec.Nova.Enabled) {
-			reqs = "Galera, Memcached, RabbitMQ, Keystone, Glance, Neutron, Nova"
-		}
-	case "Telemetry.Autoscaling":
-		if !(r.Spec.Galera.Enabled && r.Spec.Heat.Enabled && r.Spec.Rabbitmq.Enabled && r.Spec.Keystone.Enabled) {
-			reqs = "Galera, Heat, RabbitMQ, Keystone"
-		}
-	case "Telemetry.Ceilometer":
-		if !(r.Spec.Rabbitmq.Enabled && r.Spec.Keystone.Enabled) {
-			reqs = "RabbitMQ, Keystone"
-		}
-	case "Telemetry.CloudKitty":
-		if !(r.Spec.Rabbitmq.Enabled && r.Spec.Keystone.Enabled) {
-			reqs = "RabbitMQ, Keystone"
-		}
-	case "Watcher":
-		if !(r.Spec.Galera.Enabled && r.Spec.Memcached.Enabled && r.Spec.Rabbitmq.Enabled &&
-			r.Spec.Keystone.Enabled && r.Spec.Telemetry.Enabled && *r.Spec.Telemetry.Template.Ceilometer.Enabled &&
-			*r.Spec.Telemetry.Template.MetricStorage.Enabled) {
-			reqs = "Galera, Memcached, RabbitMQ, Keystone, Telemetry, Telemetry.Ceilometer, Telemetry.MetricStorage"
+		for _, required := range dep.requires {
+			if !r.isServiceEnabled(required) {
+				return fmt.Sprintf("%s requires these services to be enabled: %s.", name, strings.Join(dep.requires, ", "))
+			}
 		}
 	}
-
-	// If "reqs" is not the empty string, we have missing requirements
-	if reqs != "" {
-		msg = fmt.Sprintf("%s requires these services to be enabled: %s.", name, reqs)
-	}
-
-	return msg
+	return ""
 }
 
 // ValidateCreateServices validating service definitions during the OpenstackControlPlane CR creation
@@ -552,6 +527,9 @@ func (r *OpenStackControlPlane) ValidateCreateServices(basePath *field.Path) (ad
 				mariadbv1.CrMaxLengthCorrection) // omit issue with statefulset pod label "controller-revision-hash": "<statefulset_name>-<hash>"
 			errors = append(errors, err...)
 		}
+		warn, errs := r.ValidateGaleraReplicas(basePath, nil)
+		warnings = append(warnings, warn...)
+		errors = append(errors, errs...)
 	}
 
 	return warnings, errors
@@ -769,117 +747,60 @@ func (r *OpenStackControlPlane) ValidateUpdateServices(old OpenStackControlPlane
 				mariadbv1.CrMaxLengthCorrection) // omit issue with statefulset pod label "controller-revision-hash": "<statefulset_name>-<hash>"
 			errors = append(errors, err...)
 		}
+		warn, errs := r.ValidateGaleraReplicas(basePath, old.Galera.Templates)
+		warnings = append(warnings, warn...)
+		errors = append(errors, errs...)
 	}
 
 	return warnings, errors
 }
 
-// ValidateServiceDependencies ensures that when a service is enabled then all the services it depends on are also
-// enabled
-func (r *OpenStackControlPlane) ValidateServiceDependencies(basePath *field.Path) field.ErrorList {
+// ValidateGaleraReplicas - returns an error for each Galera template set to an
+// even number of replicas, which can't keep the quorum when split in halves.
+// The Galera templates of oldTemplates already running with an even number of
+// replicas only get a warning, to not block unrelated updates. Zero replicas
+// stop the instance and are allowed.
+func (r *OpenStackControlPlane) ValidateGaleraReplicas(
+	basePath *field.Path,
+	oldTemplates *map[string]mariadbv1.GaleraSpecCore,
+) (admission.Warnings, field.ErrorList) {
+	var allWarn []string
 	var allErrs field.ErrorList
 
-	// Add service dependency validations
-
-	if r.Spec.Keystone.Enabled {
-		if depErrorMsg := r.checkDepsEnabled("Keystone"); depErrorMsg != "" {
-			err := field.Invalid(basePath.Child("keystone").Child("enabled"), r.Spec.Keystone.Enabled, depErrorMsg)
-			allErrs = append(allErrs, err)
-		}
+	if r.Spec.Galera.Templates == nil {
+		return allWarn, allErrs
 	}
-
-	if r.Spec.Glance.Enabled {
-		if depErrorMsg := r.checkDepsEnabled("Glance"); depErrorMsg != "" {
-			err := field.Invalid(basePath.Child("glance").Child("enabled"), r.Spec.Glance.Enabled, depErrorMsg)
-			allErrs = append(allErrs, err)
+	for _, name := range sortedKeys(*r.Spec.Galera.Templates) {
+		replicas := ptr.Deref((*r.Spec.Galera.Templates)[name].Replicas, 0)
+		if replicas == 0 || replicas%2 != 0 {
+			continue
 		}
-	}
-
-	if r.Spec.Cinder.Enabled {
-		if depErrorMsg := r.checkDepsEnabled("Cinder"); depErrorMsg != "" {
-			err := field.Invalid(basePath.Child("cinder").Child("enabled"), r.Spec.Cinder.Enabled, depErrorMsg)
-			allErrs = append(allErrs, err)
-		}
-	}
-
-	if r.Spec.Placement.Enabled {
-		if depErrorMsg := r.checkDepsEnabled("Placement"); depErrorMsg != "" {
-			err := field.Invalid(basePath.Child("placement").Child("enabled"), r.Spec.Placement.Enabled, depErrorMsg)
-			allErrs = append(allErrs, err)
-		}
-	}
-
-	if r.Spec.Neutron.Enabled {
-		if depErrorMsg := r.checkDepsEnabled("Neutron"); depErrorMsg != "" {
-			err := field.Invalid(basePath.Child("neutron").Child("enabled"), r.Spec.Neutron.Enabled, depErrorMsg)
-			allErrs = append(allErrs, err)
-		}
-	}
-
-	if r.Spec.Nova.Enabled {
-		if depErrorMsg := r.checkDepsEnabled("Nova"); depErrorMsg != "" {
-			err := field.Invalid(basePath.Child("nova").Child("enabled"), r.Spec.Nova.Enabled, depErrorMsg)
-			allErrs = append(allErrs, err)
-		}
-	}
-
-	if r.Spec.Heat.Enabled {
-		if depErrorMsg := r.checkDepsEnabled("Heat"); depErrorMsg != "" {
-			err := field.Invalid(basePath.Child("heat").Child("enabled"), r.Spec.Heat.Enabled, depErrorMsg)
-			allErrs = append(allErrs, err)
-		}
-	}
-
-	if r.Spec.Swift.Enabled {
-		if depErrorMsg := r.checkDepsEnabled("Swift"); depErrorMsg != "" {
-			err := field.Invalid(basePath.Child("swift").Child("enabled"), r.Spec.Swift.Enabled, depErrorMsg)
-			allErrs = append(allErrs, err)
-		}
-	}
-
-	if r.Spec.Horizon.Enabled {
-		if depErrorMsg := r.checkDepsEnabled("Horizon"); depErrorMsg != "" {
-			err := field.Invalid(basePath.Child("horizon").Child("enabled"), r.Spec.Horizon.Enabled, depErrorMsg)
-			allErrs = append(allErrs, err)
-		}
-	}
-
-	if r.Spec.Octavia.Enabled {
-		if depErrorMsg := r.checkDepsEnabled("Octavia"); depErrorMsg != "" {
-			err := field.Invalid(basePath.Child("octavia").Child("enabled"), r.Spec.Octavia.Enabled, depErrorMsg)
-			allErrs = append(allErrs, err)
+		path := basePath.Child("galera").Child("templates").Key(name).Child("replicas")
+		if oldTemplates != nil {
+			if oldTemplate, exists := (*oldTemplates)[name]; exists && ptr.Deref(oldTemplate.Replicas, 0) == replicas {
+				allWarn = append(allWarn, fmt.Sprintf(
+					"%s: galera replicas %d can't keep the quorum, an odd number is recommended", path, replicas))
+				continue
+			}
 		}
+		allErrs = append(allErrs, field.Invalid(
+			path, replicas, "galera replicas must be an odd number to keep the quorum"))
 	}
 
-	if r.Spec.Barbican.Enabled {
-		if depErrorMsg := r.checkDepsEnabled("Barbican"); depErrorMsg != "" {
-			err := field.Invalid(basePath.Child("barbican").Child("enabled"), r.Spec.Barbican.Enabled, depErrorMsg)
-			allErrs = append(allErrs, err)
-		}
-	}
-	if r.Spec.Telemetry.Enabled &&
-		r.Spec.Telemetry.Template.Ceilometer.Enabled != nil &&
-		*r.Spec.Telemetry.Template.Ceilometer.Enabled {
+	return allWarn, allErrs
+}
 
-		if depErrorMsg := r.checkDepsEnabled("Telemetry.Ceilometer"); depErrorMsg != "" {
-			err := field.Invalid(basePath.Child("telemetry").Child("template").Child("ceilometer").Child("enabled"),
-				*r.Spec.Telemetry.Template.Ceilometer.Enabled, depErrorMsg)
-			allErrs = append(allErrs, err)
-		}
-	}
-	if r.Spec.Telemetry.Enabled &&
-		r.Spec.Telemetry.Template.Autoscaling.Enabled != nil &&
-		*r.Spec.Telemetry.Template.Autoscaling.Enabled {
+// ValidateServiceDependencies ensures that when a service is enabled then all the services it depends on are also
+// enabled
+func (r *OpenStackControlPlane) ValidateServiceDependencies(basePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
 
-		if depErrorMsg := r.checkDepsEnabled("Telemetry.Autoscaling"); depErrorMsg != "" {
-			err := field.Invalid(basePath.Child("telemetry").Child("template").Child("autoscaling").Child("enabled"),
-				*r.Spec.Telemetry.Template.Autoscaling.Enabled, depErrorMsg)
-			allErrs = append(allErrs, err)
+	for _, dep := range serviceDependencies {
+		if !r.isServiceEnabled(dep.name) {
+			continue
 		}
-	}
-	if r.Spec.Watcher.Enabled {
-		if depErrorMsg := r.checkDepsEnabled("Watcher"); depErrorMsg != "" {
-			err := field.Invalid(basePath.Child("watcher").Child("enabled"), r.Spec.Watcher.Enabled, depErrorMsg)
+		if depErrorMsg := r.checkDepsEnabled(dep.name); depErrorMsg != "" {
+			err := field.Invalid(basePath.Child(dep.path[0], dep.path[1:]...), true, depErrorMsg)
 			allErrs = append(allErrs, err)
 		}
 	}
@@ -969,6 +890,10 @@ func setOverrideSpec(override **route.OverrideSpec, anno map[string]string) {
 
 // DefaultServices - common function for calling individual services' defaulting functions
 func (r *OpenStackControlPlane) DefaultServices() {
+	// Nova cells - first, they create the galera and rabbitmq templates of the
+	// cells which are defaulted below
+	r.DefaultNovaCells()
+
 	// Cinder
 	if r.Spec.Cinder.Enabled || r.Spec.Cinder.Template != nil {
 		if r.Spec.Cinder.Template == nil {
@@ -1246,7 +1171,6 @@ func (r *OpenStackControlPlane) DefaultServices() {
 			r.Spec.Watcher.Template.DatabaseInstance = ptr.To("openstack")
 		}
 	}
-
 }
 
 // DefaultLabel - adding default label to the OpenStackControlPlane
@@ -1293,6 +1217,11 @@ func (r *OpenStackControlPlane) ValidateTopology(basePath *field.Path) *field.Er
 			return err
 		}
 	}
+	if r.Spec.Scheduling != nil && r.Spec.Scheduling.TopologyRef != nil {
+		if err := topologyv1.ValidateTopologyNamespace(r.Spec.Scheduling.TopologyRef.Namespace, *basePath.Child("scheduling"), r.Namespace); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -10,14 +10,18 @@ import (
 	rabbitmqv1 "github.com/openstack-k8s-operators/infra-operator/apis/rabbitmq/v1beta1"
 	ironicv1 "github.com/openstack-k8s-operators/ironic-operator/api/v1beta1"
 	keystonev1 "github.com/openstack-k8s-operators/keystone-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/route"
 	manilav1 "github.com/openstack-k8s-operators/manila-operator/api/v1beta1"
 	mariadbv1 "github.com/openstack-k8s-operators/mariadb-operator/api/v1beta1"
 	neutronv1 "github.com/openstack-k8s-operators/neutron-operator/api/v1beta1"
 	novav1 "github.com/openstack-k8s-operators/nova-operator/api/nova/v1beta1"
+	placementv1 "github.com/openstack-k8s-operators/nova-operator/api/placement/v1beta1"
 	octaviav1 "github.com/openstack-k8s-operators/octavia-operator/api/v1beta1"
 	swiftv1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
 	telemetryv1 "github.com/openstack-k8s-operators/telemetry-operator/api/v1beta1"
 	watcherv1 "github.com/openstack-k8s-operators/watcher-operator/api/v1beta1"
+	k8s_corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
@@ -261,6 +265,693 @@ var _ = Describe("OpenStackControlPlane Webhook", func() {
 		})
 	})
 
+	Context("ValidateInstanceDeletion", func() {
+		var instance *OpenStackControlPlane
+		var oldSpec OpenStackControlPlaneSpec
+		var basePath *field.Path
+
+		BeforeEach(func() {
+			instance = &OpenStackControlPlane{
+				Spec: OpenStackControlPlaneSpec{
+					Galera: GaleraSection{
+						Enabled: true,
+						Templates: ptr.To(map[string]mariadbv1.GaleraSpecCore{
+							"openstack": {},
+						}),
+					},
+					Rabbitmq: RabbitmqSection{
+						Templates: ptr.To(map[string]rabbitmqv1.RabbitMqSpecCore{
+							"rabbitmq": {},
+						}),
+					},
+				},
+			}
+			cinderTemplate := &cinderv1.CinderSpecCore{}
+			cinderTemplate.DatabaseInstance = "openstack"
+			instance.Spec.Cinder.Enabled = true
+			instance.Spec.Cinder.Template = cinderTemplate
+			novaTemplate := &novav1.NovaSpecCore{}
+			novaTemplate.CellTemplates = map[string]novav1.NovaCellTemplate{
+				"cell1": {
+					CellDatabaseInstance:   "openstack-cell1",
+					CellMessageBusInstance: "rabbitmq-cell1",
+				},
+			}
+			instance.Spec.Nova.Enabled = true
+			instance.Spec.Nova.Template = novaTemplate
+
+			oldSpec = *instance.Spec.DeepCopy()
+			(*oldSpec.Galera.Templates)["openstack-cell1"] = mariadbv1.GaleraSpecCore{}
+			(*oldSpec.Rabbitmq.Templates)["rabbitmq-cell1"] = rabbitmqv1.RabbitMqSpecCore{}
+			basePath = field.NewPath("spec")
+		})
+
+		It("should collect the references of the enabled services", func() {
+			instance.Spec.Manila.Enabled = false
+			instance.Spec.Manila.Template = &manilav1.ManilaSpecCore{}
+			instance.Spec.Manila.Template.DatabaseInstance = "openstack-cell1"
+
+			refs, err := instance.GetGaleraReferences()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(refs).To(Equal(map[string][]string{
+				"openstack":       {"spec.cinder.template.databaseInstance"},
+				"openstack-cell1": {"spec.nova.template.cellTemplates.cell1.cellDatabaseInstance"},
+			}))
+
+			refs, err = instance.GetRabbitMqReferences()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(refs).To(Equal(map[string][]string{
+				"rabbitmq-cell1": {"spec.nova.template.cellTemplates.cell1.cellMessageBusInstance"},
+			}))
+		})
+
+		It("should reject the removal of referenced instances", func() {
+			instance.SetAnnotations(map[string]string{
+				ConfirmDeleteAnnotation: "openstack-cell1,rabbitmq-cell1",
+			})
+
+			errs := instance.ValidateInstanceDeletion(oldSpec, basePath)
+			Expect(errs).To(HaveLen(2))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeForbidden))
+			Expect(errs[0].Field).To(Equal("spec.galera.templates[openstack-cell1]"))
+			Expect(errs[0].Detail).To(ContainSubstring("spec.nova.template.cellTemplates.cell1.cellDatabaseInstance"))
+			Expect(errs[1].Type).To(Equal(field.ErrorTypeForbidden))
+			Expect(errs[1].Field).To(Equal("spec.rabbitmq.templates[rabbitmq-cell1]"))
+			Expect(errs[1].Detail).To(ContainSubstring("spec.nova.template.cellTemplates.cell1.cellMessageBusInstance"))
+		})
+
+		It("should require the confirm-delete annotation for unreferenced instances", func() {
+			instance.Spec.Nova.Template.CellTemplates = map[string]novav1.NovaCellTemplate{}
+
+			errs := instance.ValidateInstanceDeletion(oldSpec, basePath)
+			Expect(errs).To(HaveLen(2))
+			Expect(errs[0].Field).To(Equal("spec.galera.templates[openstack-cell1]"))
+			Expect(errs[0].Detail).To(ContainSubstring(ConfirmDeleteAnnotation))
+			Expect(errs[1].Field).To(Equal("spec.rabbitmq.templates[rabbitmq-cell1]"))
+			Expect(errs[1].Detail).To(ContainSubstring(ConfirmDeleteAnnotation))
+
+			instance.SetAnnotations(map[string]string{
+				ConfirmDeleteAnnotation: "openstack-cell1, rabbitmq-cell1",
+			})
+			Expect(instance.ValidateInstanceDeletion(oldSpec, basePath)).To(BeEmpty())
+		})
+
+		It("should allow keeping the templates", func() {
+			oldSpec = *instance.Spec.DeepCopy()
+			Expect(instance.ValidateInstanceDeletion(oldSpec, basePath)).To(BeEmpty())
+		})
+	})
+
+	Context("ServiceDependencies", func() {
+		var instance *OpenStackControlPlane
+		var basePath *field.Path
+
+		BeforeEach(func() {
+			instance = &OpenStackControlPlane{}
+			instance.Spec.Galera.Enabled = true
+			instance.Spec.Memcached.Enabled = true
+			instance.Spec.Rabbitmq.Enabled = true
+			instance.Spec.Keystone.Enabled = true
+			instance.Spec.Glance.Enabled = true
+			instance.Spec.Heat.Enabled = true
+			instance.Spec.Telemetry.Enabled = true
+			instance.Spec.Telemetry.Template = &telemetryv1.TelemetrySpecCore{}
+			instance.Spec.Telemetry.Template.Autoscaling.Enabled = ptr.To(true)
+			basePath = field.NewPath("spec")
+		})
+
+		It("should reject an enabled service whose dependencies are disabled", func() {
+			Expect(instance.ValidateServiceDependencies(basePath)).To(BeEmpty())
+
+			instance.Spec.Nova.Enabled = true
+			instance.Spec.Telemetry.Template.Ceilometer.Enabled = ptr.To(true)
+			instance.Spec.Rabbitmq.Enabled = false
+
+			errs := instance.ValidateServiceDependencies(basePath)
+			Expect(errs).To(HaveLen(5))
+			Expect(errs[0].Field).To(Equal("spec.keystone.enabled"))
+			Expect(errs[1].Field).To(Equal("spec.nova.enabled"))
+			Expect(errs[1].Detail).To(Equal(
+				"Nova requires these services to be enabled: Galera, Memcached, RabbitMQ, Keystone, Glance, Neutron, Placement."))
+			Expect(errs[2].Field).To(Equal("spec.heat.enabled"))
+			Expect(errs[3].Field).To(Equal("spec.telemetry.template.ceilometer.enabled"))
+			Expect(errs[4].Field).To(Equal("spec.telemetry.template.autoscaling.enabled"))
+		})
+
+		It("should not check the dependencies of CloudKitty", func() {
+			instance.Spec.Telemetry.Template.Autoscaling.Enabled = ptr.To(false)
+			instance.Spec.Telemetry.Template.CloudKitty.Enabled = ptr.To(true)
+			instance.Spec.Rabbitmq.Enabled = false
+			instance.Spec.Keystone.Enabled = false
+			instance.Spec.Glance.Enabled = false
+
+			Expect(instance.ValidateServiceDependencies(basePath)).To(BeEmpty())
+			Expect(instance.GetServiceDependencies("Telemetry")).To(BeEmpty())
+		})
+
+		It("should list the dependencies of the enabled components of a service", func() {
+			Expect(instance.GetServiceDependencies("Telemetry")).To(Equal(
+				[]string{"Galera", "Heat", "RabbitMQ", "Keystone"}))
+
+			instance.Spec.Telemetry.Template.Autoscaling.Enabled = ptr.To(false)
+			instance.Spec.Telemetry.Template.Ceilometer.Enabled = ptr.To(true)
+			Expect(instance.GetServiceDependencies("Telemetry")).To(Equal(
+				[]string{"RabbitMQ", "Keystone"}))
+
+			Expect(instance.GetServiceDependencies("Redis")).To(BeEmpty())
+		})
+
+		It("should report the dependencies disabled or not ready", func() {
+			Expect(instance.GetServiceDependenciesNotReady("Glance", false)).To(BeEmpty())
+			Expect(instance.GetServiceDependenciesNotReady("Glance", true)).To(Equal(
+				[]string{"Galera", "Memcached", "Keystone"}))
+
+			instance.Status.Conditions.MarkTrue(OpenStackControlPlaneMariaDBReadyCondition, "ready")
+			instance.Status.Conditions.MarkTrue(OpenStackControlPlaneMemcachedReadyCondition, "ready")
+			Expect(instance.GetServiceDependenciesNotReady("Glance", true)).To(Equal(
+				[]string{"Keystone"}))
+
+			instance.Spec.Keystone.Enabled = false
+			Expect(instance.GetServiceDependenciesNotReady("Glance", false)).To(Equal(
+				[]string{"Keystone (disabled)"}))
+			Expect(GetServiceReadyCondition("Glance")).To(Equal(OpenStackControlPlaneGlanceReadyCondition))
+		})
+
+		It("should wait for ready dependencies unless the annotation opts out", func() {
+			Expect(instance.IsWaitingForDependencies()).To(BeTrue())
+			instance.SetAnnotations(map[string]string{WaitForDependenciesAnnotation: "false"})
+			Expect(instance.IsWaitingForDependencies()).To(BeFalse())
+		})
+	})
+
+	Context("MessagingTopology", func() {
+		var instance *OpenStackControlPlane
+		var basePath *field.Path
+
+		BeforeEach(func() {
+			instance = &OpenStackControlPlane{}
+			instance.Spec.Rabbitmq.Enabled = true
+			instance.Spec.Rabbitmq.Templates = &map[string]rabbitmqv1.RabbitMqSpecCore{
+				"rabbitmq":               {},
+				"rabbitmq-cell1":         {},
+				"rabbitmq-notifications": {},
+			}
+			instance.Spec.Cinder.Enabled = true
+			instance.Spec.Glance.Enabled = true
+			instance.Spec.Nova.Enabled = true
+			instance.Spec.Nova.Template = &novav1.NovaSpecCore{
+				CellTemplates: map[string]novav1.NovaCellTemplate{
+					"cell0": {},
+					"cell1": {},
+				},
+			}
+			instance.Spec.MessagingTopology = &MessagingTopologySection{
+				Buses: map[string]rabbitmqv1.RabbitMqConfig{
+					"rpc":           {Cluster: "rabbitmq"},
+					"cell1":         {Cluster: "rabbitmq-cell1", Vhost: "cell1"},
+					"notifications": {Cluster: "rabbitmq-notifications"},
+				},
+				Services: map[string]MessagingTopologyBinding{
+					"cinder": {RPC: "rpc"},
+					"nova":   {RPC: "rpc"},
+				},
+				NovaCells: map[string]string{
+					"cell1": "cell1",
+				},
+				Notifications: "notifications",
+			}
+			basePath = field.NewPath("spec")
+		})
+
+		It("should propagate the buses into the templates not setting their own", func() {
+			instance.Spec.Cinder.Template = &cinderv1.CinderSpecCore{}
+			instance.Spec.Cinder.Template.NotificationsBus = &rabbitmqv1.RabbitMqConfig{Cluster: "rabbitmq"}
+			// manila is disabled, its template is left unchanged
+			instance.Spec.MessagingTopology.Services["manila"] = MessagingTopologyBinding{RPC: "rpc"}
+
+			instance.ApplyMessagingTopology()
+
+			Expect(instance.Spec.Cinder.Template.MessagingBus).To(Equal(rabbitmqv1.RabbitMqConfig{Cluster: "rabbitmq"}))
+			Expect(instance.Spec.Cinder.Template.NotificationsBus).To(Equal(&rabbitmqv1.RabbitMqConfig{Cluster: "rabbitmq"}))
+			Expect(instance.Spec.Glance.Template.NotificationsBus).To(Equal(&rabbitmqv1.RabbitMqConfig{Cluster: "rabbitmq-notifications"}))
+			Expect(instance.Spec.Nova.Template.MessagingBus).To(Equal(rabbitmqv1.RabbitMqConfig{Cluster: "rabbitmq"}))
+			Expect(instance.Spec.Nova.Template.NotificationsBus).To(Equal(&rabbitmqv1.RabbitMqConfig{Cluster: "rabbitmq-notifications"}))
+			Expect(instance.Spec.Nova.Template.CellTemplates["cell0"].MessagingBus.Cluster).To(BeEmpty())
+			Expect(instance.Spec.Nova.Template.CellTemplates["cell1"].MessagingBus).To(Equal(
+				rabbitmqv1.RabbitMqConfig{Cluster: "rabbitmq-cell1", Vhost: "cell1"}))
+			Expect(instance.Spec.Manila.Template).To(BeNil())
+		})
+
+		It("should reject dangling references", func() {
+			Expect(instance.ValidateMessagingTopology(basePath)).To(BeEmpty())
+
+			instance.Spec.MessagingTopology.Buses["cell2"] = rabbitmqv1.RabbitMqConfig{Cluster: "rabbitmq-cell2", User: "cell2"}
+			instance.Spec.MessagingTopology.Services["glance"] = MessagingTopologyBinding{RPC: "rpc"}
+			instance.Spec.MessagingTopology.Services["neutron"] = MessagingTopologyBinding{Notifications: "missing"}
+			instance.Spec.MessagingTopology.Services["unknown"] = MessagingTopologyBinding{RPC: "rpc"}
+			instance.Spec.MessagingTopology.NovaCells["cell2"] = "cell2"
+
+			errs := instance.ValidateMessagingTopology(basePath)
+			Expect(errs).To(HaveLen(6))
+			Expect(errs[0].Field).To(Equal("spec.messagingTopology.buses[cell2].user"))
+			Expect(errs[1].Field).To(Equal("spec.messagingTopology.buses[cell2].cluster"))
+			Expect(errs[2].Field).To(Equal("spec.messagingTopology.services[glance].rpc"))
+			Expect(errs[3].Field).To(Equal("spec.messagingTopology.services[neutron].notifications"))
+			Expect(errs[4].Field).To(Equal("spec.messagingTopology.services[unknown]"))
+			Expect(errs[5].Field).To(Equal("spec.messagingTopology.novaCells[cell2]"))
+		})
+
+		It("should protect the RabbitMQ instances of the buses from deletion", func() {
+			refs, err := instance.GetRabbitMqReferences()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(refs).To(HaveKeyWithValue("rabbitmq-cell1",
+				[]string{"spec.messagingTopology.buses[cell1].cluster"}))
+		})
+	})
+
+	Context("NovaCells", func() {
+		var instance *OpenStackControlPlane
+		var basePath *field.Path
+
+		BeforeEach(func() {
+			instance = &OpenStackControlPlane{}
+			instance.Spec.Galera.Enabled = true
+			instance.Spec.Galera.Templates = ptr.To(map[string]mariadbv1.GaleraSpecCore{
+				"openstack": {},
+			})
+			instance.Spec.Rabbitmq.Enabled = true
+			instance.Spec.Rabbitmq.Templates = ptr.To(map[string]rabbitmqv1.RabbitMqSpecCore{
+				"rabbitmq": {},
+			})
+			instance.Spec.Nova.Enabled = true
+			instance.Spec.Nova.Template = &novav1.NovaSpecCore{
+				CellTemplates: map[string]novav1.NovaCellTemplate{
+					"cell0": {CellDatabaseAccount: "nova-cell0", CellDatabaseInstance: "openstack", HasAPIAccess: true},
+				},
+			}
+			instance.Spec.Nova.Cells = map[string]NovaCellSection{
+				"cell0": {},
+				"cell1": {},
+				"cell2": {
+					Galera:   "openstack-cell1",
+					Rabbitmq: "rabbitmq-cell1",
+					NoVNCProxy: &NovaCellNoVNCProxySection{
+						Enabled: ptr.To(true),
+						Override: &Override{
+							TLS: &TLSServiceOverride{},
+						},
+					},
+				},
+			}
+			basePath = field.NewPath("spec")
+		})
+
+		It("should generate the cell templates and the galera and rabbitmq templates of the cells", func() {
+			instance.DefaultNovaCells()
+
+			Expect(*instance.Spec.Galera.Templates).To(HaveLen(2))
+			Expect(*instance.Spec.Galera.Templates).To(HaveKey("openstack-cell1"))
+			Expect(*instance.Spec.Rabbitmq.Templates).To(HaveLen(2))
+			Expect(*instance.Spec.Rabbitmq.Templates).To(HaveKey("rabbitmq-cell1"))
+
+			cellTemplates := instance.Spec.Nova.Template.CellTemplates
+			Expect(cellTemplates).To(HaveLen(3))
+			Expect(cellTemplates["cell0"].CellDatabaseInstance).To(Equal("openstack"))
+			Expect(cellTemplates["cell0"].MessagingBus.Cluster).To(BeEmpty())
+			Expect(cellTemplates["cell1"].CellDatabaseAccount).To(Equal("nova-cell1"))
+			Expect(cellTemplates["cell1"].CellDatabaseInstance).To(Equal("openstack-cell1"))
+			Expect(cellTemplates["cell1"].MessagingBus.Cluster).To(Equal("rabbitmq-cell1"))
+			Expect(cellTemplates["cell2"].CellDatabaseAccount).To(Equal("nova-cell2"))
+			Expect(cellTemplates["cell2"].CellDatabaseInstance).To(Equal("openstack-cell1"))
+			Expect(cellTemplates["cell2"].MessagingBus.Cluster).To(Equal("rabbitmq-cell1"))
+			Expect(cellTemplates["cell2"].NoVNCProxyServiceTemplate.Enabled).To(Equal(ptr.To(true)))
+			Expect(instance.Spec.Nova.CellOverride).To(HaveKey("cell2"))
+			Expect(instance.Spec.Nova.CellOverride["cell2"].NoVNCProxy.TLS).ToNot(BeNil())
+		})
+
+		It("should warn about instances shared by several cells without enough replicas", func() {
+			instance.DefaultNovaCells()
+
+			warns, errs := instance.ValidateNovaCells(basePath)
+			Expect(errs).To(BeEmpty())
+			Expect(warns).To(Equal([]string{
+				"galera instance openstack-cell1 is shared by the nova cells cell1, cell2 with 1 replicas, at least 3 replicas are recommended",
+				"rabbitmq instance rabbitmq-cell1 is shared by the nova cells cell1, cell2 with 1 replicas, at least 3 replicas are recommended",
+			}))
+
+			galera := (*instance.Spec.Galera.Templates)["openstack-cell1"]
+			galera.Replicas = ptr.To[int32](3)
+			(*instance.Spec.Galera.Templates)["openstack-cell1"] = galera
+			warns, _ = instance.ValidateNovaCells(basePath)
+			Expect(warns).To(HaveLen(1))
+		})
+
+		It("should keep the messaging bus of the cell template and of the messaging topology", func() {
+			instance.Spec.Nova.Template.CellTemplates["cell1"] = novav1.NovaCellTemplate{
+				MessagingBus: rabbitmqv1.RabbitMqConfig{Cluster: "rabbitmq"},
+			}
+			instance.Spec.Nova.Cells["cell3"] = NovaCellSection{}
+			instance.Spec.MessagingTopology = &MessagingTopologySection{
+				Buses:     map[string]rabbitmqv1.RabbitMqConfig{"cells": {Cluster: "rabbitmq", Vhost: "cells"}},
+				NovaCells: map[string]string{"cell3": "cells"},
+			}
+
+			instance.DefaultNovaCells()
+
+			cellTemplates := instance.Spec.Nova.Template.CellTemplates
+			Expect(cellTemplates["cell1"].MessagingBus.Cluster).To(Equal("rabbitmq"))
+			Expect(cellTemplates["cell3"].MessagingBus.Cluster).To(BeEmpty())
+			Expect(*instance.Spec.Rabbitmq.Templates).ToNot(HaveKey("rabbitmq-cell3"))
+
+			instance.ApplyMessagingTopology()
+			Expect(instance.Spec.Nova.Template.CellTemplates["cell3"].MessagingBus).To(Equal(
+				rabbitmqv1.RabbitMqConfig{Cluster: "rabbitmq", Vhost: "cells"}))
+		})
+
+		It("should keep the database instance of the cell template", func() {
+			instance.Spec.Galera.Templates = ptr.To(map[string]mariadbv1.GaleraSpecCore{
+				"openstack": {},
+				"custom-db": {},
+			})
+			instance.Spec.Nova.Template.CellTemplates["cell1"] = novav1.NovaCellTemplate{
+				CellDatabaseAccount:  "nova-cell1",
+				CellDatabaseInstance: "custom-db",
+			}
+			instance.Spec.Nova.Template.CellTemplates["cell2"] = novav1.NovaCellTemplate{
+				CellDatabaseAccount:  "nova-cell2",
+				CellDatabaseInstance: "custom-db",
+			}
+
+			instance.DefaultNovaCells()
+
+			cellTemplates := instance.Spec.Nova.Template.CellTemplates
+			Expect(cellTemplates["cell1"].CellDatabaseInstance).To(Equal("custom-db"))
+			Expect(cellTemplates["cell2"].CellDatabaseInstance).To(Equal("openstack-cell1"))
+			Expect(*instance.Spec.Galera.Templates).To(HaveLen(3))
+			Expect(*instance.Spec.Galera.Templates).To(HaveKey("custom-db"))
+			Expect(*instance.Spec.Galera.Templates).To(HaveKey("openstack-cell1"))
+
+			warns, errs := instance.ValidateNovaCells(basePath)
+			Expect(errs).To(BeEmpty())
+			Expect(warns).To(Equal([]string{
+				"rabbitmq instance rabbitmq-cell1 is shared by the nova cells cell1, cell2 with 1 replicas, at least 3 replicas are recommended",
+			}))
+		})
+
+		It("should reject a rabbitmq for a cell bound by the messaging topology", func() {
+			instance.Spec.MessagingTopology = &MessagingTopologySection{
+				Buses:     map[string]rabbitmqv1.RabbitMqConfig{"cells": {Cluster: "rabbitmq"}},
+				NovaCells: map[string]string{"cell2": "cells"},
+			}
+
+			_, errs := instance.ValidateNovaCells(basePath)
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("spec.nova.cells[cell2].rabbitmq"))
+		})
+
+		It("should merge the noVNCProxy override into the override of the cell", func() {
+			instance.Spec.Nova.CellOverride = map[string]NovaCellOverrideSpec{
+				"cell2": {NoVNCProxy: Override{Route: &route.OverrideSpec{}}},
+			}
+
+			instance.DefaultNovaCells()
+
+			Expect(instance.Spec.Nova.CellOverride["cell2"].NoVNCProxy.Route).ToNot(BeNil())
+			Expect(instance.Spec.Nova.CellOverride["cell2"].NoVNCProxy.TLS).ToNot(BeNil())
+		})
+
+		It("should reject a noVNCProxy for cell0", func() {
+			instance.Spec.Nova.Cells["cell0"] = NovaCellSection{
+				NoVNCProxy: &NovaCellNoVNCProxySection{Enabled: ptr.To(true)},
+			}
+
+			_, errs := instance.ValidateNovaCells(basePath)
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("spec.nova.cells[cell0].noVNCProxy"))
+		})
+	})
+
+	Context("GaleraReplicas", func() {
+		It("should reject an even number of galera replicas", func() {
+			instance := &OpenStackControlPlane{}
+			instance.Spec.Galera.Enabled = true
+			instance.Spec.Galera.Templates = ptr.To(map[string]mariadbv1.GaleraSpecCore{
+				"openstack":       {Replicas: ptr.To[int32](3)},
+				"openstack-cell1": {Replicas: ptr.To[int32](2)},
+				"openstack-cell2": {Replicas: ptr.To[int32](0)},
+				"openstack-cell3": {},
+			})
+
+			warns, errs := instance.ValidateGaleraReplicas(field.NewPath("spec"), nil)
+			Expect(warns).To(BeEmpty())
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("spec.galera.templates[openstack-cell1].replicas"))
+		})
+
+		It("should only warn about an unchanged even number of galera replicas", func() {
+			instance := &OpenStackControlPlane{}
+			instance.Spec.Galera.Enabled = true
+			instance.Spec.Galera.Templates = ptr.To(map[string]mariadbv1.GaleraSpecCore{
+				"openstack":       {Replicas: ptr.To[int32](2)},
+				"openstack-cell1": {Replicas: ptr.To[int32](4)},
+			})
+			oldTemplates := ptr.To(map[string]mariadbv1.GaleraSpecCore{
+				"openstack":       {Replicas: ptr.To[int32](2)},
+				"openstack-cell1": {Replicas: ptr.To[int32](3)},
+			})
+
+			warns, errs := instance.ValidateGaleraReplicas(field.NewPath("spec"), oldTemplates)
+			Expect(warns).To(Equal([]string{
+				"spec.galera.templates[openstack].replicas: galera replicas 2 can't keep the quorum, an odd number is recommended",
+			}))
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("spec.galera.templates[openstack-cell1].replicas"))
+		})
+	})
+
+	Context("ManagementState", func() {
+		It("should report the enabled services set to Unmanaged", func() {
+			instance := &OpenStackControlPlane{}
+			instance.Spec.Keystone.Enabled = true
+			instance.Spec.Glance.Enabled = true
+			instance.Spec.Glance.ManagementState = ManagementStateUnmanaged
+			instance.Spec.Cinder.Enabled = true
+			instance.Spec.Cinder.ManagementState = ManagementStateUnmanaged
+			instance.Spec.Nova.Enabled = true
+			instance.Spec.Nova.ManagementState = ManagementStateManaged
+			instance.Spec.Heat.ManagementState = ManagementStateUnmanaged
+			instance.Spec.Galera.Enabled = true
+			instance.Spec.Galera.ManagementState = ManagementStateUnmanaged
+			instance.Spec.Ovn.Enabled = true
+
+			Expect(instance.IsServiceUnmanaged("Glance")).To(BeTrue())
+			Expect(instance.IsServiceUnmanaged("Galera")).To(BeTrue())
+			Expect(instance.IsServiceUnmanaged("Keystone")).To(BeFalse())
+			Expect(instance.IsServiceUnmanaged("Nova")).To(BeFalse())
+			Expect(instance.IsServiceUnmanaged("OVN")).To(BeFalse())
+			// a disabled service isn't reported
+			Expect(instance.IsServiceUnmanaged("Heat")).To(BeFalse())
+			Expect(instance.GetUnmanagedServices()).To(Equal([]string{"Cinder", "Galera", "Glance"}))
+		})
+	})
+
+	Context("Scheduling", func() {
+		It("should apply the scheduling policy to the templates not setting their own", func() {
+			instance := &OpenStackControlPlane{}
+			instance.Spec.NodeSelector = map[string]string{"foo": "bar"}
+			instance.Spec.Scheduling = &SchedulingSection{
+				NodeSelector:    ptr.To(map[string]string{"foo": "scheduling"}),
+				Tolerations:     []k8s_corev1.Toleration{{Key: "openstack", Operator: k8s_corev1.TolerationOpExists}},
+				PodAntiAffinity: PodAntiAffinityPreferred,
+			}
+			instance.Spec.Keystone.Enabled = true
+			instance.Spec.Keystone.Template = &keystonev1.KeystoneAPISpecCore{}
+			instance.Spec.Cinder.Enabled = true
+			instance.Spec.Cinder.Template = &cinderv1.CinderSpecCore{}
+			instance.Spec.Cinder.Template.NodeSelector = ptr.To(map[string]string{"foo": "cinder"})
+			instance.Spec.Rabbitmq.Enabled = true
+			instance.Spec.Rabbitmq.Templates = &map[string]rabbitmqv1.RabbitMqSpecCore{
+				"rabbitmq": {},
+			}
+
+			Expect(*instance.GetSchedulingNodeSelector()).To(Equal(map[string]string{"foo": "scheduling"}))
+			status := instance.GetSchedulingStatus()
+			Expect(status["keystone"].NodeSelector).To(Equal(map[string]string{"foo": "scheduling"}))
+			Expect(status["cinder"].NodeSelector).To(Equal(map[string]string{"foo": "cinder"}))
+			Expect(status["keystone"].Tolerations).To(BeEmpty())
+			Expect(status["rabbitmq/rabbitmq"].Tolerations).To(HaveLen(1))
+			Expect(status["rabbitmq/rabbitmq"].PodAntiAffinity).To(Equal(PodAntiAffinityPreferred))
+			// the fields the templates don't support are reported
+			Expect(status["keystone"].IgnoredFields).To(Equal([]string{"tolerations", "podAntiAffinity"}))
+			Expect(status["rabbitmq/rabbitmq"].IgnoredFields).To(BeEmpty())
+
+			// without a scheduling policy the top-level nodeSelector applies
+			instance.Spec.Scheduling = nil
+			Expect(*instance.GetSchedulingNodeSelector()).To(Equal(map[string]string{"foo": "bar"}))
+			Expect(instance.GetSchedulingStatus()["keystone"].NodeSelector).To(Equal(map[string]string{"foo": "bar"}))
+			Expect(instance.GetSchedulingStatus()["keystone"].IgnoredFields).To(BeEmpty())
+		})
+
+		It("should build the pod anti-affinity of the presets", func() {
+			labels := map[string]string{"app.kubernetes.io/name": "rabbitmq"}
+			Expect(GetPodAntiAffinity("", labels)).To(BeNil())
+
+			preferred := GetPodAntiAffinity(PodAntiAffinityPreferred, labels)
+			Expect(preferred.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution).To(HaveLen(1))
+			Expect(preferred.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution).To(BeEmpty())
+
+			required := GetPodAntiAffinity(PodAntiAffinityRequired, labels)
+			Expect(required.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution).To(HaveLen(1))
+			term := required.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution[0]
+			Expect(term.TopologyKey).To(Equal(k8s_corev1.LabelHostname))
+			Expect(term.LabelSelector.MatchLabels).To(Equal(labels))
+		})
+	})
+
+	Context("Sizing", func() {
+		It("should apply the sizing profile to the fields left unset", func() {
+			instance := &OpenStackControlPlane{}
+			instance.Spec.Sizing = &SizingSection{Profile: SizingProfileLarge}
+			instance.Spec.Keystone.Enabled = true
+			instance.Spec.Keystone.Template = &keystonev1.KeystoneAPISpecCore{}
+			instance.Spec.Keystone.Template.Resources.Limits = k8s_corev1.ResourceList{
+				k8s_corev1.ResourceMemory: resource.MustParse("8Gi"),
+			}
+			instance.Spec.Placement.Enabled = true
+			instance.Spec.Placement.Template = &placementv1.PlacementAPISpecCore{}
+			// the CRD default counts as unset
+			instance.Spec.Placement.Template.Replicas = ptr.To[int32](1)
+			instance.Spec.Neutron.Enabled = true
+			instance.Spec.Neutron.Template = &neutronv1.NeutronAPISpecCore{}
+			instance.Spec.Neutron.Template.Replicas = ptr.To[int32](2)
+			instance.Spec.Galera.Enabled = true
+			instance.Spec.Galera.Templates = &map[string]mariadbv1.GaleraSpecCore{
+				"openstack": {},
+			}
+
+			status := instance.ApplySizing(GetBuiltinSizingProfile(instance.GetSizingProfileName()))
+
+			keystone := instance.Spec.Keystone.Template
+			Expect(*keystone.Replicas).To(Equal(int32(3)))
+			Expect(*keystone.HttpdCustomization.ProcessNumber).To(Equal(int32(6)))
+			Expect(keystone.Resources.Requests.Memory().String()).To(Equal("1Gi"))
+			Expect(keystone.Resources.Limits.Memory().String()).To(Equal("8Gi"))
+			Expect(*instance.Spec.Placement.Template.Replicas).To(Equal(int32(3)))
+			Expect(*instance.Spec.Neutron.Template.Replicas).To(Equal(int32(2)))
+			galera := (*instance.Spec.Galera.Templates)["openstack"]
+			Expect(*galera.Replicas).To(Equal(int32(3)))
+			Expect(galera.Resources.Requests.Memory().String()).To(Equal("4Gi"))
+
+			// the effective sizing and the replaced CRD defaults are reported
+			Expect(*status["keystone"].Replicas).To(Equal(int32(3)))
+			Expect(*status["keystone"].Workers).To(Equal(int32(6)))
+			Expect(status["keystone"].ReplacedFields).To(BeEmpty())
+			Expect(*status["placement"].Replicas).To(Equal(int32(3)))
+			Expect(status["placement"].ReplacedFields).To(Equal([]string{"replicas"}))
+			Expect(*status["neutron"].Replicas).To(Equal(int32(2)))
+			Expect(status["neutron"].ReplacedFields).To(BeEmpty())
+			Expect(*status["galera/openstack"].Replicas).To(Equal(int32(3)))
+		})
+
+		It("should report the explicit keystone workers replaced by the sizing profile", func() {
+			instance := &OpenStackControlPlane{}
+			instance.Spec.Keystone.Enabled = true
+			instance.Spec.Keystone.Template = &keystonev1.KeystoneAPISpecCore{}
+			instance.Spec.Keystone.Template.Replicas = ptr.To[int32](1)
+			instance.Spec.Keystone.Template.HttpdCustomization.ProcessNumber = ptr.To[int32](3)
+
+			// the medium profile keeps 3 workers
+			medium := instance.DeepCopy()
+			status := medium.ApplySizing(GetBuiltinSizingProfile(SizingProfileMedium))
+			Expect(*status["keystone"].Workers).To(Equal(int32(3)))
+			Expect(status["keystone"].ReplacedFields).To(Equal([]string{"replicas"}))
+
+			status = instance.ApplySizing(GetBuiltinSizingProfile(SizingProfileLarge))
+			Expect(*status["keystone"].Workers).To(Equal(int32(6)))
+			Expect(status["keystone"].ReplacedFields).To(Equal([]string{"replicas", "httpdCustomization.processNumber"}))
+		})
+
+		It("should only accept custom profiles from a ConfigMap", func() {
+			basePath := field.NewPath("spec")
+			instance := &OpenStackControlPlane{}
+			Expect(instance.ValidateSizing(basePath)).To(BeEmpty())
+
+			instance.Spec.Sizing = &SizingSection{Profile: SizingProfileSmall}
+			Expect(instance.ValidateSizing(basePath)).To(BeEmpty())
+
+			instance.Spec.Sizing = &SizingSection{Profile: "lab"}
+			errs := instance.ValidateSizing(basePath)
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("spec.sizing.profile"))
+
+			instance.Spec.Sizing.ConfigMap = "sizing-profiles"
+			Expect(instance.ValidateSizing(basePath)).To(BeEmpty())
+		})
+	})
+
+	Context("Autoscaling", func() {
+		var instance *OpenStackControlPlane
+		var basePath *field.Path
+
+		BeforeEach(func() {
+			instance = &OpenStackControlPlane{}
+			instance.Spec.Keystone.Enabled = true
+			instance.Spec.Keystone.Template = &keystonev1.KeystoneAPISpecCore{}
+			instance.Spec.Keystone.Template.Replicas = ptr.To[int32](3)
+			basePath = field.NewPath("spec")
+		})
+
+		It("should keep the template replicas as the minimum", func() {
+			instance.Spec.Keystone.Autoscaling = &AutoscalingSection{
+				MinReplicas: ptr.To[int32](2),
+				MaxReplicas: 5,
+			}
+
+			keystoneAPI := func() AutoscaledAPI {
+				for _, api := range instance.GetAutoscaledAPIs() {
+					if api.Name == "keystone" {
+						return api
+					}
+				}
+				Fail("keystone is not an autoscaled API")
+				return AutoscaledAPI{}
+			}
+			Expect(keystoneAPI().IsAutoscaled()).To(BeTrue())
+			Expect(keystoneAPI().GetMinReplicas()).To(Equal(int32(3)))
+
+			instance.Spec.Keystone.Autoscaling.MinReplicas = ptr.To[int32](4)
+			Expect(keystoneAPI().GetMinReplicas()).To(Equal(int32(4)))
+
+			// a template scaled to zero replicas stops the API
+			instance.Spec.Keystone.Template.Replicas = ptr.To[int32](0)
+			Expect(keystoneAPI().IsAutoscaled()).To(BeFalse())
+			Expect(keystoneAPI().GetMinReplicas()).To(Equal(int32(0)))
+			instance.Spec.Keystone.Template.Replicas = ptr.To[int32](3)
+
+			// unmanaged services are not autoscaled
+			instance.Spec.Keystone.ManagementState = ManagementStateUnmanaged
+			Expect(keystoneAPI().IsAutoscaled()).To(BeFalse())
+		})
+
+		It("should reject less maximum replicas than the minimum ones", func() {
+			instance.Spec.Keystone.Autoscaling = &AutoscalingSection{MaxReplicas: 3}
+			Expect(instance.ValidateAutoscaling(basePath)).To(BeEmpty())
+
+			instance.Spec.Keystone.Autoscaling = &AutoscalingSection{
+				MinReplicas: ptr.To[int32](4),
+				MaxReplicas: 3,
+			}
+			errs := instance.ValidateAutoscaling(basePath)
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("spec.keystone.autoscaling.maxReplicas"))
+
+			instance.Spec.Keystone.Autoscaling = &AutoscalingSection{MaxReplicas: 2}
+			errs = instance.ValidateAutoscaling(basePath)
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Detail).To(ContainSubstring("replicas of the template"))
+		})
+	})
+
 	Context("Service-level messaging bus migrations", func() {
 		var instance *OpenStackControlPlane
 
@@ -0,0 +1,129 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"encoding/json"
+
+	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PlannedAction - the action the OpenStackControlPlane reconcile would take on a resource
+type PlannedAction string
+
+const (
+	// PlannedActionCreate - the resource would be created
+	PlannedActionCreate PlannedAction = "Create"
+	// PlannedActionUpdate - the resource would be changed
+	PlannedActionUpdate PlannedAction = "Update"
+	// PlannedActionDelete - the resource would be deleted
+	PlannedActionDelete PlannedAction = "Delete"
+)
+
+// OpenStackControlPlanePlanSpec defines the proposed change of an OpenStackControlPlane
+type OpenStackControlPlanePlanSpec struct {
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// ControlPlane - name of the OpenStackControlPlane, in the same namespace, the plan is computed for
+	ControlPlane string `json:"controlPlane"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// SpecPatch - merge patch applied to the spec of the OpenStackControlPlane to get the proposed
+	// spec. Maps are merged, keys set to null are removed and lists are replaced. When empty the
+	// plan lists the changes pending for the current spec.
+	SpecPatch map[string]json.RawMessage `json:"specPatch,omitempty"`
+}
+
+// OpenStackControlPlanePlanChange - a resource the OpenStackControlPlane reconcile would change
+type OpenStackControlPlanePlanChange struct {
+	// Action - Create, Update or Delete
+	Action PlannedAction `json:"action"`
+
+	// Kind - kind of the resource
+	Kind string `json:"kind"`
+
+	// Name - name of the resource
+	Name string `json:"name"`
+
+	// Fields - paths of the fields an Update changes
+	Fields []string `json:"fields,omitempty"`
+
+	// Images - container images the change rolls out, as <field path>=<image>
+	Images []string `json:"images,omitempty"`
+}
+
+// OpenStackControlPlanePlanStatus defines the observed state of OpenStackControlPlanePlan
+type OpenStackControlPlanePlanStatus struct {
+	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors={"urn:alm:descriptor:io.kubernetes.conditions"}
+	// Conditions
+	Conditions condition.Conditions `json:"conditions,omitempty" optional:"true"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// Changes - resources the reconcile of the proposed spec would create, change or delete
+	Changes []OpenStackControlPlanePlanChange `json:"changes,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// IncompleteSteps - reconcile steps which stopped early, e.g. waiting for a resource the
+	// plan would create, the changes of the following resources of these steps are missing
+	IncompleteSteps []string `json:"incompleteSteps,omitempty"`
+
+	// ControlPlaneGeneration - the generation of the OpenStackControlPlane the plan was computed for
+	ControlPlaneGeneration int64 `json:"controlPlaneGeneration,omitempty"`
+
+	//ObservedGeneration - the most recent generation observed for this object.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=osctlplan;osctlplans
+// +operator-sdk:csv:customresourcedefinitions:displayName="OpenStack ControlPlane Plan"
+// +kubebuilder:printcolumn:name="ControlPlane",type=string,JSONPath=`.spec.controlPlane`
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[0].status",description="Status"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[0].message",description="Message"
+
+// OpenStackControlPlanePlan is the Schema for the openstackcontrolplaneplans API. It lists the
+// changes applying a proposed spec to an OpenStackControlPlane would make, without making them.
+type OpenStackControlPlanePlan struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OpenStackControlPlanePlanSpec   `json:"spec,omitempty"`
+	Status OpenStackControlPlanePlanStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OpenStackControlPlanePlanList contains a list of OpenStackControlPlanePlan
+type OpenStackControlPlanePlanList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OpenStackControlPlanePlan `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OpenStackControlPlanePlan{}, &OpenStackControlPlanePlanList{})
+}
+
+// IsReady - returns true if the plan was computed for the current spec
+func (instance OpenStackControlPlanePlan) IsReady() bool {
+	return instance.Status.Conditions.IsTrue(condition.ReadyCondition)
+}
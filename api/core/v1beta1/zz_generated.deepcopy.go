@@ -21,6 +21,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	"encoding/json"
 	barbican_operatorapiv1beta1 "github.com/openstack-k8s-operators/barbican-operator/api/v1beta1"
 	cinder_operatorapiv1beta1 "github.com/openstack-k8s-operators/cinder-operator/api/v1beta1"
 	designate_operatorapiv1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
@@ -47,6 +48,7 @@ import (
 	swift_operatorapiv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
 	telemetry_operatorapiv1beta1 "github.com/openstack-k8s-operators/telemetry-operator/api/v1beta1"
 	watcher_operatorapiv1beta1 "github.com/openstack-k8s-operators/watcher-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -106,9 +108,59 @@ func (in *ApplicationCredentialSection) DeepCopy() *ApplicationCredentialSection
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingSection) DeepCopyInto(out *AutoscalingSection) {
+	*out = *in
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetCPUUtilizationPercentage != nil {
+		in, out := &in.TargetCPUUtilizationPercentage, &out.TargetCPUUtilizationPercentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetRequestsPerSecond != nil {
+		in, out := &in.TargetRequestsPerSecond, &out.TargetRequestsPerSecond
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingSection.
+func (in *AutoscalingSection) DeepCopy() *AutoscalingSection {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingSection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingStatus) DeepCopyInto(out *AutoscalingStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingStatus.
+func (in *AutoscalingStatus) DeepCopy() *AutoscalingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BarbicanSection) DeepCopyInto(out *BarbicanSection) {
 	*out = *in
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(AutoscalingSection)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Template != nil {
 		in, out := &in.Template, &out.Template
 		*out = new(barbican_operatorapiv1beta1.BarbicanSpecCore)
@@ -687,6 +739,11 @@ func (in *ContainerTemplate) DeepCopyInto(out *ContainerTemplate) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.OrasImage != nil {
+		in, out := &in.OrasImage, &out.OrasImage
+		*out = new(string)
+		**out = **in
+	}
 	if in.OsContainerImage != nil {
 		in, out := &in.OsContainerImage, &out.OsContainerImage
 		*out = new(string)
@@ -870,6 +927,11 @@ func (in *DNSMasqSection) DeepCopy() *DNSMasqSection {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DesignateSection) DeepCopyInto(out *DesignateSection) {
 	*out = *in
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(AutoscalingSection)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Template != nil {
 		in, out := &in.Template, &out.Template
 		*out = new(designate_operatorapiv1beta1.DesignateSpecCore)
@@ -954,6 +1016,11 @@ func (in *GlanceSection) DeepCopy() *GlanceSection {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HeatSection) DeepCopyInto(out *HeatSection) {
 	*out = *in
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(AutoscalingSection)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Template != nil {
 		in, out := &in.Template, &out.Template
 		*out = new(heat_operatorapiv1beta1.HeatSpecCore)
@@ -981,6 +1048,11 @@ func (in *HeatSection) DeepCopy() *HeatSection {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HorizonSection) DeepCopyInto(out *HorizonSection) {
 	*out = *in
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(AutoscalingSection)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Template != nil {
 		in, out := &in.Template, &out.Template
 		*out = new(horizon_operatorapiv1beta1.HorizonSpecCore)
@@ -1029,6 +1101,11 @@ func (in *IronicSection) DeepCopy() *IronicSection {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeystoneSection) DeepCopyInto(out *KeystoneSection) {
 	*out = *in
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(AutoscalingSection)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Template != nil {
 		in, out := &in.Template, &out.Template
 		*out = new(apiv1beta1.KeystoneAPISpecCore)
@@ -1099,9 +1176,65 @@ func (in *MemcachedSection) DeepCopy() *MemcachedSection {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MessagingTopologyBinding) DeepCopyInto(out *MessagingTopologyBinding) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MessagingTopologyBinding.
+func (in *MessagingTopologyBinding) DeepCopy() *MessagingTopologyBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(MessagingTopologyBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MessagingTopologySection) DeepCopyInto(out *MessagingTopologySection) {
+	*out = *in
+	if in.Buses != nil {
+		in, out := &in.Buses, &out.Buses
+		*out = make(map[string]rabbitmqv1beta1.RabbitMqConfig, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = make(map[string]MessagingTopologyBinding, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NovaCells != nil {
+		in, out := &in.NovaCells, &out.NovaCells
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MessagingTopologySection.
+func (in *MessagingTopologySection) DeepCopy() *MessagingTopologySection {
+	if in == nil {
+		return nil
+	}
+	out := new(MessagingTopologySection)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NeutronSection) DeepCopyInto(out *NeutronSection) {
 	*out = *in
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(AutoscalingSection)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Template != nil {
 		in, out := &in.Template, &out.Template
 		*out = new(neutron_operatorapiv1beta1.NeutronAPISpecCore)
@@ -1125,6 +1258,31 @@ func (in *NeutronSection) DeepCopy() *NeutronSection {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NovaCellNoVNCProxySection) DeepCopyInto(out *NovaCellNoVNCProxySection) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Override != nil {
+		in, out := &in.Override, &out.Override
+		*out = new(Override)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NovaCellNoVNCProxySection.
+func (in *NovaCellNoVNCProxySection) DeepCopy() *NovaCellNoVNCProxySection {
+	if in == nil {
+		return nil
+	}
+	out := new(NovaCellNoVNCProxySection)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NovaCellOverrideSpec) DeepCopyInto(out *NovaCellOverrideSpec) {
 	*out = *in
@@ -1141,6 +1299,26 @@ func (in *NovaCellOverrideSpec) DeepCopy() *NovaCellOverrideSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NovaCellSection) DeepCopyInto(out *NovaCellSection) {
+	*out = *in
+	if in.NoVNCProxy != nil {
+		in, out := &in.NoVNCProxy, &out.NoVNCProxy
+		*out = new(NovaCellNoVNCProxySection)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NovaCellSection.
+func (in *NovaCellSection) DeepCopy() *NovaCellSection {
+	if in == nil {
+		return nil
+	}
+	out := new(NovaCellSection)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NovaSection) DeepCopyInto(out *NovaSection) {
 	*out = *in
@@ -1157,6 +1335,13 @@ func (in *NovaSection) DeepCopyInto(out *NovaSection) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.Cells != nil {
+		in, out := &in.Cells, &out.Cells
+		*out = make(map[string]NovaCellSection, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 	if in.ApplicationCredential != nil {
 		in, out := &in.ApplicationCredential, &out.ApplicationCredential
 		*out = new(ServiceAppCredSection)
@@ -1177,6 +1362,11 @@ func (in *NovaSection) DeepCopy() *NovaSection {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OctaviaSection) DeepCopyInto(out *OctaviaSection) {
 	*out = *in
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(AutoscalingSection)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Template != nil {
 		in, out := &in.Template, &out.Template
 		*out = new(octavia_operatorapiv1beta1.OctaviaSpecCore)
@@ -1275,6 +1465,155 @@ func (in *OpenStackControlPlaneList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenStackControlPlanePlan) DeepCopyInto(out *OpenStackControlPlanePlan) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenStackControlPlanePlan.
+func (in *OpenStackControlPlanePlan) DeepCopy() *OpenStackControlPlanePlan {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenStackControlPlanePlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpenStackControlPlanePlan) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenStackControlPlanePlanChange) DeepCopyInto(out *OpenStackControlPlanePlanChange) {
+	*out = *in
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenStackControlPlanePlanChange.
+func (in *OpenStackControlPlanePlanChange) DeepCopy() *OpenStackControlPlanePlanChange {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenStackControlPlanePlanChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenStackControlPlanePlanList) DeepCopyInto(out *OpenStackControlPlanePlanList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OpenStackControlPlanePlan, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenStackControlPlanePlanList.
+func (in *OpenStackControlPlanePlanList) DeepCopy() *OpenStackControlPlanePlanList {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenStackControlPlanePlanList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpenStackControlPlanePlanList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenStackControlPlanePlanSpec) DeepCopyInto(out *OpenStackControlPlanePlanSpec) {
+	*out = *in
+	if in.SpecPatch != nil {
+		in, out := &in.SpecPatch, &out.SpecPatch
+		*out = make(map[string]json.RawMessage, len(*in))
+		for key, val := range *in {
+			var outVal []byte
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make(json.RawMessage, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenStackControlPlanePlanSpec.
+func (in *OpenStackControlPlanePlanSpec) DeepCopy() *OpenStackControlPlanePlanSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenStackControlPlanePlanSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenStackControlPlanePlanStatus) DeepCopyInto(out *OpenStackControlPlanePlanStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(condition.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = make([]OpenStackControlPlanePlanChange, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.IncompleteSteps != nil {
+		in, out := &in.IncompleteSteps, &out.IncompleteSteps
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenStackControlPlanePlanStatus.
+func (in *OpenStackControlPlanePlanStatus) DeepCopy() *OpenStackControlPlanePlanStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenStackControlPlanePlanStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpenStackControlPlaneSpec) DeepCopyInto(out *OpenStackControlPlaneSpec) {
 	*out = *in
@@ -1308,6 +1647,11 @@ func (in *OpenStackControlPlaneSpec) DeepCopyInto(out *OpenStackControlPlaneSpec
 		*out = new(rabbitmqv1beta1.RabbitMqConfig)
 		**out = **in
 	}
+	if in.MessagingTopology != nil {
+		in, out := &in.MessagingTopology, &out.MessagingTopology
+		*out = new(MessagingTopologySection)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Memcached.DeepCopyInto(&out.Memcached)
 	in.Ovn.DeepCopyInto(&out.Ovn)
 	in.Neutron.DeepCopyInto(&out.Neutron)
@@ -1335,6 +1679,16 @@ func (in *OpenStackControlPlaneSpec) DeepCopyInto(out *OpenStackControlPlaneSpec
 		*out = new(topologyv1beta1.TopoRef)
 		**out = **in
 	}
+	if in.Scheduling != nil {
+		in, out := &in.Scheduling, &out.Scheduling
+		*out = new(SchedulingSection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sizing != nil {
+		in, out := &in.Sizing, &out.Sizing
+		*out = new(SizingSection)
+		**out = **in
+	}
 	in.Watcher.DeepCopyInto(&out.Watcher)
 	in.ApplicationCredential.DeepCopyInto(&out.ApplicationCredential)
 }
@@ -1371,6 +1725,27 @@ func (in *OpenStackControlPlaneStatus) DeepCopyInto(out *OpenStackControlPlaneSt
 		**out = **in
 	}
 	in.ContainerImages.DeepCopyInto(&out.ContainerImages)
+	if in.Scheduling != nil {
+		in, out := &in.Scheduling, &out.Scheduling
+		*out = make(map[string]ServiceSchedulingStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Sizing != nil {
+		in, out := &in.Sizing, &out.Sizing
+		*out = make(map[string]ServiceSizingStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = make(map[string]AutoscalingStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenStackControlPlaneStatus.
@@ -1640,6 +2015,11 @@ func (in *OvnSection) DeepCopy() *OvnSection {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PlacementSection) DeepCopyInto(out *PlacementSection) {
 	*out = *in
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(AutoscalingSection)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Template != nil {
 		in, out := &in.Template, &out.Template
 		*out = new(placementv1beta1.PlacementAPISpecCore)
@@ -1715,6 +2095,44 @@ func (in *RedisSection) DeepCopy() *RedisSection {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchedulingSection) DeepCopyInto(out *SchedulingSection) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(map[string]string)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make(map[string]string, len(*in))
+			for key, val := range *in {
+				(*out)[key] = val
+			}
+		}
+	}
+	if in.TopologyRef != nil {
+		in, out := &in.TopologyRef, &out.TopologyRef
+		*out = new(topologyv1beta1.TopoRef)
+		**out = **in
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulingSection.
+func (in *SchedulingSection) DeepCopy() *SchedulingSection {
+	if in == nil {
+		return nil
+	}
+	out := new(SchedulingSection)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceAppCredSection) DeepCopyInto(out *ServiceAppCredSection) {
 	*out = *in
@@ -1790,9 +2208,98 @@ func (in *ServiceDefaults) DeepCopy() *ServiceDefaults {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceSchedulingStatus) DeepCopyInto(out *ServiceSchedulingStatus) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.TopologyRef != nil {
+		in, out := &in.TopologyRef, &out.TopologyRef
+		*out = new(topologyv1beta1.TopoRef)
+		**out = **in
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.IgnoredFields != nil {
+		in, out := &in.IgnoredFields, &out.IgnoredFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceSchedulingStatus.
+func (in *ServiceSchedulingStatus) DeepCopy() *ServiceSchedulingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceSchedulingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceSizingStatus) DeepCopyInto(out *ServiceSizingStatus) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Workers != nil {
+		in, out := &in.Workers, &out.Workers
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ReplacedFields != nil {
+		in, out := &in.ReplacedFields, &out.ReplacedFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceSizingStatus.
+func (in *ServiceSizingStatus) DeepCopy() *ServiceSizingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceSizingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SizingSection) DeepCopyInto(out *SizingSection) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SizingSection.
+func (in *SizingSection) DeepCopy() *SizingSection {
+	if in == nil {
+		return nil
+	}
+	out := new(SizingSection)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SwiftSection) DeepCopyInto(out *SwiftSection) {
 	*out = *in
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(AutoscalingSection)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Template != nil {
 		in, out := &in.Template, &out.Template
 		*out = new(swift_operatorapiv1beta1.SwiftSpecCore)
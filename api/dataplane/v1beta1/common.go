@@ -134,6 +134,27 @@ type NodeSection struct {
 	// CtlplaneInterface - Interface on the provisioned nodes to use for ctlplane network
 	// +kubebuilder:validation:Optional
 	CtlplaneInterface string `json:"ctlplaneInterface,omitempty"`
+
+	// Maintenance - Cordons the node when set. A cordoned node is excluded from
+	// the inventory used by OpenStackDataPlaneDeployments and therefore not
+	// counted in their execution summaries.
+	// +kubebuilder:validation:Optional
+	Maintenance *MaintenanceSpec `json:"maintenance,omitempty"`
+}
+
+// MaintenanceSpec defines the maintenance options of a node
+type MaintenanceSpec struct {
+	// DisableComputeService - Whether to disable the nova-compute service of
+	// the node while it is in maintenance. The service is enabled again once
+	// the node leaves maintenance.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	DisableComputeService bool `json:"disableComputeService,omitempty"`
+
+	// Reason - Why the node is in maintenance, used as the disable reason of
+	// the nova-compute service
+	// +kubebuilder:validation:Optional
+	Reason string `json:"reason,omitempty"`
 }
 
 // NodeTemplate is a specification of the node attributes that override top level attributes.
@@ -188,6 +209,8 @@ type AnsibleEESpec struct {
 	NetworkAttachments []string `json:"networkAttachments"`
 	// OpenStackAnsibleEERunnerImage image to use as the ansibleEE runner image
 	OpenStackAnsibleEERunnerImage string `json:"openStackAnsibleEERunnerImage,omitempty"`
+	// PlaybookSourceImage oras image pulling the OCI playbook sources
+	PlaybookSourceImage string `json:"playbookSourceImage,omitempty"`
 	// AnsibleTags for ansible execution
 	AnsibleTags string `json:"ansibleTags,omitempty"`
 	// AnsibleLimit for ansible execution
@@ -201,4 +224,64 @@ type AnsibleEESpec struct {
 	// AnsibleEEEnvConfigMapName is the name of ConfigMap containing environment
 	// variables to inject to the Ansible execution environment pod.
 	AnsibleEEEnvConfigMapName string `json:"ansibleEEEnvConfigMapName,omitempty"`
+	// JobTemplate sets the resources, scheduling and timeout of the Ansible execution jobs
+	JobTemplate *AnsibleEEJobTemplate `json:"jobTemplate,omitempty"`
+}
+
+// AnsibleEEJobTemplate defines the resources, scheduling and timeout of the
+// AnsibleEE jobs
+type AnsibleEEJobTemplate struct {
+	// Resources - compute resources of the ansible-runner container
+	// +kubebuilder:validation:Optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Tolerations - tolerations of the AnsibleEE pods
+	// +kubebuilder:validation:Optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Affinity - scheduling constraints of the AnsibleEE pods
+	// +kubebuilder:validation:Optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// PriorityClassName - priority class of the AnsibleEE pods
+	// +kubebuilder:validation:Optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// ActiveDeadlineSeconds - duration in seconds an AnsibleEE job may run
+	// before it is terminated, e.g. when an SSH session hangs
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum:=1
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty"`
+}
+
+// Merge returns a copy of the template with the fields set in override
+// replacing its own
+func (t *AnsibleEEJobTemplate) Merge(override *AnsibleEEJobTemplate) *AnsibleEEJobTemplate {
+	if t == nil {
+		return override.DeepCopy()
+	}
+	merged := t.DeepCopy()
+	if override == nil {
+		return merged
+	}
+	if override.Resources != nil {
+		merged.Resources = override.Resources.DeepCopy()
+	}
+	if override.Tolerations != nil {
+		merged.Tolerations = make([]corev1.Toleration, len(override.Tolerations))
+		for i := range override.Tolerations {
+			override.Tolerations[i].DeepCopyInto(&merged.Tolerations[i])
+		}
+	}
+	if override.Affinity != nil {
+		merged.Affinity = override.Affinity.DeepCopy()
+	}
+	if override.PriorityClassName != "" {
+		merged.PriorityClassName = override.PriorityClassName
+	}
+	if override.ActiveDeadlineSeconds != nil {
+		activeDeadlineSeconds := *override.ActiveDeadlineSeconds
+		merged.ActiveDeadlineSeconds = &activeDeadlineSeconds
+	}
+	return merged
 }
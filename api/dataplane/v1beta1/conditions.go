@@ -85,6 +85,33 @@ const (
 	// NodeSetDNSDataMultipleDNSMasqErrorMessage error
 	NodeSetDNSDataMultipleDNSMasqErrorMessage = "NodeSet DNSData error occurred. Multiple DNSMasq resources exist"
 
+	// NodeSetDecommissionReadyCondition Status=True condition indicates
+	// all nodes pending decommission for the NodeSet have been cleaned up.
+	NodeSetDecommissionReadyCondition condition.Type = "NodeSetDecommissionReady"
+
+	// NodeSetDecommissionReadyMessage ready
+	NodeSetDecommissionReadyMessage = "NodeSetDecommissionReady ready"
+
+	// NodeSetDecommissionReadyWaitingMessage not yet ready
+	NodeSetDecommissionReadyWaitingMessage = "NodeSetDecommissionReady not yet ready, decommissioning %s"
+
+	// NodeSetDecommissionErrorMessage error
+	NodeSetDecommissionErrorMessage = "NodeSetDecommissionReady error occurred %s"
+
+	// NodeSetMaintenanceReadyCondition Status=True condition indicates the
+	// nova-compute services of the nodes entering or leaving maintenance
+	// have been disabled or enabled.
+	NodeSetMaintenanceReadyCondition condition.Type = "NodeSetMaintenanceReady"
+
+	// NodeSetMaintenanceReadyMessage ready
+	NodeSetMaintenanceReadyMessage = "NodeSetMaintenanceReady ready"
+
+	// NodeSetMaintenanceReadyWaitingMessage not yet ready
+	NodeSetMaintenanceReadyWaitingMessage = "NodeSetMaintenanceReady not yet ready, waiting for %s"
+
+	// NodeSetMaintenanceErrorMessage error
+	NodeSetMaintenanceErrorMessage = "NodeSetMaintenanceReady error occurred %s"
+
 	// InputReadyWaitingMessage not yet ready
 	InputReadyWaitingMessage = "Waiting for input %s, not yet ready"
 
@@ -103,4 +130,17 @@ const (
 
 	// NodeSetServiceDeploymentErrorMessage error
 	NodeSetServiceDeploymentErrorMessage = "Deployment error occurred in %s service"
+
+	// NodeSetDrainReadyCondition Status=True condition indicates all the
+	// batches of hosts of the NodeSet have been drained and updated.
+	NodeSetDrainReadyCondition condition.Type = "NodeSetDrainReady"
+
+	// NodeSetDrainReadyMessage ready
+	NodeSetDrainReadyMessage = "NodeSetDrainReady ready"
+
+	// NodeSetDrainReadyWaitingMessage not yet ready
+	NodeSetDrainReadyWaitingMessage = "NodeSetDrainReady not yet ready, %s hosts %s of batch %d/%d"
+
+	// NodeSetDrainErrorMessage error
+	NodeSetDrainErrorMessage = "NodeSetDrainReady error occurred %s"
 )
@@ -23,4 +23,17 @@ const (
 	// ConfirmDeleteAnnotation is the annotation key required to allow
 	// deletion of an OpenStackDataPlaneDeployment. The value must be "true".
 	ConfirmDeleteAnnotation = "dataplane.openstack.org/confirm-delete"
+
+	// DecommissionNodesAnnotation is the annotation key holding a comma
+	// separated list of node hostnames of an OpenStackDataPlaneNodeSet to
+	// decommission while they are still part of the NodeSet.
+	DecommissionNodesAnnotation = "dataplane.openstack.org/decommission-nodes"
+
+	// InventoryPreviewAnnotation is the annotation key requesting a preview
+	// of the inventory of an OpenStackDataPlaneNodeSet. The value is a
+	// strategic merge patch of the NodeSet spec in JSON or YAML, empty to
+	// preview the current spec. The inventory is rendered with the values
+	// coming from Secrets redacted into the dataplanenodeset-<name>-preview
+	// ConfigMap.
+	InventoryPreviewAnnotation = "dataplane.openstack.org/inventory-preview"
 )
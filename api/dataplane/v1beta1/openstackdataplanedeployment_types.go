@@ -78,6 +78,112 @@ type OpenStackDataPlaneDeploymentSpec struct {
 	// variables to inject into the Ansible Execution Environment pod.
 	// If not specified, defaults to "openstack-aee-default-env".
 	AnsibleEEEnvConfigMapName string `json:"ansibleEEEnvConfigMapName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// DrainBeforeUpdate - when set, the hosts of each NodeSet are deployed in
+	// batches. The nova-compute service of the hosts of a batch is disabled and
+	// their instances live-migrated before the services run, and the
+	// nova-compute service is enabled again before moving to the next batch.
+	DrainBeforeUpdate *DrainBeforeUpdateSpec `json:"drainBeforeUpdate,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ArtifactSink - when set, the job_events and stdout artifacts of
+	// ansible-runner are persisted to a PersistentVolumeClaim or uploaded to an
+	// S3-compatible endpoint by each service job, so they outlive the job pod.
+	ArtifactSink *ArtifactSinkSpec `json:"artifactSink,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// AnsibleEEJobTemplate - resources, scheduling and timeout of the AnsibleEE
+	// jobs. The fields set here override the ones of the NodeSets, and are
+	// overridden by the ones of the OpenStackDataPlaneService.
+	AnsibleEEJobTemplate *AnsibleEEJobTemplate `json:"ansibleEEJobTemplate,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=PerService;SingleJob
+	// ExecutionMode - PerService, the default, runs each service of a NodeSet
+	// in its own AnsibleEE job. SingleJob composes the playbooks and roles of
+	// the services of a NodeSet into a single generated playbook run by one
+	// AnsibleEE job. Services deployed on all the NodeSets still run in their
+	// own job.
+	ExecutionMode ExecutionMode `json:"executionMode,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ReportProgress - when set, the AnsibleEE jobs running with the service
+	// account of their NodeSet report the progress of the execution and the
+	// notable ansible events to the progress ConfigMap of the NodeSet. The jobs
+	// then run ansible-runner through a reporter script.
+	ReportProgress bool `json:"reportProgress,omitempty"`
+}
+
+// ExecutionMode - how the services of a NodeSet are split into AnsibleEE jobs
+type ExecutionMode string
+
+const (
+	// ExecutionModePerService - run each service in its own job
+	ExecutionModePerService ExecutionMode = "PerService"
+	// ExecutionModeSingleJob - run the services of a NodeSet in a single job
+	ExecutionModeSingleJob ExecutionMode = "SingleJob"
+)
+
+// DrainBeforeUpdateSpec defines how the hosts are drained before being updated
+type DrainBeforeUpdateSpec struct {
+	// BatchSize - number of hosts drained and updated at a time
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:default:=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	BatchSize int `json:"batchSize,omitempty"`
+}
+
+// ArtifactSinkSpec defines where the ansible-runner artifacts are persisted.
+// Exactly one of PVC and S3 must be set.
+type ArtifactSinkSpec struct {
+	// PVC - copy the artifacts to a PersistentVolumeClaim
+	// +kubebuilder:validation:Optional
+	PVC *PVCArtifactSinkSpec `json:"pvc,omitempty"`
+
+	// S3 - upload the artifacts to an S3-compatible endpoint
+	// +kubebuilder:validation:Optional
+	S3 *S3ArtifactSinkSpec `json:"s3,omitempty"`
+
+	// Prefix - path the artifacts are stored under, in the volume or the bucket
+	// +kubebuilder:validation:Optional
+	Prefix string `json:"prefix,omitempty"`
+}
+
+// PVCArtifactSinkSpec defines the PersistentVolumeClaim the artifacts are copied to
+type PVCArtifactSinkSpec struct {
+	// ClaimName - name of the PersistentVolumeClaim in the namespace of the
+	// deployment. The claim must be ReadWriteMany when the jobs of several
+	// NodeSets run at the same time.
+	// +kubebuilder:validation:Required
+	ClaimName string `json:"claimName"`
+}
+
+// S3ArtifactSinkSpec defines the S3-compatible endpoint the artifacts are uploaded to
+type S3ArtifactSinkSpec struct {
+	// Endpoint - URL of the S3-compatible endpoint, e.g. https://s3.example.com
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern:=`^https?://`
+	Endpoint string `json:"endpoint"`
+
+	// Bucket - name of the bucket the artifacts are uploaded to
+	// +kubebuilder:validation:Required
+	Bucket string `json:"bucket"`
+
+	// Region - region used to sign the requests
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="us-east-1"
+	Region string `json:"region,omitempty"`
+
+	// CredentialsSecretName - name of the Secret holding the AWS_ACCESS_KEY_ID
+	// and AWS_SECRET_ACCESS_KEY of the endpoint
+	// +kubebuilder:validation:Required
+	CredentialsSecretName string `json:"credentialsSecretName"`
+
+	// InsecureSkipVerify - do not verify the TLS certificate of the endpoint
+	// +kubebuilder:validation:Optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
 }
 
 // AnsibleExecutionSummary captures the final ansible-runner execution result
@@ -95,6 +201,43 @@ type AnsibleExecutionSummary struct {
 	FailedHostList *[]string `json:"failedHostList,omitempty" optional:"true"`
 	// UnreachableHostList contains the hosts that were unreachable.
 	UnreachableHostList *[]string `json:"unreachableHostList,omitempty" optional:"true"`
+	// OkTasks is the number of tasks that succeeded without changes, across all hosts.
+	OkTasks *int `json:"okTasks,omitempty" optional:"true"`
+	// ChangedTasks is the number of tasks that changed a host, across all hosts.
+	ChangedTasks *int `json:"changedTasks,omitempty" optional:"true"`
+	// SkippedTasks is the number of tasks that were skipped, across all hosts.
+	SkippedTasks *int `json:"skippedTasks,omitempty" optional:"true"`
+	// TaskFailures contains the task that failed on each failed host, for up to 20 hosts.
+	TaskFailures *[]AnsibleTaskFailure `json:"taskFailures,omitempty" optional:"true"`
+}
+
+// AnsibleExecutionProgress reports the progress of a running AEE job.
+type AnsibleExecutionProgress struct {
+	// Play is the name of the play being executed.
+	Play string `json:"play,omitempty" optional:"true"`
+	// Task is the name of the task being executed.
+	Task string `json:"task,omitempty" optional:"true"`
+	// CompletedHosts is the number of hosts that completed the current task.
+	CompletedHosts *int `json:"completedHosts,omitempty" optional:"true"`
+	// TotalHosts is the number of hosts the execution ran tasks on so far.
+	TotalHosts *int `json:"totalHosts,omitempty" optional:"true"`
+	// ElapsedTime is the time elapsed since the job started.
+	ElapsedTime string `json:"elapsedTime,omitempty" optional:"true"`
+	// LastEventSequence is the sequence number of the last ansible event of the
+	// job recorded as a Kubernetes Event.
+	LastEventSequence *int `json:"lastEventSequence,omitempty" optional:"true"`
+}
+
+// AnsibleTaskFailure describes the Ansible task that failed on a host.
+type AnsibleTaskFailure struct {
+	// Host is the name of the host the task failed on.
+	Host string `json:"host"`
+	// Task is the name of the failed task.
+	Task string `json:"task,omitempty" optional:"true"`
+	// Role is the role the failed task belongs to.
+	Role string `json:"role,omitempty" optional:"true"`
+	// Message is the error message of the failed task, truncated.
+	Message string `json:"message,omitempty" optional:"true"`
 }
 
 // OpenStackDataPlaneDeploymentStatus defines the observed state of OpenStackDataPlaneDeployment
@@ -108,6 +251,9 @@ type OpenStackDataPlaneDeploymentStatus struct {
 	// AnsibleExecutionSummaries stores the most recent AEE execution summary per Job name.
 	AnsibleExecutionSummaries map[string]AnsibleExecutionSummary `json:"ansibleExecutionSummaries,omitempty" optional:"true"`
 
+	// AnsibleExecutionProgress stores the progress of the running AEE jobs per Job name.
+	AnsibleExecutionProgress map[string]AnsibleExecutionProgress `json:"ansibleExecutionProgress,omitempty" optional:"true"`
+
 	// ConfigMapHashes
 	ConfigMapHashes map[string]string `json:"configMapHashes,omitempty" optional:"true"`
 
@@ -123,6 +269,16 @@ type OpenStackDataPlaneDeploymentStatus struct {
 	// ContainerImages
 	ContainerImages map[string]string `json:"containerImages,omitempty"`
 
+	// UpdatedBatches - number of host batches drained and updated per NodeSet
+	UpdatedBatches map[string]int `json:"updatedBatches,omitempty" optional:"true"`
+
+	// ArtifactLocations - location of the ansible-runner artifacts per NodeSet and service
+	ArtifactLocations map[string]map[string]string `json:"artifactLocations,omitempty" optional:"true"`
+
+	// PlaybookSourceRevisions - Git commit or OCI digest of the playbook
+	// sources fetched per NodeSet and service
+	PlaybookSourceRevisions map[string]map[string]string `json:"playbookSourceRevisions,omitempty" optional:"true"`
+
 	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors={"urn:alm:descriptor:io.kubernetes.conditions"}
 	// Conditions
 	Conditions condition.Conditions `json:"conditions,omitempty" optional:"true"`
@@ -220,4 +376,10 @@ func (instance *OpenStackDataPlaneDeployment) InitHashesAndImages() {
 	if instance.Status.BmhRefHashes == nil {
 		instance.Status.BmhRefHashes = make(map[string]string)
 	}
+	if instance.Spec.DrainBeforeUpdate != nil && instance.Status.UpdatedBatches == nil {
+		instance.Status.UpdatedBatches = make(map[string]int)
+	}
+	if instance.Spec.ArtifactSink != nil && instance.Status.ArtifactLocations == nil {
+		instance.Status.ArtifactLocations = make(map[string]map[string]string)
+	}
 }
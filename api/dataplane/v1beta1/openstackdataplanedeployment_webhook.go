@@ -65,9 +65,24 @@ func (r *OpenStackDataPlaneDeployment) ValidateCreate() (admission.Warnings, err
 
 // ValidateCreate validates the OpenStackDataPlaneDeploymentSpec on creation
 func (spec *OpenStackDataPlaneDeploymentSpec) ValidateCreate() field.ErrorList {
-	// TODO(user): fill in your validation logic upon object creation.
+	errors := field.ErrorList{}
+
+	// The hosts of a batch are passed as the ansible limit
+	if spec.DrainBeforeUpdate != nil && spec.AnsibleLimit != "" {
+		errors = append(errors, field.Invalid(
+			field.NewPath("spec", "ansibleLimit"),
+			spec.AnsibleLimit,
+			"ansibleLimit cannot be used with drainBeforeUpdate"))
+	}
 
-	return field.ErrorList{}
+	if spec.ArtifactSink != nil && (spec.ArtifactSink.PVC == nil) == (spec.ArtifactSink.S3 == nil) {
+		errors = append(errors, field.Invalid(
+			field.NewPath("spec", "artifactSink"),
+			"object",
+			"artifactSink requires exactly one of pvc or s3"))
+	}
+
+	return errors
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
@@ -17,7 +17,12 @@ limitations under the License.
 package v1beta1
 
 import (
+	"fmt"
+	"maps"
 	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 )
 
 // NodeHostNameIsFQDN Helper to check if a hostname is fqdn
@@ -29,3 +34,97 @@ func NodeHostNameIsFQDN(hostname string) bool {
 	match, _ := regexp.MatchString(regex, hostname)
 	return match
 }
+
+// GetNodeIndex returns the index of the node generated by the pool with the
+// given name, or -1 if the name does not match the HostNamePattern
+func (pool *NodePoolSpec) GetNodeIndex(nodeName string) int {
+	prefix, suffix, found := strings.Cut(pool.HostNamePattern, "%d")
+	if !found || len(nodeName) <= len(prefix)+len(suffix) ||
+		!strings.HasPrefix(nodeName, prefix) || !strings.HasSuffix(nodeName, suffix) {
+		return -1
+	}
+	idx, err := strconv.Atoi(nodeName[len(prefix) : len(nodeName)-len(suffix)])
+	// Reject leading zeros or signs, they would not be generated
+	if err != nil || idx < 0 || pool.GetNodeName(idx) != nodeName {
+		return -1
+	}
+	return idx
+}
+
+// GetNodeName returns the name of the node generated by the pool at index
+func (pool *NodePoolSpec) GetNodeName(idx int) string {
+	return strings.Replace(pool.HostNamePattern, "%d", fmt.Sprint(idx), 1)
+}
+
+// GetNodePoolNodes returns the names of the nodes generated by the pool.
+// The nodes generated by the previous reconcile, recorded in
+// Status.NodePoolNodes, are kept and nodes are added or removed so that there
+// are NodePool.Replicas of them. New nodes get the index following the highest
+// generated one.
+func (instance *OpenStackDataPlaneNodeSet) GetNodePoolNodes() []string {
+	pool := instance.Spec.NodePool
+	if pool == nil || instance.Spec.PreProvisioned {
+		return nil
+	}
+
+	indexes := []int{}
+	for _, nodeName := range instance.Status.NodePoolNodes {
+		if idx := pool.GetNodeIndex(nodeName); idx >= 0 && !slices.Contains(indexes, idx) {
+			indexes = append(indexes, idx)
+		}
+	}
+	slices.Sort(indexes)
+
+	for len(indexes) > pool.Replicas {
+		if pool.ScaleInPolicy == ScaleInPolicyLowestIndex {
+			indexes = indexes[1:]
+		} else {
+			indexes = indexes[:len(indexes)-1]
+		}
+	}
+
+	next := 0
+	if len(indexes) > 0 {
+		next = indexes[len(indexes)-1] + 1
+	}
+	for len(indexes) < pool.Replicas {
+		indexes = append(indexes, next)
+		next++
+	}
+
+	nodeNames := make([]string, 0, len(indexes))
+	for _, idx := range indexes {
+		nodeNames = append(nodeNames, pool.GetNodeName(idx))
+	}
+	return nodeNames
+}
+
+// AddNodePoolNodes adds the nodes generated by the pool to the nodes of the
+// spec, in memory only. The nodes listed in the spec take precedence over the
+// generated ones with the same name.
+func (spec *OpenStackDataPlaneNodeSetSpec) AddNodePoolNodes(nodeNames []string) {
+	if spec.NodePool == nil || len(nodeNames) == 0 {
+		return
+	}
+	if spec.Nodes == nil {
+		spec.Nodes = map[string]NodeSection{}
+	}
+
+	var domain string
+	if spec.BaremetalSetTemplate != nil {
+		domain = spec.BaremetalSetTemplate.DomainName
+	}
+	for _, nodeName := range nodeNames {
+		if _, exists := spec.Nodes[nodeName]; exists {
+			continue
+		}
+		hostName := nodeName
+		if domain != "" {
+			hostName = strings.Join([]string{nodeName, domain}, ".")
+		}
+		spec.Nodes[nodeName] = NodeSection{
+			HostName:         hostName,
+			BmhLabelSelector: maps.Clone(spec.NodePool.BmhLabelSelector),
+		}
+	}
+}
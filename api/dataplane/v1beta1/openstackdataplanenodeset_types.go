@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"slices"
+	"strings"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -86,8 +87,101 @@ type OpenStackDataPlaneNodeSetSpec struct {
 	// +kubebuilder:default=true
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
 	TLSEnabled bool `json:"tlsEnabled" yaml:"tlsEnabled"`
+
+	// Decommission - Enables the decommission workflow for nodes removed from
+	// the NodeSet or listed in the decommission-nodes annotation. When not set,
+	// removed nodes have their IPs and BareMetalHosts released immediately.
+	// +kubebuilder:validation:Optional
+	Decommission *DecommissionSpec `json:"decommission,omitempty"`
+
+	// NodePool - Generates the nodes of a bare-metal NodeSet from a count
+	// instead of listing them individually. The generated nodes are not
+	// stored in the nodes section, they are listed in status.nodePoolNodes.
+	// +kubebuilder:validation:Optional
+	NodePool *NodePoolSpec `json:"nodePool,omitempty"`
+
+	// AnsibleEEJobTemplate - resources, scheduling and timeout of the
+	// AnsibleEE jobs of the NodeSet. The fields set on the
+	// OpenStackDataPlaneDeployment and on the OpenStackDataPlaneService
+	// override the ones set here.
+	// +kubebuilder:validation:Optional
+	AnsibleEEJobTemplate *AnsibleEEJobTemplate `json:"ansibleEEJobTemplate,omitempty"`
+}
+
+// ScaleInPolicy - which generated nodes are removed first on scale-in
+type ScaleInPolicy string
+
+const (
+	// ScaleInPolicyHighestIndex - remove the nodes with the highest index first
+	ScaleInPolicyHighestIndex ScaleInPolicy = "HighestIndex"
+	// ScaleInPolicyLowestIndex - remove the nodes with the lowest index first
+	ScaleInPolicyLowestIndex ScaleInPolicy = "LowestIndex"
+)
+
+// NodePoolSpec defines the nodes generated from a pool of BareMetalHosts
+type NodePoolSpec struct {
+	// Replicas - Number of nodes generated from the pool
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=0
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	Replicas int `json:"replicas"`
+
+	// HostNamePattern - Name of the generated nodes, where %d is replaced by
+	// the index of the node, e.g. edpm-compute-%d
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[a-z0-9-]*%d[a-z0-9-]*$`
+	HostNamePattern string `json:"hostNamePattern"`
+
+	// BmhLabelSelector - Labels selecting the BareMetalHosts of the pool for
+	// the generated nodes
+	// +kubebuilder:validation:Optional
+	BmhLabelSelector map[string]string `json:"bmhLabelSelector,omitempty"`
+
+	// ScaleInPolicy - Which generated nodes are removed first when the
+	// replicas are decreased
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=HighestIndex;LowestIndex
+	// +kubebuilder:default=HighestIndex
+	ScaleInPolicy ScaleInPolicy `json:"scaleInPolicy,omitempty"`
+}
+
+// DecommissionSpec defines how nodes are cleaned up before they are released
+type DecommissionSpec struct {
+	// Services - OpenStackDataPlaneServices to run against a node being
+	// decommissioned, in order.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default={cleanup}
+	Services []string `json:"services,omitempty"`
+
+	// ControlPlaneCleanup - Whether to remove the nova-compute service, the
+	// placement resource provider and the OVN chassis of the node from the
+	// control plane.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=true
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	ControlPlaneCleanup bool `json:"controlPlaneCleanup"`
+
+	// LiveMigrate - Whether to disable the nova-compute service and
+	// live-migrate the instances off the node before cleaning it up.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	LiveMigrate bool `json:"liveMigrate,omitempty"`
 }
 
+// DecommissionPhase - the step a node being decommissioned is at
+type DecommissionPhase string
+
+const (
+	// DecommissionPhaseDraining - instances are being live-migrated off the node
+	DecommissionPhaseDraining DecommissionPhase = "Draining"
+	// DecommissionPhaseCleaningUp - decommission services are running against the node
+	DecommissionPhaseCleaningUp DecommissionPhase = "CleaningUp"
+	// DecommissionPhaseRemovingFromControlPlane - the node is being removed from the control plane
+	DecommissionPhaseRemovingFromControlPlane DecommissionPhase = "RemovingFromControlPlane"
+	// DecommissionPhaseDecommissioned - the node is decommissioned and can be released
+	DecommissionPhaseDecommissioned DecommissionPhase = "Decommissioned"
+)
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +operator-sdk:csv:customresourcedefinitions:displayName="OpenStack Data Plane NodeSet"
@@ -167,6 +261,15 @@ type OpenStackDataPlaneNodeSetStatus struct {
 
 	//DeployedBmhHash - Hash of BMHs deployed
 	DeployedBmhHash string `json:"deployedBmhHash,omitempty"`
+
+	// DecommissionStatuses - decommission phase per node hostname
+	DecommissionStatuses map[string]DecommissionPhase `json:"decommissionStatuses,omitempty" optional:"true"`
+
+	// CordonedNodes - hostnames of the nodes in maintenance
+	CordonedNodes []string `json:"cordonedNodes,omitempty" optional:"true"`
+
+	// NodePoolNodes - names of the nodes generated from the nodePool
+	NodePoolNodes []string `json:"nodePoolNodes,omitempty" optional:"true"`
 }
 
 // +kubebuilder:object:root=true
@@ -207,6 +310,19 @@ func (instance *OpenStackDataPlaneNodeSet) InitConditions() {
 		cl = append(cl, *condition.UnknownCondition(NodeSetBareMetalProvisionReadyCondition, condition.InitReason, condition.InitReason))
 	}
 
+	// Only set Decommission related conditions if enabled
+	if instance.Spec.Decommission != nil {
+		cl = append(cl, *condition.UnknownCondition(NodeSetDecommissionReadyCondition, condition.InitReason, condition.InitReason))
+	}
+
+	// Only set Maintenance related conditions if a node is in maintenance
+	for _, node := range instance.Spec.Nodes {
+		if node.Maintenance != nil {
+			cl = append(cl, *condition.UnknownCondition(NodeSetMaintenanceReadyCondition, condition.InitReason, condition.InitReason))
+			break
+		}
+	}
+
 	instance.Status.Conditions.Init(&cl)
 }
 
@@ -217,7 +333,20 @@ func (instance OpenStackDataPlaneNodeSet) GetAnsibleEESpec() AnsibleEESpec {
 		ExtraMounts:        instance.Spec.NodeTemplate.ExtraMounts,
 		Env:                instance.Spec.Env,
 		ServiceAccountName: instance.Name,
+		JobTemplate:        instance.Spec.AnsibleEEJobTemplate,
+	}
+}
+
+// GetDecommissionNodes - get the hostnames listed in the decommission-nodes annotation
+func (instance OpenStackDataPlaneNodeSet) GetDecommissionNodes() []string {
+	hostNames := []string{}
+	for _, hostName := range strings.Split(instance.Annotations[DecommissionNodesAnnotation], ",") {
+		hostName = strings.TrimSpace(hostName)
+		if hostName != "" && !slices.Contains(hostNames, hostName) {
+			hostNames = append(hostNames, hostName)
+		}
 	}
+	return hostNames
 }
 
 // ContainerImageDefaults - the hardcoded defaults which are the last fallback
@@ -246,6 +375,7 @@ var ContainerImageDefaults = openstackv1.ContainerImages{
 		EdpmKeplerImage:               getStrPtr("quay.io/sustainable_computing_io/kepler:release-0.7.12"),
 		EdpmPodmanExporterImage:       getStrPtr("quay.io/openstack-k8s-operators/prometheus-podman-exporter:latest"),
 		OpenstackNetworkExporterImage: getStrPtr("quay.io/openstack-k8s-operators/openstack-network-exporter:current-podified"),
+		OrasImage:                     getStrPtr("ghcr.io/oras-project/oras:v1.2.3"),
 		OsContainerImage:              getStrPtr("quay.io/podified-antelope-centos9/edpm-hardened-uefi:current-podified"),
 		SwiftAccountImage:             getStrPtr("quay.io/podified-antelope-centos9/openstack-swift-account:current-podified"),
 		SwiftContainerImage:           getStrPtr("quay.io/podified-antelope-centos9/openstack-swift-container:current-podified"),
@@ -284,6 +414,7 @@ func SetupDefaults() {
 			CeilometerIpmiImage:           getImageDefault("RELATED_IMAGE_CEILOMETER_IPMI_IMAGE_URL_DEFAULT", ContainerImageDefaults.CeilometerIpmiImage),
 			NovaComputeImage:              getImageDefault("RELATED_IMAGE_NOVA_COMPUTE_IMAGE_URL_DEFAULT", ContainerImageDefaults.NovaComputeImage),
 			OvnControllerImage:            getImageDefault("RELATED_IMAGE_OVN_CONTROLLER_AGENT_IMAGE_URL_DEFAULT", ContainerImageDefaults.OvnControllerImage),
+			OrasImage:                     getImageDefault("RELATED_IMAGE_ORAS_IMAGE_URL_DEFAULT", ContainerImageDefaults.OrasImage),
 			OsContainerImage:              getImageDefault("RELATED_IMAGE_OS_CONTAINER_IMAGE_URL_DEFAULT", ContainerImageDefaults.OsContainerImage),
 			SwiftAccountImage:             getImageDefault("RELATED_IMAGE_SWIFT_ACCOUNT_IMAGE_URL_DEFAULT", ContainerImageDefaults.SwiftAccountImage),
 			SwiftContainerImage:           getImageDefault("RELATED_IMAGE_SWIFT_CONTAINER_IMAGE_URL_DEFAULT", ContainerImageDefaults.SwiftContainerImage),
@@ -138,6 +138,10 @@ func (r *OpenStackDataPlaneNodeSet) validateNodes(ctx context.Context, c client.
 		errors = append(errors, r.Spec.validatePreProvisionedNodes()...)
 	}
 
+	if r.Spec.NodePool != nil {
+		errors = append(errors, r.Spec.validateNodePool()...)
+	}
+
 	return errors, nil
 
 }
@@ -233,6 +237,33 @@ func (spec *OpenStackDataPlaneNodeSetSpec) ValidateDelete() field.ErrorList {
 
 }
 
+// validateNodePool validates that nodes can be generated from the pool
+func (spec *OpenStackDataPlaneNodeSetSpec) validateNodePool() field.ErrorList {
+	var errors field.ErrorList
+	poolPath := field.NewPath("spec").Child("nodePool")
+
+	if spec.PreProvisioned || spec.BaremetalSetTemplate == nil {
+		errors = append(errors, field.Forbidden(
+			poolPath,
+			"nodePool requires a baremetalSetTemplate and is not supported with pre-provisioned nodes"))
+	}
+
+	if strings.Count(spec.NodePool.HostNamePattern, "%d") != 1 {
+		errors = append(errors, field.Invalid(
+			poolPath.Child("hostNamePattern"),
+			spec.NodePool.HostNamePattern,
+			"hostNamePattern must contain %d exactly once"))
+	} else {
+		for _, msg := range apimachineryvalidation.IsDNS1123Label(spec.NodePool.GetNodeName(0)) {
+			errors = append(errors, field.Invalid(
+				poolPath.Child("hostNamePattern"),
+				spec.NodePool.HostNamePattern,
+				msg))
+		}
+	}
+	return errors
+}
+
 // validatePreProvisionedNodes validates that ansibleHost is a valid IP
 // for pre-provisioned nodes. An IP is required so that the controller
 // can default the ctlplane fixedIP from it, ensuring IPAM reserves the
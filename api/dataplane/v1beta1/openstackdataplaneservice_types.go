@@ -17,6 +17,8 @@ limitations under the License.
 package v1beta1
 
 import (
+	"encoding/json"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	certmgrv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
@@ -76,6 +78,13 @@ type OpenStackDataPlaneServiceSpec struct {
 	// Role is a path to the role that ansible will run on this execution
 	Role string `json:"role,omitempty"`
 
+	// PlaybookSource - Git repository or OCI artifact the playbooks and roles
+	// of the service are fetched from when its job starts, instead of the
+	// runner image. Playbook is then a path relative to the root of the
+	// source, or the FQCN of a playbook of the collection it holds.
+	// +kubebuilder:validation:Optional
+	PlaybookSource *PlaybookSourceSpec `json:"playbookSource,omitempty" yaml:"playbookSource,omitempty"`
+
 	// CACerts - Secret containing the CA certificate chain
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:MaxLength:=253
@@ -117,6 +126,72 @@ type OpenStackDataPlaneServiceSpec struct {
 	// to manage the service. If not set, will default to the
 	// OpenStackDataPlaneService name.
 	EDPMServiceType string `json:"edpmServiceType,omitempty" yaml:"edpmServiceType,omitempty"`
+
+	// ParallelGroup - services listed next to each other on a NodeSet with the
+	// same ParallelGroup are deployed at the same time, instead of one after the
+	// other. The services that follow the group start once all the services of
+	// the group are ready.
+	// +kubebuilder:validation:Optional
+	ParallelGroup string `json:"parallelGroup,omitempty" yaml:"parallelGroup,omitempty"`
+
+	// AnsibleEEJobTemplate - resources, scheduling and timeout of the AnsibleEE
+	// jobs of the service. The fields set here override the ones of the
+	// OpenStackDataPlaneDeployment and of the NodeSets.
+	// +kubebuilder:validation:Optional
+	AnsibleEEJobTemplate *AnsibleEEJobTemplate `json:"ansibleEEJobTemplate,omitempty" yaml:"ansibleEEJobTemplate,omitempty"`
+
+	// AnsibleVarsSchema - OpenAPI v3 schema, as used by the
+	// CustomResourceDefinitions, of the ansible variables consumed by the
+	// service. The group and host vars of the NodeSets deploying the service
+	// are validated against it when the NodeSets and the
+	// OpenStackDataPlaneDeployments are created or updated.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	AnsibleVarsSchema map[string]json.RawMessage `json:"ansibleVarsSchema,omitempty" yaml:"ansibleVarsSchema,omitempty"`
+}
+
+// PlaybookSourceSpec defines where the playbooks of a service are fetched
+// from. Exactly one of Git or OCI must be set.
+type PlaybookSourceSpec struct {
+	// Git - fetch the playbooks from a ref of a Git repository
+	// +kubebuilder:validation:Optional
+	Git *GitPlaybookSource `json:"git,omitempty" yaml:"git,omitempty"`
+
+	// OCI - fetch the playbooks from an OCI artifact pulled with oras
+	// +kubebuilder:validation:Optional
+	OCI *OCIPlaybookSource `json:"oci,omitempty" yaml:"oci,omitempty"`
+}
+
+// GitPlaybookSource defines the Git repository the playbooks are fetched from
+type GitPlaybookSource struct {
+	// URL - HTTP(S) URL of the repository
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern:=`^https?://`
+	URL string `json:"url" yaml:"url"`
+
+	// Ref - branch, tag or commit fetched from the repository
+	// +kubebuilder:validation:Required
+	Ref string `json:"ref" yaml:"ref"`
+
+	// CredentialsSecretName - name of the Secret holding the username and
+	// password, or the token alone, used to fetch from the repository
+	// +kubebuilder:validation:Optional
+	CredentialsSecretName string `json:"credentialsSecretName,omitempty" yaml:"credentialsSecretName,omitempty"`
+}
+
+// OCIPlaybookSource defines the OCI artifact the playbooks are fetched from
+type OCIPlaybookSource struct {
+	// Image - reference of the artifact pinned by digest, e.g.
+	// quay.io/example/playbooks@sha256:<digest>
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern:=`^[^@]+@sha256:[a-f0-9]{64}$`
+	Image string `json:"image" yaml:"image"`
+
+	// PullSecretName - name of the kubernetes.io/dockerconfigjson Secret
+	// used to pull the artifact
+	// +kubebuilder:validation:Optional
+	PullSecretName string `json:"pullSecretName,omitempty" yaml:"pullSecretName,omitempty"`
 }
 
 // OpenStackDataPlaneServiceStatus defines the observed state of OpenStackDataPlaneService
@@ -83,9 +83,24 @@ func (spec *OpenStackDataPlaneServiceSpec) ValidateArtifact() field.ErrorList {
 	return field.ErrorList{}
 }
 
+// ValidatePlaybookSource validates that the PlaybookSource sets exactly one
+// of Git or OCI
+func (spec *OpenStackDataPlaneServiceSpec) ValidatePlaybookSource() field.ErrorList {
+	if spec.PlaybookSource != nil && (spec.PlaybookSource.Git == nil) == (spec.PlaybookSource.OCI == nil) {
+		return field.ErrorList{
+			field.Invalid(
+				field.NewPath("spec", "playbookSource"),
+				spec.PlaybookSource, "playbookSource requires exactly one of git or oci",
+			),
+		}
+	}
+
+	return field.ErrorList{}
+}
+
 // ValidateCreate validates the OpenStackDataPlaneServiceSpec on creation
 func (spec *OpenStackDataPlaneServiceSpec) ValidateCreate() field.ErrorList {
-	return spec.ValidateArtifact()
+	return append(spec.ValidateArtifact(), spec.ValidatePlaybookSource()...)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
@@ -106,7 +121,7 @@ func (r *OpenStackDataPlaneService) ValidateUpdate(original runtime.Object) (adm
 
 // ValidateUpdate validates the OpenStackDataPlaneServiceSpec on update
 func (spec *OpenStackDataPlaneServiceSpec) ValidateUpdate() field.ErrorList {
-	return spec.ValidateArtifact()
+	return append(spec.ValidateArtifact(), spec.ValidatePlaybookSource()...)
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -31,6 +31,43 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AnsibleEEJobTemplate) DeepCopyInto(out *AnsibleEEJobTemplate) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ActiveDeadlineSeconds != nil {
+		in, out := &in.ActiveDeadlineSeconds, &out.ActiveDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnsibleEEJobTemplate.
+func (in *AnsibleEEJobTemplate) DeepCopy() *AnsibleEEJobTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(AnsibleEEJobTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AnsibleEESpec) DeepCopyInto(out *AnsibleEESpec) {
 	*out = *in
@@ -74,6 +111,11 @@ func (in *AnsibleEESpec) DeepCopyInto(out *AnsibleEESpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.JobTemplate != nil {
+		in, out := &in.JobTemplate, &out.JobTemplate
+		*out = new(AnsibleEEJobTemplate)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnsibleEESpec.
@@ -86,6 +128,36 @@ func (in *AnsibleEESpec) DeepCopy() *AnsibleEESpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AnsibleExecutionProgress) DeepCopyInto(out *AnsibleExecutionProgress) {
+	*out = *in
+	if in.CompletedHosts != nil {
+		in, out := &in.CompletedHosts, &out.CompletedHosts
+		*out = new(int)
+		**out = **in
+	}
+	if in.TotalHosts != nil {
+		in, out := &in.TotalHosts, &out.TotalHosts
+		*out = new(int)
+		**out = **in
+	}
+	if in.LastEventSequence != nil {
+		in, out := &in.LastEventSequence, &out.LastEventSequence
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnsibleExecutionProgress.
+func (in *AnsibleExecutionProgress) DeepCopy() *AnsibleExecutionProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(AnsibleExecutionProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AnsibleExecutionSummary) DeepCopyInto(out *AnsibleExecutionSummary) {
 	*out = *in
@@ -127,6 +199,30 @@ func (in *AnsibleExecutionSummary) DeepCopyInto(out *AnsibleExecutionSummary) {
 			copy(*out, *in)
 		}
 	}
+	if in.OkTasks != nil {
+		in, out := &in.OkTasks, &out.OkTasks
+		*out = new(int)
+		**out = **in
+	}
+	if in.ChangedTasks != nil {
+		in, out := &in.ChangedTasks, &out.ChangedTasks
+		*out = new(int)
+		**out = **in
+	}
+	if in.SkippedTasks != nil {
+		in, out := &in.SkippedTasks, &out.SkippedTasks
+		*out = new(int)
+		**out = **in
+	}
+	if in.TaskFailures != nil {
+		in, out := &in.TaskFailures, &out.TaskFailures
+		*out = new([]AnsibleTaskFailure)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]AnsibleTaskFailure, len(*in))
+			copy(*out, *in)
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnsibleExecutionSummary.
@@ -177,6 +273,46 @@ func (in *AnsibleOpts) DeepCopy() *AnsibleOpts {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AnsibleTaskFailure) DeepCopyInto(out *AnsibleTaskFailure) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnsibleTaskFailure.
+func (in *AnsibleTaskFailure) DeepCopy() *AnsibleTaskFailure {
+	if in == nil {
+		return nil
+	}
+	out := new(AnsibleTaskFailure)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArtifactSinkSpec) DeepCopyInto(out *ArtifactSinkSpec) {
+	*out = *in
+	if in.PVC != nil {
+		in, out := &in.PVC, &out.PVC
+		*out = new(PVCArtifactSinkSpec)
+		**out = **in
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(S3ArtifactSinkSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArtifactSinkSpec.
+func (in *ArtifactSinkSpec) DeepCopy() *ArtifactSinkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArtifactSinkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigMapEnvSource) DeepCopyInto(out *ConfigMapEnvSource) {
 	*out = *in
@@ -223,6 +359,56 @@ func (in *DataSource) DeepCopy() *DataSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DecommissionSpec) DeepCopyInto(out *DecommissionSpec) {
+	*out = *in
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DecommissionSpec.
+func (in *DecommissionSpec) DeepCopy() *DecommissionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DecommissionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DrainBeforeUpdateSpec) DeepCopyInto(out *DrainBeforeUpdateSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DrainBeforeUpdateSpec.
+func (in *DrainBeforeUpdateSpec) DeepCopy() *DrainBeforeUpdateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DrainBeforeUpdateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitPlaybookSource) DeepCopyInto(out *GitPlaybookSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitPlaybookSource.
+func (in *GitPlaybookSource) DeepCopy() *GitPlaybookSource {
+	if in == nil {
+		return nil
+	}
+	out := new(GitPlaybookSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LocalObjectReference) DeepCopyInto(out *LocalObjectReference) {
 	*out = *in
@@ -238,6 +424,43 @@ func (in *LocalObjectReference) DeepCopy() *LocalObjectReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceSpec) DeepCopyInto(out *MaintenanceSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceSpec.
+func (in *MaintenanceSpec) DeepCopy() *MaintenanceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePoolSpec) DeepCopyInto(out *NodePoolSpec) {
+	*out = *in
+	if in.BmhLabelSelector != nil {
+		in, out := &in.BmhLabelSelector, &out.BmhLabelSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolSpec.
+func (in *NodePoolSpec) DeepCopy() *NodePoolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeSection) DeepCopyInto(out *NodeSection) {
 	*out = *in
@@ -266,6 +489,11 @@ func (in *NodeSection) DeepCopyInto(out *NodeSection) {
 		**out = **in
 	}
 	in.Ansible.DeepCopyInto(&out.Ansible)
+	if in.Maintenance != nil {
+		in, out := &in.Maintenance, &out.Maintenance
+		*out = new(MaintenanceSpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSection.
@@ -318,6 +546,21 @@ func (in *NodeTemplate) DeepCopy() *NodeTemplate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCIPlaybookSource) DeepCopyInto(out *OCIPlaybookSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCIPlaybookSource.
+func (in *OCIPlaybookSource) DeepCopy() *OCIPlaybookSource {
+	if in == nil {
+		return nil
+	}
+	out := new(OCIPlaybookSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpenStackDataPlaneDeployment) DeepCopyInto(out *OpenStackDataPlaneDeployment) {
 	*out = *in
@@ -418,6 +661,21 @@ func (in *OpenStackDataPlaneDeploymentSpec) DeepCopyInto(out *OpenStackDataPlane
 			(*out)[key] = val
 		}
 	}
+	if in.DrainBeforeUpdate != nil {
+		in, out := &in.DrainBeforeUpdate, &out.DrainBeforeUpdate
+		*out = new(DrainBeforeUpdateSpec)
+		**out = **in
+	}
+	if in.ArtifactSink != nil {
+		in, out := &in.ArtifactSink, &out.ArtifactSink
+		*out = new(ArtifactSinkSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AnsibleEEJobTemplate != nil {
+		in, out := &in.AnsibleEEJobTemplate, &out.AnsibleEEJobTemplate
+		*out = new(AnsibleEEJobTemplate)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenStackDataPlaneDeploymentSpec.
@@ -465,6 +723,13 @@ func (in *OpenStackDataPlaneDeploymentStatus) DeepCopyInto(out *OpenStackDataPla
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.AnsibleExecutionProgress != nil {
+		in, out := &in.AnsibleExecutionProgress, &out.AnsibleExecutionProgress
+		*out = make(map[string]AnsibleExecutionProgress, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 	if in.ConfigMapHashes != nil {
 		in, out := &in.ConfigMapHashes, &out.ConfigMapHashes
 		*out = make(map[string]string, len(*in))
@@ -500,6 +765,49 @@ func (in *OpenStackDataPlaneDeploymentStatus) DeepCopyInto(out *OpenStackDataPla
 			(*out)[key] = val
 		}
 	}
+	if in.UpdatedBatches != nil {
+		in, out := &in.UpdatedBatches, &out.UpdatedBatches
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ArtifactLocations != nil {
+		in, out := &in.ArtifactLocations, &out.ArtifactLocations
+		*out = make(map[string]map[string]string, len(*in))
+		for key, val := range *in {
+			var outVal map[string]string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make(map[string]string, len(*in))
+				for key, val := range *in {
+					(*out)[key] = val
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.PlaybookSourceRevisions != nil {
+		in, out := &in.PlaybookSourceRevisions, &out.PlaybookSourceRevisions
+		*out = make(map[string]map[string]string, len(*in))
+		for key, val := range *in {
+			var outVal map[string]string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make(map[string]string, len(*in))
+				for key, val := range *in {
+					(*out)[key] = val
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make(condition.Conditions, len(*in))
@@ -616,6 +924,21 @@ func (in *OpenStackDataPlaneNodeSetSpec) DeepCopyInto(out *OpenStackDataPlaneNod
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Decommission != nil {
+		in, out := &in.Decommission, &out.Decommission
+		*out = new(DecommissionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodePool != nil {
+		in, out := &in.NodePool, &out.NodePool
+		*out = new(NodePoolSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AnsibleEEJobTemplate != nil {
+		in, out := &in.AnsibleEEJobTemplate, &out.AnsibleEEJobTemplate
+		*out = new(AnsibleEEJobTemplate)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenStackDataPlaneNodeSetSpec.
@@ -736,6 +1059,23 @@ func (in *OpenStackDataPlaneNodeSetStatus) DeepCopyInto(out *OpenStackDataPlaneN
 			(*out)[key] = val
 		}
 	}
+	if in.DecommissionStatuses != nil {
+		in, out := &in.DecommissionStatuses, &out.DecommissionStatuses
+		*out = make(map[string]DecommissionPhase, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.CordonedNodes != nil {
+		in, out := &in.CordonedNodes, &out.CordonedNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodePoolNodes != nil {
+		in, out := &in.NodePoolNodes, &out.NodePoolNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenStackDataPlaneNodeSetStatus.
@@ -824,11 +1164,37 @@ func (in *OpenStackDataPlaneServiceSpec) DeepCopyInto(out *OpenStackDataPlaneSer
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.PlaybookSource != nil {
+		in, out := &in.PlaybookSource, &out.PlaybookSource
+		*out = new(PlaybookSourceSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ContainerImageFields != nil {
 		in, out := &in.ContainerImageFields, &out.ContainerImageFields
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.AnsibleEEJobTemplate != nil {
+		in, out := &in.AnsibleEEJobTemplate, &out.AnsibleEEJobTemplate
+		*out = new(AnsibleEEJobTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AnsibleVarsSchema != nil {
+		in, out := &in.AnsibleVarsSchema, &out.AnsibleVarsSchema
+		*out = make(map[string]json.RawMessage, len(*in))
+		for key, val := range *in {
+			var outVal []byte
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make(json.RawMessage, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenStackDataPlaneServiceSpec.
@@ -893,6 +1259,61 @@ func (in *OpenstackDataPlaneServiceCert) DeepCopy() *OpenstackDataPlaneServiceCe
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PVCArtifactSinkSpec) DeepCopyInto(out *PVCArtifactSinkSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PVCArtifactSinkSpec.
+func (in *PVCArtifactSinkSpec) DeepCopy() *PVCArtifactSinkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PVCArtifactSinkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlaybookSourceSpec) DeepCopyInto(out *PlaybookSourceSpec) {
+	*out = *in
+	if in.Git != nil {
+		in, out := &in.Git, &out.Git
+		*out = new(GitPlaybookSource)
+		**out = **in
+	}
+	if in.OCI != nil {
+		in, out := &in.OCI, &out.OCI
+		*out = new(OCIPlaybookSource)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlaybookSourceSpec.
+func (in *PlaybookSourceSpec) DeepCopy() *PlaybookSourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PlaybookSourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3ArtifactSinkSpec) DeepCopyInto(out *S3ArtifactSinkSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3ArtifactSinkSpec.
+func (in *S3ArtifactSinkSpec) DeepCopy() *S3ArtifactSinkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(S3ArtifactSinkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretEnvSource) DeepCopyInto(out *SecretEnvSource) {
 	*out = *in
@@ -43,6 +43,7 @@ import (
 	clientcontroller "github.com/openstack-k8s-operators/openstack-operator/internal/controller/client"
 	corecontroller "github.com/openstack-k8s-operators/openstack-operator/internal/controller/core"
 	dataplanecontroller "github.com/openstack-k8s-operators/openstack-operator/internal/controller/dataplane"
+	deployment "github.com/openstack-k8s-operators/openstack-operator/internal/dataplane"
 	webhookclientv1beta1 "github.com/openstack-k8s-operators/openstack-operator/internal/webhook/client/v1beta1"
 	webhookcorev1beta1 "github.com/openstack-k8s-operators/openstack-operator/internal/webhook/core/v1beta1"
 	webhookdataplanev1beta1 "github.com/openstack-k8s-operators/openstack-operator/internal/webhook/dataplane/v1beta1"
@@ -346,6 +347,14 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "OpenStackVersion")
 		os.Exit(1)
 	}
+	if err := (&corecontroller.OpenStackControlPlanePlanReconciler{
+		Client:  mgr.GetClient(),
+		Scheme:  mgr.GetScheme(),
+		Kclient: kclient,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "OpenStackControlPlanePlan")
+		os.Exit(1)
+	}
 	if err := (&dataplanecontroller.OpenStackDataPlaneNodeSetReconciler{
 		Client:  mgr.GetClient(),
 		Scheme:  mgr.GetScheme(),
@@ -362,9 +371,11 @@ func main() {
 		os.Exit(1)
 	}
 	if err := (&dataplanecontroller.OpenStackDataPlaneDeploymentReconciler{
-		Client:  mgr.GetClient(),
-		Scheme:  mgr.GetScheme(),
-		Kclient: kclient,
+		Client:       mgr.GetClient(),
+		Scheme:       mgr.GetScheme(),
+		Kclient:      kclient,
+		Recorder:     mgr.GetEventRecorderFor("openstackdataplanedeployment-controller"),
+		EventLimiter: deployment.NewEventRateLimiter(),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "OpenStackDataPlaneDeployment")
 		os.Exit(1)
@@ -25,6 +25,7 @@ export RELATED_IMAGE_IRONIC_PXE_IMAGE_URL_DEFAULT=quay.io/podified-antelope-cent
 export RELATED_IMAGE_IRONIC_NEUTRON_AGENT_IMAGE_URL_DEFAULT=quay.io/podified-antelope-centos9/openstack-ironic-neutron-agent:current-podified
 export RELATED_IMAGE_IRONIC_PYTHON_AGENT_IMAGE_URL_DEFAULT=quay.io/podified-antelope-centos9/ironic-python-agent:current-podified
 export RELATED_IMAGE_OS_CONTAINER_IMAGE_URL_DEFAULT=quay.io/podified-antelope-centos9/edpm-hardened-uefi:current-podified
+export RELATED_IMAGE_ORAS_IMAGE_URL_DEFAULT=ghcr.io/oras-project/oras:v1.2.3
 export RELATED_IMAGE_AGENT_IMAGE_URL_DEFAULT=quay.io/openstack-k8s-operators/openstack-baremetal-operator-agent:current-podified
 export RELATED_IMAGE_APACHE_IMAGE_URL_DEFAULT=registry.redhat.io/ubi9/httpd-24:latest
 export OS_IMAGE_DEFAULT=edpm-hardened-uefi.qcow2
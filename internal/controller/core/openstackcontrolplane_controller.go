@@ -40,6 +40,7 @@ import (
 	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
 	common_helper "github.com/openstack-k8s-operators/lib-common/modules/common/helper"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/webhook"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 
 	designatev1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
@@ -130,6 +131,7 @@ func (r *OpenStackControlPlaneReconciler) GetLogger(ctx context.Context) logr.Lo
 // +kubebuilder:rbac:groups=config.openshift.io,resources=networks,verbs=get;list;watch;
 // +kubebuilder:rbac:groups=topology.openstack.org,resources=topologies,verbs=get;list;watch;update
 // +kubebuilder:rbac:groups=watcher.openstack.org,resources=watchers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -299,6 +301,12 @@ func (r *OpenStackControlPlaneReconciler) Reconcile(ctx context.Context, req ctr
 	}
 	instance.Status.Conditions.MarkTrue(corev1beta1.OpenStackControlPlaneBackupConfigReadyCondition, corev1beta1.OpenStackControlPlaneBackupConfigReadyMessage)
 
+	// Run the setup steps first, e.g. autoscaling the APIs, both the normal
+	// and the minor update reconciliation rely on them
+	if _, err := r.reconcileSteps(ctx, instance, version, helper, openstack.ControlPlaneStageSetup); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	if instance.Status.DeployedVersion == nil || version.Spec.TargetVersion == *instance.Status.DeployedVersion { //revive:disable:indent-error-flow
 		// green field deployment or no minor update in progress
 		ctrlResult, err := r.reconcileNormal(ctx, instance, version, helper)
@@ -408,6 +416,10 @@ func (r *OpenStackControlPlaneReconciler) Reconcile(ctx context.Context, req ctr
 }
 
 func (r *OpenStackControlPlaneReconciler) reconcileOVNControllers(ctx context.Context, instance *corev1beta1.OpenStackControlPlane, version *corev1beta1.OpenStackVersion, helper *common_helper.Helper) (ctrl.Result, error) {
+	if managed, err := openstack.EnsureOVNManaged(ctx, instance, helper); !managed || err != nil {
+		return ctrl.Result{}, err
+	}
+
 	var ovnMetricsCertName string
 	if instance.Spec.Ovn.Enabled && instance.Spec.TLS.PodLevel.Enabled {
 		var err error
@@ -466,9 +478,9 @@ func isInfrastructureReady(instance *corev1beta1.OpenStackControlPlane) (bool, [
 }
 
 func (r *OpenStackControlPlaneReconciler) reconcileNormal(ctx context.Context, instance *corev1beta1.OpenStackControlPlane, version *corev1beta1.OpenStackVersion, helper *common_helper.Helper) (ctrl.Result, error) {
-	if instance.Spec.TopologyRef != nil {
+	if topologyRef := instance.GetSchedulingTopologyRef(); topologyRef != nil {
 		if err := r.checkTopologyRef(ctx, helper,
-			instance.Spec.TopologyRef, instance.Namespace); err != nil {
+			topologyRef, instance.Namespace); err != nil {
 			instance.Status.Conditions.Set(condition.FalseCondition(
 				condition.TopologyReadyCondition,
 				condition.ErrorReason,
@@ -515,44 +527,10 @@ func (r *OpenStackControlPlaneReconciler) reconcileNormal(ctx context.Context, i
 		}
 	}
 
-	// Reconcile infrastructure components (always run)
-	ctrlResult, err := openstack.ReconcileCAs(ctx, instance, helper)
-	if err != nil {
-		return ctrl.Result{}, err
-	} else if (ctrlResult != ctrl.Result{}) {
-		return ctrlResult, nil
-	}
-
-	ctrlResult, err = openstack.ReconcileDNSMasqs(ctx, instance, version, helper)
-	if err != nil {
-		return ctrl.Result{}, err
-	} else if (ctrlResult != ctrl.Result{}) {
-		return ctrlResult, nil
-	}
-
-	ctrlResult, err = openstack.ReconcileRabbitMQs(ctx, instance, version, helper)
-	if err != nil {
-		return ctrl.Result{}, err
-	} else if (ctrlResult != ctrl.Result{}) {
-		return ctrlResult, nil
-	}
-
-	ctrlResult, err = openstack.ReconcileGaleras(ctx, instance, version, helper)
-	if err != nil {
-		return ctrl.Result{}, err
-	} else if (ctrlResult != ctrl.Result{}) {
-		return ctrlResult, nil
-	}
+	openstack.PrepareControlPlane(instance)
 
-	ctrlResult, err = openstack.ReconcileMemcacheds(ctx, instance, version, helper)
-	if err != nil {
-		return ctrl.Result{}, err
-	} else if (ctrlResult != ctrl.Result{}) {
-		return ctrlResult, nil
-	}
-
-	// OVN databases are part of infrastructure
-	ctrlResult, err = openstack.ReconcileOVN(ctx, instance, version, helper)
+	// Reconcile infrastructure components (always run)
+	ctrlResult, err := r.reconcileSteps(ctx, instance, version, helper, openstack.ControlPlaneStageInfrastructure)
 	if err != nil {
 		return ctrl.Result{}, err
 	} else if (ctrlResult != ctrl.Result{}) {
@@ -602,161 +580,20 @@ func (r *OpenStackControlPlaneReconciler) reconcileNormal(ctx context.Context, i
 	}
 
 	// Continue with OpenStack service reconciliation
-	ctrlResult, err = openstack.ReconcileKeystoneAPI(ctx, instance, version, helper)
-	if err != nil {
-		return ctrl.Result{}, err
-	} else if (ctrlResult != ctrl.Result{}) {
-		return ctrlResult, nil
-	}
-
-	ctrlResult, err = openstack.ReconcilePlacementAPI(ctx, instance, version, helper)
-	if err != nil {
-		return ctrl.Result{}, err
-	} else if (ctrlResult != ctrl.Result{}) {
-		return ctrlResult, nil
-	}
-
-	ctrlResult, err = openstack.ReconcileGlance(ctx, instance, version, helper)
-	if err != nil {
-		return ctrl.Result{}, err
-	} else if (ctrlResult != ctrl.Result{}) {
-		return ctrlResult, nil
-	}
-
-	ctrlResult, err = openstack.ReconcileCinder(ctx, instance, version, helper)
-	if err != nil {
-		return ctrl.Result{}, err
-	} else if (ctrlResult != ctrl.Result{}) {
-		return ctrlResult, nil
-	}
-
-	// OVN already reconciled in infrastructure section above
-
-	ctrlResult, err = openstack.ReconcileNeutron(ctx, instance, version, helper)
-	if err != nil {
-		return ctrl.Result{}, err
-	} else if (ctrlResult != ctrl.Result{}) {
-		return ctrlResult, nil
-	}
-
-	ctrlResult, err = openstack.ReconcileNova(ctx, instance, version, helper)
-	if err != nil {
-		return ctrl.Result{}, err
-	} else if (ctrlResult != ctrl.Result{}) {
-		return ctrlResult, nil
-	}
-
-	ctrlResult, err = openstack.ReconcileHeat(ctx, instance, version, helper)
-	if err != nil {
-		return ctrl.Result{}, err
-	} else if (ctrlResult != ctrl.Result{}) {
-		return ctrlResult, nil
-	}
-
-	ctrlResult, err = openstack.ReconcileIronic(ctx, instance, version, helper)
-	if err != nil {
-		return ctrl.Result{}, err
-	} else if (ctrlResult != ctrl.Result{}) {
-		return ctrlResult, nil
-	}
-
-	ctrlResult, err = openstack.ReconcileOpenStackClient(ctx, instance, version, helper)
-	if err != nil {
-		return ctrl.Result{}, err
-	} else if (ctrlResult != ctrl.Result{}) {
-		return ctrlResult, nil
-	}
-
-	ctrlResult, err = openstack.ReconcileManila(ctx, instance, version, helper)
-	if err != nil {
-		return ctrl.Result{}, err
-	} else if (ctrlResult != ctrl.Result{}) {
-		return ctrlResult, nil
-	}
-
-	ctrlResult, err = openstack.ReconcileHorizon(ctx, instance, version, helper)
-	if err != nil {
-		return ctrl.Result{}, err
-	} else if (ctrlResult != ctrl.Result{}) {
-		return ctrlResult, nil
-	}
-
-	ctrlResult, err = openstack.ReconcileTelemetry(ctx, instance, version, helper)
-	if err != nil {
-		return ctrl.Result{}, err
-	} else if (ctrlResult != ctrl.Result{}) {
-		return ctrlResult, nil
-	}
-
-	ctrlResult, err = openstack.ReconcileBarbican(ctx, instance, version, helper)
-	if err != nil {
-		return ctrl.Result{}, err
-	} else if (ctrlResult != ctrl.Result{}) {
-		return ctrlResult, nil
-	}
-
-	ctrlResult, err = openstack.ReconcileRedis(ctx, instance, version, helper)
-	if err != nil {
-		return ctrl.Result{}, err
-	} else if (ctrlResult != ctrl.Result{}) {
-		return ctrlResult, nil
-	}
-
-	ctrlResult, err = openstack.ReconcileOctavia(ctx, instance, version, helper)
-	if err != nil {
-		return ctrl.Result{}, err
-	} else if (ctrlResult != ctrl.Result{}) {
-		return ctrlResult, nil
-	}
-
-	ctrlResult, err = openstack.ReconcileDesignate(ctx, instance, version, helper)
-	if err != nil {
-		return ctrl.Result{}, err
-	} else if (ctrlResult != ctrl.Result{}) {
-		return ctrlResult, nil
-	}
-
-	ctrlResult, err = openstack.ReconcileSwift(ctx, instance, version, helper)
-	if err != nil {
-		return ctrl.Result{}, err
-	} else if (ctrlResult != ctrl.Result{}) {
-		return ctrlResult, nil
-	}
-
-	ctrlResult, err = openstack.ReconcileTest(ctx, instance, version, helper)
-	if err != nil {
-		return ctrl.Result{}, err
-	} else if (ctrlResult != ctrl.Result{}) {
-		return ctrlResult, nil
-	}
-
-	ctrlResult, err = openstack.ReconcileInstanceHa(ctx, instance, version, helper)
-	if err != nil {
-		return ctrl.Result{}, err
-	} else if (ctrlResult != ctrl.Result{}) {
-		return ctrlResult, nil
-	}
-
-	ctrlResult, err = openstack.ReconcileWatcher(ctx, instance, version, helper)
-	if err != nil {
-		return ctrl.Result{}, err
-	} else if (ctrlResult != ctrl.Result{}) {
-		return ctrlResult, nil
-	}
+	return r.reconcileSteps(ctx, instance, version, helper, openstack.ControlPlaneStageServices)
+}
 
-	ctrlResult, errs := openstack.DeleteCertsAndRoutes(ctx, instance, helper)
-	if errs != nil {
-		instance.Status.Conditions.Set(condition.FalseCondition(
-			corev1beta1.OpenStackControlPlaneCertCleanupReadyCondition,
-			condition.ErrorReason,
-			condition.SeverityWarning,
-			corev1beta1.OpenStackControlPlaneCertCleanupReadyErrorMessage,
-			errs))
-		return ctrlResult, errs
+// reconcileSteps runs the steps of a stage of the reconciliation in order,
+// stopping at the first one that fails or requeues
+func (r *OpenStackControlPlaneReconciler) reconcileSteps(ctx context.Context, instance *corev1beta1.OpenStackControlPlane, version *corev1beta1.OpenStackVersion, helper *common_helper.Helper, stage openstack.ControlPlaneStage) (ctrl.Result, error) {
+	for _, step := range openstack.GetControlPlaneSteps(stage) {
+		ctrlResult, err := step.Reconcile(ctx, instance, version, helper)
+		if err != nil {
+			return ctrlResult, err
+		} else if (ctrlResult != ctrl.Result{}) {
+			return ctrlResult, nil
+		}
 	}
-
-	instance.Status.Conditions.Remove(corev1beta1.OpenStackControlPlaneCertCleanupReadyCondition)
-
 	return ctrl.Result{}, nil
 }
 
@@ -890,6 +727,7 @@ func (r *OpenStackControlPlaneReconciler) SetupWithManager(
 		Owns(&barbicanv1.Barbican{}).
 		Owns(&watcherv1.Watcher{}).
 		Owns(&corev1beta1.OpenStackVersion{}).
+		Owns(&autoscalingv2.HorizontalPodAutoscaler{}).
 		Watches(
 			&corev1.Secret{},
 			handler.EnqueueRequestsFromMapFunc(r.findObjectsForSrc),
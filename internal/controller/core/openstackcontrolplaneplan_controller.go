@@ -0,0 +1,255 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/go-logr/logr"
+	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	corev1beta1 "github.com/openstack-k8s-operators/openstack-operator/api/core/v1beta1"
+	"github.com/openstack-k8s-operators/openstack-operator/internal/openstack"
+)
+
+// OpenStackControlPlanePlanReconciler reconciles a OpenStackControlPlanePlan object
+type OpenStackControlPlanePlanReconciler struct {
+	client.Client
+	Kclient kubernetes.Interface
+	Scheme  *runtime.Scheme
+}
+
+// GetLogger returns a logger object with a prefix of "controller.name" and additional controller context fields
+func (r *OpenStackControlPlanePlanReconciler) GetLogger(ctx context.Context) logr.Logger {
+	return log.FromContext(ctx).WithName("Controllers").WithName("OpenStackControlPlanePlan")
+}
+
+// +kubebuilder:rbac:groups=core.openstack.org,resources=openstackcontrolplaneplans,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core.openstack.org,resources=openstackcontrolplaneplans/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core.openstack.org,resources=openstackcontrolplanes,verbs=get;list;watch;update
+// +kubebuilder:rbac:groups=core.openstack.org,resources=openstackversions,verbs=get;list;watch
+
+// Reconcile computes the changes the OpenStackControlPlane reconcile would
+// make for the proposed spec of the plan, without making them
+func (r *OpenStackControlPlanePlanReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, _err error) {
+	Log := r.GetLogger(ctx)
+	Log.Info("Reconciling OpenStackControlPlanePlan")
+	// Fetch the instance
+	instance := &corev1beta1.OpenStackControlPlanePlan{}
+	err := r.Get(ctx, req.NamespacedName, instance)
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return ctrl.Result{}, err
+	}
+
+	planHelper, err := helper.NewHelper(
+		instance,
+		r.Client,
+		r.Kclient,
+		r.Scheme,
+		Log,
+	)
+	if err != nil {
+		Log.Error(err, "unable to create helper")
+		return ctrl.Result{}, err
+	}
+
+	// Save a copy of the condtions so that we can restore the LastTransitionTime
+	// when a condition's state doesn't change.
+	savedConditions := instance.Status.Conditions.DeepCopy()
+
+	// Always patch the instance status when exiting this function so we can persist any changes.
+	defer func() {
+		// Don't update the status, if reconciler Panics
+		if r := recover(); r != nil {
+			Log.Info(fmt.Sprintf("panic during reconcile %v\n", r))
+			panic(r)
+		}
+		condition.RestoreLastTransitionTimes(
+			&instance.Status.Conditions, savedConditions)
+
+		err := planHelper.PatchInstance(ctx, instance)
+		if err != nil {
+			_err = err
+			return
+		}
+	}()
+
+	cl := condition.CreateList(
+		condition.UnknownCondition(condition.ReadyCondition, condition.InitReason, corev1beta1.OpenStackControlPlanePlanReadyInitMessage),
+	)
+	instance.Status.Conditions.Init(&cl)
+	instance.Status.ObservedGeneration = instance.Generation
+
+	controlPlane := &corev1beta1.OpenStackControlPlane{}
+	err = r.Get(ctx, client.ObjectKey{
+		Namespace: instance.Namespace,
+		Name:      instance.Spec.ControlPlane,
+	}, controlPlane)
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			Log.Info("Controlplane not found:", "controlPlane", instance.Spec.ControlPlane)
+			r.setWaiting(instance, instance.Spec.ControlPlane)
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	// the OpenStackVersion is created by the OpenStackControlPlane reconcile
+	// and provides the container images
+	version := &corev1beta1.OpenStackVersion{}
+	err = r.Get(ctx, client.ObjectKey{
+		Namespace: instance.Namespace,
+		Name:      controlPlane.Name,
+	}, version)
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		return ctrl.Result{}, err
+	}
+	if err != nil || !version.Status.Conditions.IsTrue(corev1beta1.OpenStackVersionInitialized) {
+		Log.Info("OpenStackVersion not initialized:", "controlPlane", controlPlane.Name)
+		r.setWaiting(instance, controlPlane.Name)
+		return ctrl.Result{RequeueAfter: time.Duration(10) * time.Second}, nil
+	}
+
+	proposed, err := openstack.ApplyControlPlanePlanPatch(controlPlane, instance.Spec.SpecPatch)
+	if err != nil {
+		r.setError(instance, err)
+		return ctrl.Result{}, nil
+	}
+	// deleting a Galera or RabbitMQ instance requires to confirm it, pass the
+	// confirmation of the plan to get the deletions planned
+	if confirmed, ok := instance.Annotations[corev1beta1.ConfirmDeleteAnnotation]; ok {
+		if proposed.Annotations == nil {
+			proposed.Annotations = map[string]string{}
+		}
+		proposed.Annotations[corev1beta1.ConfirmDeleteAnnotation] = confirmed
+	}
+
+	// A dry run update defaults and validates the proposed spec the same way
+	// applying it would, without persisting it
+	err = r.Update(ctx, proposed, client.DryRunAll)
+	if err != nil {
+		if k8s_errors.IsConflict(err) {
+			Log.Info("Controlplane changed while planning, retrying")
+			return ctrl.Result{Requeue: true}, nil
+		}
+		if k8s_errors.IsInvalid(err) || k8s_errors.IsForbidden(err) || k8s_errors.IsBadRequest(err) {
+			r.setError(instance, err)
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	changes, incompleteSteps, err := openstack.PlanControlPlane(
+		ctx, proposed, version, r.Client, r.Kclient, r.Scheme, Log)
+	if err != nil {
+		r.setError(instance, err)
+		return ctrl.Result{}, err
+	}
+
+	instance.Status.Changes = changes
+	instance.Status.IncompleteSteps = incompleteSteps
+	instance.Status.ControlPlaneGeneration = controlPlane.Generation
+	instance.Status.Conditions.MarkTrue(
+		condition.ReadyCondition, corev1beta1.OpenStackControlPlanePlanReadyMessage)
+	Log.Info("OpenStackControlPlanePlan computed", "changes", len(changes), "incompleteSteps", len(incompleteSteps))
+
+	return ctrl.Result{}, nil
+}
+
+func (r *OpenStackControlPlanePlanReconciler) setWaiting(instance *corev1beta1.OpenStackControlPlanePlan, controlPlane string) {
+	instance.Status.Changes = nil
+	instance.Status.IncompleteSteps = nil
+	instance.Status.Conditions.Set(condition.FalseCondition(
+		condition.ReadyCondition,
+		condition.RequestedReason,
+		condition.SeverityInfo,
+		corev1beta1.OpenStackControlPlanePlanReadyWaitingMessage,
+		controlPlane))
+}
+
+func (r *OpenStackControlPlanePlanReconciler) setError(instance *corev1beta1.OpenStackControlPlanePlan, err error) {
+	instance.Status.Changes = nil
+	instance.Status.IncompleteSteps = nil
+	instance.Status.Conditions.Set(condition.FalseCondition(
+		condition.ReadyCondition,
+		condition.ErrorReason,
+		condition.SeverityWarning,
+		corev1beta1.OpenStackControlPlanePlanReadyErrorMessage,
+		err.Error()))
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *OpenStackControlPlanePlanReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// recompute the plans of an OpenStackControlPlane when its spec changes
+	controlPlaneFunc := handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, o client.Object) []reconcile.Request {
+		Log := r.GetLogger(ctx)
+		planList := &corev1beta1.OpenStackControlPlanePlanList{}
+
+		result := []reconcile.Request{}
+
+		listOpts := []client.ListOption{
+			client.InNamespace(o.GetNamespace()),
+		}
+		if err := r.List(ctx, planList, listOpts...); err != nil {
+			Log.Error(err, "Unable to retrieve OpenStackControlPlanePlan")
+			return nil
+		}
+
+		for _, i := range planList.Items {
+			if i.Spec.ControlPlane != o.GetName() {
+				continue
+			}
+			name := client.ObjectKey{
+				Namespace: o.GetNamespace(),
+				Name:      i.Name,
+			}
+			result = append(result, reconcile.Request{NamespacedName: name})
+		}
+		if len(result) > 0 {
+			Log.Info("Reconcile request for:", "result", result)
+			return result
+		}
+		return nil
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1beta1.OpenStackControlPlanePlan{},
+			builder.WithPredicates(predicate.Or(
+				predicate.GenerationChangedPredicate{},
+				predicate.AnnotationChangedPredicate{}))).
+		Watches(&corev1beta1.OpenStackControlPlane{}, controlPlaneFunc,
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
@@ -29,6 +29,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -50,8 +51,11 @@ import (
 // OpenStackDataPlaneDeploymentReconciler reconciles a OpenStackDataPlaneDeployment object
 type OpenStackDataPlaneDeploymentReconciler struct {
 	client.Client
-	Kclient kubernetes.Interface
-	Scheme  *runtime.Scheme
+	Kclient  kubernetes.Interface
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	// EventLimiter limits the rate of the ansible events recorded per object
+	EventLimiter *deployment.EventRateLimiter
 }
 
 // GetLogger returns a logger object with a prefix of "controller.name" and additional controller context fields
@@ -65,10 +69,12 @@ func (r *OpenStackDataPlaneDeploymentReconciler) GetLogger(ctx context.Context)
 // +kubebuilder:rbac:groups=dataplane.openstack.org,resources=openstackdataplanenodesets,verbs=get;list;watch
 // +kubebuilder:rbac:groups=dataplane.openstack.org,resources=openstackdataplaneservices,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=pods,verbs=list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups=discovery.k8s.io,resources=endpointslices,verbs=get;list;watch;create;update;patch;delete;
 // +kubebuilder:rbac:groups=cert-manager.io,resources=issuers,verbs=get;list;watch;
 // +kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=get;list;watch;create;update;patch;delete;
 // +kubebuilder:rbac:groups=core.openstack.org,resources=openstackcontrolplanes,verbs=get;list;watch;
+// +kubebuilder:rbac:groups=client.openstack.org,resources=openstackclients,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -290,6 +296,7 @@ func (r *OpenStackDataPlaneDeploymentReconciler) Reconcile(ctx context.Context,
 		ansibleEESpec.AnsibleLimit = instance.Spec.AnsibleLimit
 		ansibleEESpec.ExtraVars = instance.Spec.AnsibleExtraVars
 		ansibleEESpec.AnsibleEEEnvConfigMapName = instance.Spec.AnsibleEEEnvConfigMapName
+		ansibleEESpec.JobTemplate = ansibleEESpec.JobTemplate.Merge(instance.Spec.AnsibleEEJobTemplate)
 
 		if nodeSet.Status.DNSClusterAddresses != nil && nodeSet.Status.CtlplaneSearchDomain != "" {
 			ansibleEESpec.DNSConfig = &corev1.PodDNSConfig{
@@ -308,13 +315,20 @@ func (r *OpenStackDataPlaneDeploymentReconciler) Reconcile(ctx context.Context,
 			InventorySecrets:            globalInventorySecrets,
 			AnsibleSSHPrivateKeySecrets: globalSSHKeySecrets,
 			Version:                     version,
+			Recorder:                    r.Recorder,
+			EventLimiter:                r.EventLimiter,
 		}
 
 		// When ServicesOverride is set on the OpenStackDataPlaneDeployment,
 		// deploy those services for each OpenStackDataPlaneNodeSet. Otherwise,
 		// deploy with the OpenStackDataPlaneNodeSet's Services.
 		var deployResult *ctrl.Result
-		deployResult, err = deployer.Deploy(nodesetServiceMap[nodeSet.Name])
+		if instance.Spec.DrainBeforeUpdate != nil {
+			// Drain, update and enable the hosts one batch at a time
+			deployResult, err = deployer.DeployInBatches(nodesetServiceMap[nodeSet.Name])
+		} else {
+			deployResult, err = deployer.Deploy(nodesetServiceMap[nodeSet.Name])
+		}
 
 		nsConditions := instance.Status.NodeSetConditions[nodeSet.Name]
 		nsConditions.Set(nsConditions.Mirror(dataplanev1.NodeSetDeploymentReadyCondition))
@@ -450,6 +464,7 @@ func (r *OpenStackDataPlaneDeploymentReconciler) setHashes(
 			return err
 		}
 	}
+	deployment.GetPlaybookSourceHashes(instance.Status.PlaybookSourceRevisions, instance.Status.ConfigMapHashes)
 
 	for _, nodeSet := range nodeSets.Items {
 		instance.Status.NodeSetHashes[nodeSet.Name] = nodeSet.Status.ConfigHash
@@ -465,14 +480,15 @@ func (r *OpenStackDataPlaneDeploymentReconciler) setHashes(
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *OpenStackDataPlaneDeploymentReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	// watch for changes in certificates
-	certFn := func(ctx context.Context, obj client.Object) []reconcile.Request {
+	// watch for changes in certificates and in the progress reported by the
+	// AnsibleEE jobs
+	nodeSetObjectFn := func(ctx context.Context, obj client.Object) []reconcile.Request {
 		Log := r.GetLogger(ctx)
 		result := []reconcile.Request{}
 
 		objectLabelValue, ok := obj.GetLabels()[deployment.NodeSetLabel]
 		if !ok {
-			// cert doesn't have a nodeset label
+			// object doesn't have a nodeset label
 			return nil
 		}
 
@@ -495,7 +511,7 @@ func (r *OpenStackDataPlaneDeploymentReconciler) SetupWithManager(mgr ctrl.Manag
 					Namespace: dep.GetNamespace(),
 					Name:      dep.GetName(),
 				}
-				Log.Info(fmt.Sprintf("Cert %s is used by deployment %s", obj.GetName(), dep.GetName()))
+				Log.Info(fmt.Sprintf("%s of NodeSet %s is used by deployment %s", obj.GetName(), objectLabelValue, dep.GetName()))
 				result = append(result, reconcile.Request{NamespacedName: name})
 			}
 		}
@@ -514,7 +530,13 @@ func (r *OpenStackDataPlaneDeploymentReconciler) SetupWithManager(mgr ctrl.Manag
 				predicate.LabelChangedPredicate{}))).
 		Owns(&batchv1.Job{}).
 		Watches(&certmgrv1.Certificate{},
-			handler.EnqueueRequestsFromMapFunc(certFn)).
+			handler.EnqueueRequestsFromMapFunc(nodeSetObjectFn)).
+		Watches(&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(nodeSetObjectFn),
+			builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+				_, ok := obj.GetLabels()[deployment.ProgressLabel]
+				return ok
+			}))).
 		Complete(r)
 }
 
@@ -550,6 +572,8 @@ func (r *OpenStackDataPlaneDeploymentReconciler) listNodeSets(ctx context.Contex
 				err.Error())
 			return &nodeSets, err
 		}
+		// The nodes generated from the nodePool are not stored in the spec
+		nodeSetInstance.Spec.AddNodePoolNodes(nodeSetInstance.Status.NodePoolNodes)
 		nodeSets.Items = append(nodeSets.Items, *nodeSetInstance)
 	}
 	return &nodeSets, err
@@ -68,6 +68,8 @@ const (
 	AnsibleSSHPrivateKey = "ssh-privatekey"
 	// AnsibleSSHAuthorizedKeys authorized keys
 	AnsibleSSHAuthorizedKeys = "authorized_keys"
+	// nodeRetryInterval - the interval to retry the failed node operations
+	nodeRetryInterval = time.Duration(30) * time.Second
 )
 
 // OpenStackDataPlaneNodeSetReconciler reconciles a OpenStackDataPlaneNodeSet object
@@ -108,11 +110,12 @@ func (r *OpenStackDataPlaneNodeSetReconciler) GetLogger(ctx context.Context) log
 // +kubebuilder:rbac:groups=network.openstack.org,resources=dnsdata/finalizers,verbs=update;patch
 // +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete;
 // +kubebuilder:rbac:groups=core.openstack.org,resources=openstackversions,verbs=get;list;watch
+// +kubebuilder:rbac:groups=client.openstack.org,resources=openstackclients,verbs=get;list;watch
 
 // RBAC for the ServiceAccount for the internal image registry
 // +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;update;patch
-// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=roles,verbs=get;list;watch;create;update;patch
-// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=rolebindings,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=roles,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=rolebindings,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="security.openshift.io",resourceNames=anyuid,resources=securitycontextconstraints,verbs=use
 // +kubebuilder:rbac:groups="",resources=pods,verbs=list
 // +kubebuilder:rbac:groups="",resources=namespaces,verbs=get
@@ -171,6 +174,12 @@ func (r *OpenStackDataPlaneNodeSetReconciler) Reconcile(ctx context.Context, req
 		return ctrl.Result{}, err
 	}
 
+	// Add the nodes generated from the nodePool before creating the helper,
+	// so that they are used by the rest of the reconcile but are never
+	// patched into the stored spec.
+	nodePoolNodes := instance.GetNodePoolNodes()
+	instance.Spec.AddNodePoolNodes(nodePoolNodes)
+
 	helper, _ := helper.NewHelper(
 		instance,
 		r.Client,
@@ -179,6 +188,8 @@ func (r *OpenStackDataPlaneNodeSetReconciler) Reconcile(ctx context.Context, req
 		Log,
 	)
 
+	instance.Status.NodePoolNodes = nodePoolNodes
+
 	// initialize status if Conditions is nil, but do not reset if it already
 	// exists
 	isNewInstance := instance.Status.Conditions == nil
@@ -380,6 +391,26 @@ func (r *OpenStackDataPlaneNodeSetReconciler) Reconcile(ctx context.Context, req
 		return rbResult, nil
 	}
 
+	// Allow the AnsibleEE jobs to report their progress while a deployment
+	// asks for it
+	reportProgress, err := deployment.IsProgressReported(ctx, helper, instance)
+	if err == nil {
+		if reportProgress {
+			err = deployment.EnsureProgressReporting(ctx, helper, instance)
+		} else {
+			err = deployment.DeleteProgressReporting(ctx, helper, instance)
+		}
+	}
+	if err != nil {
+		instance.Status.Conditions.MarkFalse(
+			condition.ServiceAccountReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.ServiceAccountReadyErrorMessage,
+			err.Error())
+		return ctrl.Result{}, err
+	}
+
 	instance.Status.Conditions.MarkTrue(
 		condition.ServiceAccountReadyCondition,
 		condition.ServiceAccountReadyMessage)
@@ -389,6 +420,23 @@ func (r *OpenStackDataPlaneNodeSetReconciler) Reconcile(ctx context.Context, req
 		return ctrl.Result{}, err
 	}
 	containerImages := dataplaneutil.GetContainerImages(version)
+
+	// Decommission nodes removed from the NodeSet before releasing them. A
+	// failure is reported in the NodeSetDecommissionReady condition and
+	// doesn't block the rest of the NodeSet.
+	decommissionDone, decommissionErr := deployment.EnsureDecommission(ctx, helper, instance, containerImages)
+	if decommissionErr != nil {
+		Log.Error(decommissionErr, "Unable to decommission nodes")
+	}
+
+	// Cordon the nodes in maintenance. A failure is reported in the
+	// NodeSetMaintenanceReady condition and doesn't block the rest of the
+	// NodeSet.
+	maintenanceErr := deployment.EnsureMaintenance(ctx, helper, instance)
+	if maintenanceErr != nil {
+		Log.Error(maintenanceErr, "Unable to update the nodes in maintenance")
+	}
+
 	var provResult deployment.ProvisionResult
 	// Reconcile BaremetalSet if required
 	if !instance.Spec.PreProvisioned {
@@ -433,6 +481,14 @@ func (r *OpenStackDataPlaneNodeSetReconciler) Reconcile(ctx context.Context, req
 			return ctrl.Result{}, errInventory
 		}
 	}
+
+	// Render the inventory of the spec proposed for review, if any
+	err = deployment.EnsureInventoryPreview(ctx, helper, instance,
+		allIPSets, dnsDetails.ServerAddresses, containerImages, netServiceNetMap)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
 	// all setup tasks complete, mark SetupReadyCondition True
 	instance.Status.Conditions.MarkTrue(dataplanev1.SetupReadyCondition, condition.ReadyMessage)
 
@@ -451,7 +507,10 @@ func (r *OpenStackDataPlaneNodeSetReconciler) Reconcile(ctx context.Context, req
 		Log.Info("Set NodeSet DeploymentReadyCondition true")
 		instance.Status.Conditions.MarkTrue(condition.DeploymentReadyCondition,
 			condition.DeploymentReadyMessage)
-		instance.Status.DeployedBmhHash = instance.Status.BmhRefHash
+		// The BaremetalSet keeps the removed hosts until they are decommissioned
+		if decommissionDone {
+			instance.Status.DeployedBmhHash = instance.Status.BmhRefHash
+		}
 	} else if isDeploymentRunning {
 		Log.Info("Deployment still running...", "instance", instance)
 		Log.Info("Set NodeSet DeploymentReadyCondition false")
@@ -486,6 +545,12 @@ func (r *OpenStackDataPlaneNodeSetReconciler) Reconcile(ctx context.Context, req
 			"%s", deployErrorMsg)
 	}
 
+	if err == nil && (decommissionErr != nil || maintenanceErr != nil) {
+		// Retry the decommission and maintenance, their failed jobs are run
+		// again once deleted
+		return ctrl.Result{RequeueAfter: nodeRetryInterval}, nil
+	}
+
 	return ctrl.Result{}, err
 }
 
@@ -0,0 +1,247 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployment
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsvalidation "k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	infranetworkv1 "github.com/openstack-k8s-operators/infra-operator/apis/network/v1beta1"
+	"github.com/openstack-k8s-operators/lib-common/modules/ansible"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	openstackv1 "github.com/openstack-k8s-operators/openstack-operator/api/core/v1beta1"
+	dataplanev1 "github.com/openstack-k8s-operators/openstack-operator/api/dataplane/v1beta1"
+)
+
+// NewAnsibleVarsSchemaValidator returns a validator for the ansibleVarsSchema
+// of a service, nil when the service doesn't declare one
+func NewAnsibleVarsSchemaValidator(service *dataplanev1.OpenStackDataPlaneService) (apiextensionsvalidation.SchemaValidator, error) {
+	if len(service.Spec.AnsibleVarsSchema) == 0 {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(service.Spec.AnsibleVarsSchema)
+	if err != nil {
+		return nil, err
+	}
+	schemaV1 := &apiextensionsv1.JSONSchemaProps{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(schemaV1); err != nil {
+		return nil, err
+	}
+	schema := &apiextensions.JSONSchemaProps{}
+	err = apiextensionsv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(schemaV1, schema, nil)
+	if err != nil {
+		return nil, err
+	}
+	validator, _, err := apiextensionsvalidation.NewSchemaValidator(schema)
+	return validator, err
+}
+
+// ValidateAnsibleVarsSchema checks the ansibleVarsSchema of a service is a
+// valid schema
+func ValidateAnsibleVarsSchema(service *dataplanev1.OpenStackDataPlaneService) field.ErrorList {
+	var errors field.ErrorList
+	if _, err := NewAnsibleVarsSchemaValidator(service); err != nil {
+		errors = append(errors, field.Invalid(field.NewPath("spec", "ansibleVarsSchema"),
+			field.OmitValueType{}, err.Error()))
+	}
+	return errors
+}
+
+// ValidateAnsibleVarsSchemas validates the group and host vars each node of
+// the NodeSet would get in the inventory against the ansibleVarsSchema of
+// the services. The field errors point at the NodeSet key setting the
+// offending variable. Services which don't exist yet are skipped.
+func ValidateAnsibleVarsSchemas(ctx context.Context, helper *helper.Helper,
+	instance *dataplanev1.OpenStackDataPlaneNodeSet, services []string,
+) (admission.Warnings, field.ErrorList, error) {
+	var warnings admission.Warnings
+	var errors field.ErrorList
+
+	validators := map[string]apiextensionsvalidation.SchemaValidator{}
+	serviceNames := []string{}
+	for _, serviceName := range services {
+		if _, ok := validators[serviceName]; ok {
+			continue
+		}
+		service, err := GetService(ctx, helper, serviceName)
+		if err != nil {
+			if k8s_errors.IsNotFound(err) {
+				continue
+			}
+			return warnings, errors, err
+		}
+		validator, err := NewAnsibleVarsSchemaValidator(&service)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf(
+				"invalid ansibleVarsSchema of service %s: %s", serviceName, err))
+			continue
+		}
+		if validator != nil {
+			validators[serviceName] = validator
+			serviceNames = append(serviceNames, serviceName)
+		}
+	}
+	if len(validators) == 0 {
+		return warnings, errors, nil
+	}
+
+	inventory := ansible.MakeInventory()
+	group := inventory.AddGroup(instance.Name)
+	groupVars, err := getAnsibleVarsFrom(ctx, helper, instance.Namespace, &instance.Spec.NodeTemplate.Ansible)
+	if err != nil {
+		// The ConfigMaps and Secrets may be created after the NodeSet
+		warnings = append(warnings, fmt.Sprintf(
+			"could not get ansible group vars to validate them against the services ansibleVarsSchema: %s", err))
+		return warnings, errors, nil
+	}
+	for k, v := range groupVars {
+		group.Vars[k] = v
+	}
+
+	netServiceNetMap := map[string]string{}
+	netConfigList := &infranetworkv1.NetConfigList{}
+	err = helper.GetClient().List(ctx, netConfigList, client.InNamespace(instance.Namespace))
+	if err != nil {
+		return warnings, errors, err
+	}
+	if len(netConfigList.Items) > 0 {
+		netServiceNetMap = BuildNetServiceNetMap(netConfigList.Items[0])
+	}
+
+	err = resolveGroupAnsibleVars(&instance.Spec.NodeTemplate, &group,
+		openstackv1.ContainerImages{}, netServiceNetMap)
+	if err != nil {
+		return warnings, errors, err
+	}
+
+	nodeNames := make([]string, 0, len(instance.Spec.Nodes))
+	for name := range instance.Spec.Nodes {
+		nodeNames = append(nodeNames, name)
+	}
+	sort.Strings(nodeNames)
+
+	reported := map[string]bool{}
+	for _, nodeName := range nodeNames {
+		node := instance.Spec.Nodes[nodeName]
+
+		host := group.AddHost(strings.Split(node.HostName, ".")[0])
+		hostVars, err := getAnsibleVarsFrom(ctx, helper, instance.Namespace, &node.Ansible)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf(
+				"could not get ansible host vars of node %s to validate them against the services ansibleVarsSchema: %s",
+				nodeName, err))
+			continue
+		}
+		for k, v := range hostVars {
+			host.Vars[k] = v
+		}
+		err = resolveHostAnsibleVars(&node, &host, netServiceNetMap)
+		if err != nil {
+			return warnings, errors, err
+		}
+
+		vars, err := toJSONAnsibleVars(mergeAnsibleVars(group.Vars, host.Vars))
+		if err != nil {
+			return warnings, errors, err
+		}
+		for _, serviceName := range serviceNames {
+			for _, varErr := range apiextensionsvalidation.ValidateCustomResource(nil, vars, validators[serviceName]) {
+				err := ansibleVarsSchemaError(instance, nodeName, serviceName, varErr, hostVars, groupVars)
+				// Errors on group vars are the same for all the nodes
+				if !reported[err.Error()] {
+					reported[err.Error()] = true
+					errors = append(errors, err)
+				}
+			}
+		}
+	}
+
+	return warnings, errors, nil
+}
+
+// toJSONAnsibleVars converts the vars of a host to the JSON types the schema
+// validator expects. Variables without a value, like the container images
+// coming from the OpenStackVersion, are left out.
+func toJSONAnsibleVars(vars map[string]interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(vars)
+	if err != nil {
+		return nil, err
+	}
+	jsonVars := map[string]interface{}{}
+	if err := json.Unmarshal(data, &jsonVars); err != nil {
+		return nil, err
+	}
+	for k, v := range jsonVars {
+		if v == nil {
+			delete(jsonVars, k)
+		}
+	}
+	return jsonVars, nil
+}
+
+// ansibleVarsSchemaError rewrites the path of an error returned by the schema
+// validator, relative to the vars of a node, to the NodeSet key setting the
+// variable. The values coming from ConfigMaps and Secrets are not reported.
+func ansibleVarsSchemaError(instance *dataplanev1.OpenStackDataPlaneNodeSet, nodeName string, serviceName string,
+	varErr *field.Error, hostVarsFrom map[string]interface{}, groupVarsFrom map[string]interface{},
+) *field.Error {
+	err := *varErr
+
+	varName, subPath := "", ""
+	if err.Field != "" && err.Field != "<nil>" {
+		varName = err.Field
+		if idx := strings.IndexAny(varName, ".["); idx > 0 {
+			varName, subPath = varName[:idx], varName[idx:]
+		}
+	}
+
+	var path *field.Path
+	if varName == "" {
+		path = field.NewPath("spec", "nodeTemplate", "ansible", "ansibleVars")
+	} else {
+		path = ansibleVarPath(instance, nodeName, varName, hostVarsFrom, groupVarsFrom)
+	}
+	if strings.HasSuffix(path.String(), "ansibleVarsFrom") {
+		// The detail names the variable
+		err.BadValue = field.OmitValueType{}
+		err.Field = path.String()
+	} else {
+		err.Field = path.String() + subPath
+	}
+
+	if err.Detail == "" {
+		err.Detail = fmt.Sprintf("required by the ansibleVarsSchema of service %s", serviceName)
+	} else {
+		err.Detail = fmt.Sprintf("%s, from the ansibleVarsSchema of service %s", err.Detail, serviceName)
+	}
+	return &err
+}
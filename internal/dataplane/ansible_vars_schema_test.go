@@ -0,0 +1,141 @@
+package deployment
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiextensionsvalidation "k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	dataplanev1 "github.com/openstack-k8s-operators/openstack-operator/api/dataplane/v1beta1"
+)
+
+func testAnsibleVarsSchemaService(schema string) *dataplanev1.OpenStackDataPlaneService {
+	service := &dataplanev1.OpenStackDataPlaneService{}
+	service.Name = "custom"
+	if err := json.Unmarshal([]byte(schema), &service.Spec.AnsibleVarsSchema); err != nil {
+		panic(err)
+	}
+	return service
+}
+
+func TestValidateAnsibleVarsSchema(t *testing.T) {
+	assert.Empty(t, ValidateAnsibleVarsSchema(&dataplanev1.OpenStackDataPlaneService{}))
+	assert.Empty(t, ValidateAnsibleVarsSchema(testAnsibleVarsSchemaService(
+		`{"type": "object", "properties": {"edpm_custom_port": {"type": "integer"}}}`)))
+
+	errs := ValidateAnsibleVarsSchema(testAnsibleVarsSchemaService(
+		`{"type": "object", "propreties": {"edpm_custom_port": {"type": "integer"}}}`))
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "spec.ansibleVarsSchema", errs[0].Field)
+	assert.Contains(t, errs[0].Detail, "propreties")
+}
+
+func TestAnsibleVarsSchemaErrors(t *testing.T) {
+	service := testAnsibleVarsSchemaService(`{
+		"type": "object",
+		"required": ["edpm_custom_mode"],
+		"properties": {
+			"edpm_custom_port": {"type": "integer", "maximum": 65535},
+			"edpm_custom_mode": {"type": "string", "enum": ["active", "standby"]},
+			"edpm_custom_token": {"type": "string", "minLength": 32},
+			"edpm_custom_options": {
+				"type": "object",
+				"properties": {"debug": {"type": "boolean"}}
+			}
+		}
+	}`)
+	validator, err := NewAnsibleVarsSchemaValidator(service)
+	assert.NoError(t, err)
+
+	instance := &dataplanev1.OpenStackDataPlaneNodeSet{
+		Spec: dataplanev1.OpenStackDataPlaneNodeSetSpec{
+			NodeTemplate: dataplanev1.NodeTemplate{
+				Ansible: dataplanev1.AnsibleOpts{
+					AnsibleVars: map[string]json.RawMessage{
+						"edpm_custom_port":    json.RawMessage(`70000`),
+						"edpm_custom_options": json.RawMessage(`{"debug": "yes"}`),
+					},
+				},
+			},
+			Nodes: map[string]dataplanev1.NodeSection{
+				"compute-0": {
+					Ansible: dataplanev1.AnsibleOpts{
+						AnsibleVars: map[string]json.RawMessage{
+							"edpm_custom_port": json.RawMessage(`8080`),
+						},
+					},
+				},
+				"compute-1": {},
+			},
+		},
+	}
+	groupVarsFrom := map[string]interface{}{"edpm_custom_token": "secret"}
+	groupVars := map[string]interface{}{
+		"edpm_custom_port":    70000,
+		"edpm_custom_options": map[string]interface{}{"debug": "yes"},
+		"edpm_custom_token":   "secret",
+		"edpm_frr_image":      (*string)(nil),
+	}
+
+	tests := []struct {
+		name     string
+		nodeName string
+		hostVars map[string]interface{}
+		errors   []string
+	}{
+		{
+			name:     "node overriding a group var",
+			nodeName: "compute-0",
+			hostVars: map[string]interface{}{"edpm_custom_port": 8080},
+			errors: []string{
+				"spec.nodeTemplate.ansible.ansibleVars[edpm_custom_mode]: Required value: " +
+					"required by the ansibleVarsSchema of service custom",
+				"spec.nodeTemplate.ansible.ansibleVars[edpm_custom_options].debug: Invalid value: \"string\": " +
+					"edpm_custom_options.debug in body must be of type boolean: \"string\", " +
+					"from the ansibleVarsSchema of service custom",
+				"spec.nodeTemplate.ansible.ansibleVarsFrom: Invalid value: " +
+					"edpm_custom_token in body should be at least 32 chars long, " +
+					"from the ansibleVarsSchema of service custom",
+			},
+		},
+		{
+			name:     "node using the group vars",
+			nodeName: "compute-1",
+			hostVars: map[string]interface{}{},
+			errors: []string{
+				"spec.nodeTemplate.ansible.ansibleVars[edpm_custom_mode]: Required value: " +
+					"required by the ansibleVarsSchema of service custom",
+				"spec.nodeTemplate.ansible.ansibleVars[edpm_custom_options].debug: Invalid value: \"string\": " +
+					"edpm_custom_options.debug in body must be of type boolean: \"string\", " +
+					"from the ansibleVarsSchema of service custom",
+				"spec.nodeTemplate.ansible.ansibleVarsFrom: Invalid value: " +
+					"edpm_custom_token in body should be at least 32 chars long, " +
+					"from the ansibleVarsSchema of service custom",
+				"spec.nodeTemplate.ansible.ansibleVars[edpm_custom_port]: Invalid value: 70000: " +
+					"edpm_custom_port in body should be less than or equal to 65535, " +
+					"from the ansibleVarsSchema of service custom",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vars, err := toJSONAnsibleVars(mergeAnsibleVars(groupVars, tt.hostVars))
+			assert.NoError(t, err)
+			assert.NotContains(t, vars, "edpm_frr_image")
+
+			var errs field.ErrorList
+			for _, varErr := range apiextensionsvalidation.ValidateCustomResource(nil, vars, validator) {
+				errs = append(errs, ansibleVarsSchemaError(instance, tt.nodeName, service.Name,
+					varErr, map[string]interface{}{}, groupVarsFrom))
+			}
+			messages := []string{}
+			for _, err := range errs {
+				messages = append(messages, err.Error())
+			}
+			assert.ElementsMatch(t, tt.errors, messages)
+		})
+	}
+}
@@ -60,6 +60,7 @@ func DeployBaremetalSet(
 	_, err := controllerutil.CreateOrPatch(ctx, helper.GetClient(), baremetalSet, func() error {
 		ownerLabels := labels.GetLabels(instance, labels.GetGroupLabel(NodeSetLabel), map[string]string{})
 		baremetalSet.Labels = utils.MergeStringMaps(baremetalSet.GetLabels(), ownerLabels)
+		currentHosts := baremetalSet.Spec.BaremetalHosts
 		baremetalSet.Spec.BaremetalHosts = make(map[string]baremetalv1.InstanceSpec)
 		if instance.Spec.BaremetalSetTemplate != nil {
 			instance.Spec.BaremetalSetTemplate.DeepCopyInto(&baremetalSet.Spec.OpenStackBaremetalSetTemplateSpec)
@@ -108,6 +109,16 @@ func DeployBaremetalSet(
 			baremetalSet.Spec.BaremetalHosts[hostName] = instanceSpec
 
 		}
+		// Keep the hosts removed from the NodeSet until they are decommissioned
+		for hostName, phase := range instance.Status.DecommissionStatuses {
+			hostSpec, ok := currentHosts[hostName]
+			if !ok || phase == dataplanev1.DecommissionPhaseDecommissioned {
+				continue
+			}
+			if _, exists := baremetalSet.Spec.BaremetalHosts[hostName]; !exists {
+				baremetalSet.Spec.BaremetalHosts[hostName] = hostSpec
+			}
+		}
 		err := controllerutil.SetControllerReference(
 			helper.GetBeforeObject(), baremetalSet, helper.GetScheme())
 		return err
@@ -0,0 +1,276 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployment
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	job "github.com/openstack-k8s-operators/lib-common/modules/common/job"
+	clientv1 "github.com/openstack-k8s-operators/openstack-operator/api/client/v1beta1"
+	dataplanev1 "github.com/openstack-k8s-operators/openstack-operator/api/dataplane/v1beta1"
+	"github.com/openstack-k8s-operators/openstack-operator/internal/dataplane/util"
+	"github.com/openstack-k8s-operators/openstack-operator/internal/openstackclient"
+)
+
+// ControlPlaneAction is an operation run against the control plane on
+// behalf of a data plane node
+type ControlPlaneAction string
+
+const (
+	// ControlPlaneActionDrain disables the nova-compute service of the node
+	// and live-migrates its instances to other computes
+	ControlPlaneActionDrain ControlPlaneAction = "drain"
+	// ControlPlaneActionRemove deletes the nova-compute service, placement
+	// resource provider and OVN chassis of the node
+	ControlPlaneActionRemove ControlPlaneAction = "remove"
+	// ControlPlaneActionDisable disables the nova-compute service of the node
+	ControlPlaneActionDisable ControlPlaneAction = "disable"
+	// ControlPlaneActionEnable enables the nova-compute service of the node
+	ControlPlaneActionEnable ControlPlaneAction = "enable"
+
+	// openStackClientName is the name of the OpenStackClient created by the
+	// OpenStackControlPlane, its clouds config is used by control plane jobs
+	openStackClientName = "openstackclient"
+
+	// controlPlaneJobBackoffLimit is the number of retries of a control plane job
+	controlPlaneJobBackoffLimit int32 = 3
+)
+
+// controlPlaneHostsScript resolves the nova-compute hosts matching
+// NODE_HOSTNAME, either the short name or the FQDN may be registered.
+const controlPlaneHostsScript = `set -euxo pipefail
+HOSTS=$(openstack compute service list --service nova-compute -f value -c Host | grep -E "^${NODE_HOSTNAME%%.*}(\.|$)" | sort -u || true)
+`
+
+var controlPlaneScripts = map[ControlPlaneAction]string{
+	ControlPlaneActionDrain: controlPlaneHostsScript + `
+for host in ${HOSTS}; do
+    openstack compute service set --disable --disable-reason "${DISABLE_REASON:-openstack-operator}" "${host}" nova-compute
+    for server in $(openstack server list --all-projects --host "${host}" -f value -c ID); do
+        openstack --os-compute-api-version 2.30 server migrate --live-migration --wait "${server}"
+    done
+    REMAINING=$(openstack server list --all-projects --host "${host}" -f value -c ID)
+    if [ -n "${REMAINING}" ]; then
+        echo "instances still running on ${host}: ${REMAINING}"
+        exit 1
+    fi
+done
+`,
+	ControlPlaneActionDisable: controlPlaneHostsScript + `
+for host in ${HOSTS}; do
+    openstack compute service set --disable --disable-reason "${DISABLE_REASON:-openstack-operator}" "${host}" nova-compute
+done
+`,
+	ControlPlaneActionEnable: controlPlaneHostsScript + `
+for host in ${HOSTS}; do
+    openstack compute service set --enable "${host}" nova-compute
+done
+`,
+	ControlPlaneActionRemove: controlPlaneHostsScript + `
+for host in ${HOSTS}; do
+    for service in $(openstack compute service list --service nova-compute --host "${host}" -f value -c ID); do
+        openstack compute service delete "${service}"
+    done
+    for provider in $(openstack resource provider list --name "${host}" -f value -c uuid); do
+        openstack resource provider delete "${provider}" || true
+    done
+done
+for agent in $(openstack network agent list -f value -c ID -c Host | awk -v h="${NODE_HOSTNAME%%.*}" '$2 == h || index($2, h".") == 1 {print $1}'); do
+    openstack network agent delete "${agent}"
+done
+`,
+}
+
+// GetControlPlaneJobNameAndLabels Name and Labels of a control plane job
+func GetControlPlaneJobNameAndLabels(
+	action ControlPlaneAction,
+	nodeSetName string,
+	hostName string,
+) (string, map[string]string) {
+	shortName := strings.Split(hostName, ".")[0]
+	jobName := util.GetTruncatedName(fmt.Sprintf("%s-%s-%s", action, nodeSetName, shortName))
+	labels := map[string]string{
+		"openstackdataplanenodeset": nodeSetName,
+		"openstackdataplanenode":    shortName,
+		"openstackcontrolplanejob":  string(action),
+	}
+	return jobName, labels
+}
+
+// GetDeploymentControlPlaneJobNameAndLabels Name and Labels of a control plane
+// job run by an OpenStackDataPlaneDeployment
+func GetDeploymentControlPlaneJobNameAndLabels(
+	action ControlPlaneAction,
+	deploymentName string,
+	hostName string,
+) (string, map[string]string) {
+	shortName := strings.Split(hostName, ".")[0]
+	jobName := util.GetTruncatedName(fmt.Sprintf("%s-%s-%s", deploymentName, action, shortName))
+	labels := map[string]string{
+		"openstackdataplanedeployment": deploymentName,
+		"openstackdataplanenode":       shortName,
+		"openstackcontrolplanejob":     string(action),
+	}
+	return jobName, labels
+}
+
+// getControlPlaneJobNameAndLabels Name and Labels of a control plane job
+// depending on the kind of obj
+func getControlPlaneJobNameAndLabels(
+	action ControlPlaneAction,
+	obj client.Object,
+	hostName string,
+) (string, map[string]string) {
+	if _, ok := obj.(*dataplanev1.OpenStackDataPlaneDeployment); ok {
+		return GetDeploymentControlPlaneJobNameAndLabels(action, obj.GetName(), hostName)
+	}
+	return GetControlPlaneJobNameAndLabels(action, obj.GetName(), hostName)
+}
+
+// EnsureControlPlaneJob runs action against the control plane for hostName
+// using the clouds config of the OpenStackClient. It returns true once the
+// job has succeeded.
+func EnsureControlPlaneJob(
+	ctx context.Context,
+	helper *helper.Helper,
+	obj client.Object,
+	action ControlPlaneAction,
+	hostName string,
+	envVars map[string]string,
+) (bool, error) {
+	jobName, labels := getControlPlaneJobNameAndLabels(action, obj, hostName)
+
+	existingJob, err := util.GetAnsibleExecution(ctx, helper, obj, labels)
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		return false, err
+	}
+	if existingJob != nil {
+		return isJobDone(existingJob)
+	}
+
+	osClient := &clientv1.OpenStackClient{}
+	err = helper.GetClient().Get(ctx, types.NamespacedName{
+		Namespace: obj.GetNamespace(),
+		Name:      openStackClientName,
+	}, osClient)
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			return false, fmt.Errorf("OpenStackClient %s not found, the control plane is required to %s %s",
+				openStackClientName, action, hostName)
+		}
+		return false, err
+	}
+
+	podSpec := openstackclient.ClientPodSpec(ctx, osClient, helper, "")
+	podSpec.RestartPolicy = corev1.RestartPolicyNever
+	podSpec.Containers[0].Command = []string{"/bin/bash", "-c"}
+	podSpec.Containers[0].Args = []string{controlPlaneScripts[action]}
+	podSpec.Containers[0].Env = append(podSpec.Containers[0].Env,
+		corev1.EnvVar{Name: "NODE_HOSTNAME", Value: hostName})
+	envNames := make([]string, 0, len(envVars))
+	for name := range envVars {
+		envNames = append(envNames, name)
+	}
+	sort.Strings(envNames)
+	for _, name := range envNames {
+		podSpec.Containers[0].Env = append(podSpec.Containers[0].Env,
+			corev1.EnvVar{Name: name, Value: envVars[name]})
+	}
+
+	jobDef := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName,
+			Namespace: obj.GetNamespace(),
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: ptr.To(controlPlaneJobBackoffLimit),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+
+	cpJob := job.NewJob(
+		jobDef,
+		jobName,
+		true,
+		time.Duration(5)*time.Second,
+		"",
+	)
+	_, err = cpJob.DoJob(ctx, helper)
+	return false, err
+}
+
+// DeleteControlPlaneJob deletes the job running action for hostName, if any
+func DeleteControlPlaneJob(
+	ctx context.Context,
+	helper *helper.Helper,
+	obj client.Object,
+	action ControlPlaneAction,
+	hostName string,
+) error {
+	_, labels := getControlPlaneJobNameAndLabels(action, obj, hostName)
+	existingJob, err := util.GetAnsibleExecution(ctx, helper, obj, labels)
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	err = helper.GetClient().Delete(ctx, existingJob,
+		client.PropagationPolicy(metav1.DeletePropagationBackground))
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// isJobDone returns true if the job succeeded and an error if it exhausted
+// its retries
+func isJobDone(j *batchv1.Job) (bool, error) {
+	if j.Status.Succeeded > 0 {
+		return true, nil
+	}
+	if j.Spec.BackoffLimit != nil && j.Status.Failed > *j.Spec.BackoffLimit {
+		errorMsg := fmt.Sprintf("execution.name %s execution.namespace %s failed pods: %d", j.Name, j.Namespace, j.Status.Failed)
+		for _, jobCondition := range j.Status.Conditions {
+			if jobCondition.Type == batchv1.JobFailed && jobCondition.Reason == condition.JobReasonBackoffLimitExceeded {
+				errorMsg = fmt.Sprintf("backoff limit reached for execution.name %s execution.namespace %s execution.condition.message: %s", j.Name, j.Namespace, jobCondition.Message)
+			}
+		}
+		return false, fmt.Errorf("%s", errorMsg)
+	}
+	return false, nil
+}
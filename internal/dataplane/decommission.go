@@ -0,0 +1,488 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	networkv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	infranetworkv1 "github.com/openstack-k8s-operators/infra-operator/apis/network/v1beta1"
+	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	job "github.com/openstack-k8s-operators/lib-common/modules/common/job"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/labels"
+	nad "github.com/openstack-k8s-operators/lib-common/modules/common/networkattachment"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/secret"
+	utils "github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	"github.com/openstack-k8s-operators/lib-common/modules/storage"
+	openstackv1 "github.com/openstack-k8s-operators/openstack-operator/api/core/v1beta1"
+	dataplanev1 "github.com/openstack-k8s-operators/openstack-operator/api/dataplane/v1beta1"
+	"github.com/openstack-k8s-operators/openstack-operator/internal/dataplane/util"
+)
+
+// EnsureDecommission decommissions the nodes removed from the NodeSet, or
+// listed in the decommission-nodes annotation, and releases the IPSets of
+// removed nodes once they are decommissioned. It returns true when no node
+// is pending decommission.
+func EnsureDecommission(ctx context.Context, helper *helper.Helper,
+	instance *dataplanev1.OpenStackDataPlaneNodeSet,
+	containerImages openstackv1.ContainerImages,
+) (bool, error) {
+	if instance.Spec.Decommission == nil {
+		instance.Status.DecommissionStatuses = nil
+		return true, nil
+	}
+	if instance.Status.DecommissionStatuses == nil {
+		instance.Status.DecommissionStatuses = make(map[string]dataplanev1.DecommissionPhase)
+	}
+
+	hostNames, removedIPSets, err := getDecommissionHosts(ctx, helper, instance)
+	if err != nil {
+		instance.Status.Conditions.MarkFalse(
+			dataplanev1.NodeSetDecommissionReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityError,
+			dataplanev1.NodeSetDecommissionErrorMessage,
+			err.Error())
+		return false, err
+	}
+
+	// Forget about nodes which are not pending decommission anymore
+	for hostName := range instance.Status.DecommissionStatuses {
+		if !slices.Contains(hostNames, hostName) {
+			err = deleteDecommissionInventory(ctx, helper, instance, hostName)
+			if err != nil {
+				return false, err
+			}
+			delete(instance.Status.DecommissionStatuses, hostName)
+		}
+	}
+
+	inProgress := []string{}
+	for _, hostName := range hostNames {
+		done, err := decommissionNode(ctx, helper, instance, hostName, containerImages)
+		if err != nil {
+			utils.LogErrorForObject(helper, err, fmt.Sprintf("Unable to decommission %s", hostName), instance)
+			instance.Status.Conditions.MarkFalse(
+				dataplanev1.NodeSetDecommissionReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityError,
+				dataplanev1.NodeSetDecommissionErrorMessage,
+				err.Error())
+			return false, err
+		}
+		if !done {
+			inProgress = append(inProgress, hostName)
+			continue
+		}
+
+		ipSet, removed := removedIPSets[hostName]
+		if !removed {
+			continue
+		}
+		// The node is not part of the NodeSet anymore, release it
+		utils.LogForObject(helper, fmt.Sprintf("Releasing decommissioned node %s", hostName), instance)
+		err = releaseNode(ctx, helper, instance, hostName, ipSet)
+		if err != nil {
+			instance.Status.Conditions.MarkFalse(
+				dataplanev1.NodeSetDecommissionReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityError,
+				dataplanev1.NodeSetDecommissionErrorMessage,
+				err.Error())
+			return false, err
+		}
+		delete(instance.Status.DecommissionStatuses, hostName)
+	}
+
+	if len(inProgress) > 0 {
+		instance.Status.Conditions.MarkFalse(
+			dataplanev1.NodeSetDecommissionReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			dataplanev1.NodeSetDecommissionReadyWaitingMessage,
+			strings.Join(inProgress, ","))
+		return false, nil
+	}
+
+	instance.Status.Conditions.MarkTrue(
+		dataplanev1.NodeSetDecommissionReadyCondition,
+		dataplanev1.NodeSetDecommissionReadyMessage)
+	return true, nil
+}
+
+// IsNodeDecommissioning returns true if hostName is listed in the
+// decommission-nodes annotation or still has a decommission in progress
+func IsNodeDecommissioning(instance *dataplanev1.OpenStackDataPlaneNodeSet, hostName string) bool {
+	if instance.Spec.Decommission == nil {
+		return false
+	}
+	if slices.Contains(instance.GetDecommissionNodes(), hostName) {
+		return true
+	}
+	_, ok := instance.Status.DecommissionStatuses[hostName]
+	return ok
+}
+
+// getDecommissionHosts returns the sorted hostnames pending decommission and
+// the IPSets of those which have been removed from the NodeSet
+func getDecommissionHosts(ctx context.Context, helper *helper.Helper,
+	instance *dataplanev1.OpenStackDataPlaneNodeSet,
+) ([]string, map[string]*infranetworkv1.IPSet, error) {
+	ipSetList := &infranetworkv1.IPSetList{}
+	labelSelector := labels.GetLabels(instance,
+		labels.GetGroupLabel(NodeSetLabel), map[string]string{})
+	listOpts := []client.ListOption{
+		client.InNamespace(instance.Namespace),
+		client.MatchingLabels(labelSelector),
+	}
+	err := helper.GetClient().List(ctx, ipSetList, listOpts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	currentNodes := make(map[string]bool)
+	for _, node := range instance.Spec.Nodes {
+		currentNodes[node.HostName] = true
+	}
+
+	hostNames := []string{}
+	removedIPSets := make(map[string]*infranetworkv1.IPSet)
+	for idx := range ipSetList.Items {
+		ipSet := &ipSetList.Items[idx]
+		if !ipSet.DeletionTimestamp.IsZero() || currentNodes[ipSet.Name] {
+			continue
+		}
+		hostNames = append(hostNames, ipSet.Name)
+		removedIPSets[ipSet.Name] = ipSet
+	}
+	for _, hostName := range instance.GetDecommissionNodes() {
+		if currentNodes[hostName] {
+			hostNames = append(hostNames, hostName)
+		}
+	}
+	sort.Strings(hostNames)
+
+	return hostNames, removedIPSets, nil
+}
+
+// decommissionNode moves hostName through the decommission phases and
+// returns true once it is decommissioned
+func decommissionNode(ctx context.Context, helper *helper.Helper,
+	instance *dataplanev1.OpenStackDataPlaneNodeSet,
+	hostName string,
+	containerImages openstackv1.ContainerImages,
+) (bool, error) {
+	phase, ok := instance.Status.DecommissionStatuses[hostName]
+	if !ok {
+		// Keep a copy of the inventory as the node is about to be
+		// dropped from the NodeSet inventory
+		err := createDecommissionInventory(ctx, helper, instance, hostName)
+		if err != nil {
+			return false, err
+		}
+		phase = dataplanev1.DecommissionPhaseCleaningUp
+		if instance.Spec.Decommission.LiveMigrate {
+			phase = dataplanev1.DecommissionPhaseDraining
+		}
+	}
+
+	for {
+		instance.Status.DecommissionStatuses[hostName] = phase
+		switch phase {
+		case dataplanev1.DecommissionPhaseDraining:
+			done, err := EnsureControlPlaneJob(ctx, helper, instance, ControlPlaneActionDrain, hostName,
+				map[string]string{"DISABLE_REASON": fmt.Sprintf("Decommissioning from %s", instance.Name)})
+			if err != nil || !done {
+				return false, err
+			}
+			phase = dataplanev1.DecommissionPhaseCleaningUp
+		case dataplanev1.DecommissionPhaseCleaningUp:
+			done, err := runDecommissionServices(ctx, helper, instance, hostName, containerImages)
+			if err != nil || !done {
+				return false, err
+			}
+			phase = dataplanev1.DecommissionPhaseDecommissioned
+			if instance.Spec.Decommission.ControlPlaneCleanup {
+				phase = dataplanev1.DecommissionPhaseRemovingFromControlPlane
+			}
+		case dataplanev1.DecommissionPhaseRemovingFromControlPlane:
+			done, err := EnsureControlPlaneJob(ctx, helper, instance, ControlPlaneActionRemove, hostName, nil)
+			if err != nil || !done {
+				return false, err
+			}
+			phase = dataplanev1.DecommissionPhaseDecommissioned
+		default:
+			return true, nil
+		}
+	}
+}
+
+// runDecommissionServices runs the decommission services one after the
+// other against hostName and returns true once all of them succeeded
+func runDecommissionServices(ctx context.Context, helper *helper.Helper,
+	instance *dataplanev1.OpenStackDataPlaneNodeSet,
+	hostName string,
+	containerImages openstackv1.ContainerImages,
+) (bool, error) {
+	inventorySecretName := getDecommissionInventoryName(instance.Name, hostName)
+	err := helper.GetClient().Get(ctx, types.NamespacedName{
+		Namespace: instance.Namespace,
+		Name:      inventorySecretName,
+	}, &corev1.Secret{})
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			utils.LogForObject(helper,
+				fmt.Sprintf("No inventory found for %s, skipping decommission services", hostName), instance)
+			return true, nil
+		}
+		return false, err
+	}
+
+	for _, serviceName := range instance.Spec.Decommission.Services {
+		service, err := GetService(ctx, helper, serviceName)
+		if err != nil {
+			return false, err
+		}
+		done, err := ensureDecommissionExecution(ctx, helper, instance, &service,
+			hostName, inventorySecretName, containerImages)
+		if err != nil || !done {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// ensureDecommissionExecution runs service against hostName only, using the
+// inventory saved when the decommission started
+func ensureDecommissionExecution(ctx context.Context, helper *helper.Helper,
+	instance *dataplanev1.OpenStackDataPlaneNodeSet,
+	service *dataplanev1.OpenStackDataPlaneService,
+	hostName string,
+	inventorySecretName string,
+	containerImages openstackv1.ContainerImages,
+) (bool, error) {
+	shortName := strings.Split(hostName, ".")[0]
+	executionName := util.GetTruncatedName(fmt.Sprintf("%s-%s-%s", service.Name, instance.Name, shortName))
+	executionLabels := map[string]string{
+		"openstackdataplaneservice": service.Name,
+		"openstackdataplanenodeset": instance.Name,
+		"openstackdataplanenode":    shortName,
+	}
+
+	existingJob, err := util.GetAnsibleExecution(ctx, helper, instance, executionLabels)
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		return false, err
+	}
+	if existingJob != nil {
+		return isJobDone(existingJob)
+	}
+
+	aeeSpec := instance.GetAnsibleEESpec()
+	aeeSpec.AnsibleLimit = shortName
+	aeeSpec.OpenStackAnsibleEERunnerImage = service.Spec.OpenStackAnsibleEERunnerImage
+	if aeeSpec.OpenStackAnsibleEERunnerImage == "" && containerImages.AnsibleeeImage != nil {
+		aeeSpec.OpenStackAnsibleEERunnerImage = *containerImages.AnsibleeeImage
+	}
+	if instance.Status.DNSClusterAddresses != nil && instance.Status.CtlplaneSearchDomain != "" {
+		aeeSpec.DNSConfig = &corev1.PodDNSConfig{
+			Nameservers: instance.Status.DNSClusterAddresses,
+			Searches:    []string{instance.Status.CtlplaneSearchDomain},
+		}
+	}
+
+	ansibleEE := util.EEJob{
+		Name:               executionName,
+		Namespace:          instance.Namespace,
+		Labels:             executionLabels,
+		EnvConfigMapName:   "openstack-aee-default-env",
+		NetworkAttachments: aeeSpec.NetworkAttachments,
+		ServiceAccountName: aeeSpec.ServiceAccountName,
+		PlaybookContents:   service.Spec.PlaybookContents,
+		Playbook:           service.Spec.Playbook,
+		Role:               service.Spec.Role,
+		PreserveJobs:       true,
+		BackoffLimit:       ptr.To(controlPlaneJobBackoffLimit),
+		DNSConfig:          aeeSpec.DNSConfig,
+		Env:                aeeSpec.Env,
+	}
+
+	nadList := []networkv1.NetworkAttachmentDefinition{}
+	for _, netAtt := range ansibleEE.NetworkAttachments {
+		nad, err := nad.GetNADWithName(ctx, helper, netAtt, instance.Namespace)
+		if err != nil {
+			return false, err
+		}
+		if nad != nil {
+			nadList = append(nadList, *nad)
+		}
+	}
+	ansibleEE.Annotations, err = nad.EnsureNetworksAnnotation(nadList)
+	if err != nil {
+		return false, fmt.Errorf("failed to create NetworkAttachment annotation. Error: %w", err)
+	}
+
+	ansibleEE.SetJobTemplate(aeeSpec.JobTemplate.Merge(service.Spec.AnsibleEEJobTemplate))
+	ansibleEE.FormatAEECmdLineArguments(&aeeSpec)
+	ansibleEE.DetermineAeeImage(&aeeSpec)
+	serviceType := service.Spec.EDPMServiceType
+	if serviceType == "" {
+		serviceType = service.Name
+	}
+	ansibleEE.ExtraVars = map[string]json.RawMessage{
+		"edpm_override_hosts": json.RawMessage(fmt.Sprintf("%q", instance.Name)),
+		"edpm_service_type":   json.RawMessage(fmt.Sprintf("%q", serviceType)),
+	}
+
+	ansibleEEMounts := storage.VolMounts{}
+	util.SetAeeSSHMounts(instance, service,
+		map[string]string{instance.Name: instance.Spec.NodeTemplate.AnsibleSSHPrivateKeySecret},
+		&ansibleEEMounts)
+	util.SetAeeInvMounts(instance, service,
+		map[string]string{instance.Name: inventorySecretName},
+		&ansibleEEMounts)
+	ansibleEE.ExtraMounts = append(aeeSpec.ExtraMounts, []storage.VolMounts{ansibleEEMounts}...)
+
+	jobDef, err := ansibleEE.JobForOpenStackAnsibleEE(helper)
+	if err != nil {
+		return false, err
+	}
+
+	ansibleeeJob := job.NewJob(
+		jobDef,
+		ansibleEE.Name,
+		ansibleEE.PreserveJobs,
+		time.Duration(5)*time.Second,
+		"",
+	)
+	_, err = ansibleeeJob.DoJob(ctx, helper)
+	return false, err
+}
+
+// releaseNode deletes the IPSet and the decommission leftovers of hostName
+func releaseNode(ctx context.Context, helper *helper.Helper,
+	instance *dataplanev1.OpenStackDataPlaneNodeSet,
+	hostName string,
+	ipSet *infranetworkv1.IPSet,
+) error {
+	err := helper.GetClient().Delete(ctx, ipSet)
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		return err
+	}
+
+	err = deleteDecommissionInventory(ctx, helper, instance, hostName)
+	if err != nil {
+		return err
+	}
+
+	// Remove the decommission jobs so that the hostname can be reused
+	jobs := &batchv1.JobList{}
+	err = helper.GetClient().List(ctx, jobs,
+		client.InNamespace(instance.Namespace),
+		client.MatchingLabels{
+			"openstackdataplanenodeset": instance.Name,
+			"openstackdataplanenode":    strings.Split(hostName, ".")[0],
+		})
+	if err != nil {
+		return err
+	}
+	for idx := range jobs.Items {
+		err = helper.GetClient().Delete(ctx, &jobs.Items[idx],
+			client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if err != nil && !k8s_errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// getDecommissionInventoryName returns the name of the inventory secret used
+// to decommission hostName
+func getDecommissionInventoryName(nodeSetName string, hostName string) string {
+	return fmt.Sprintf("dataplanenodeset-%s-decommission-%s", nodeSetName, strings.Split(hostName, ".")[0])
+}
+
+// createDecommissionInventory copies the current NodeSet inventory into a
+// secret used by the decommission services of hostName
+func createDecommissionInventory(ctx context.Context, helper *helper.Helper,
+	instance *dataplanev1.OpenStackDataPlaneNodeSet,
+	hostName string,
+) error {
+	inventorySecret := &corev1.Secret{}
+	err := helper.GetClient().Get(ctx, types.NamespacedName{
+		Namespace: instance.Namespace,
+		Name:      fmt.Sprintf("dataplanenodeset-%s", instance.Name),
+	}, inventorySecret)
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			// The NodeSet was never deployed, nothing to clean up
+			return nil
+		}
+		return err
+	}
+
+	template := []utils.Template{
+		{
+			Name:         getDecommissionInventoryName(instance.Name, hostName),
+			Namespace:    instance.Namespace,
+			Type:         utils.TemplateTypeNone,
+			InstanceType: instance.Kind,
+			CustomData: map[string]string{
+				"inventory": string(inventorySecret.Data["inventory"]),
+			},
+			Labels: map[string]string{
+				"openstack.org/operator-name": "dataplane",
+				"openstackdataplanenodeset":   instance.Name,
+				"openstackdataplanenode":      strings.Split(hostName, ".")[0],
+			},
+		},
+	}
+	return secret.EnsureSecrets(ctx, helper, instance, template, nil)
+}
+
+// deleteDecommissionInventory deletes the inventory secret used to
+// decommission hostName
+func deleteDecommissionInventory(ctx context.Context, helper *helper.Helper,
+	instance *dataplanev1.OpenStackDataPlaneNodeSet,
+	hostName string,
+) error {
+	inventorySecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getDecommissionInventoryName(instance.Name, hostName),
+			Namespace: instance.Namespace,
+		},
+	}
+	err := helper.GetClient().Delete(ctx, inventorySecret)
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package deployment
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dataplanev1 "github.com/openstack-k8s-operators/openstack-operator/api/dataplane/v1beta1"
+)
+
+func TestIsNodeDecommissioning(t *testing.T) {
+	tests := []struct {
+		name         string
+		decommission *dataplanev1.DecommissionSpec
+		annotation   string
+		statuses     map[string]dataplanev1.DecommissionPhase
+		hostName     string
+		expected     bool
+	}{
+		{
+			name:       "decommission disabled ignores annotation",
+			annotation: "compute-0",
+			hostName:   "compute-0",
+			expected:   false,
+		},
+		{
+			name:         "node listed in annotation",
+			decommission: &dataplanev1.DecommissionSpec{},
+			annotation:   "compute-1, compute-0",
+			hostName:     "compute-0",
+			expected:     true,
+		},
+		{
+			name:         "node with decommission in progress",
+			decommission: &dataplanev1.DecommissionSpec{},
+			statuses: map[string]dataplanev1.DecommissionPhase{
+				"compute-0": dataplanev1.DecommissionPhaseCleaningUp,
+			},
+			hostName: "compute-0",
+			expected: true,
+		},
+		{
+			name:         "node not decommissioning",
+			decommission: &dataplanev1.DecommissionSpec{},
+			annotation:   "compute-1",
+			hostName:     "compute-0",
+			expected:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := &dataplanev1.OpenStackDataPlaneNodeSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "edpm-compute",
+					Annotations: map[string]string{
+						dataplanev1.DecommissionNodesAnnotation: tt.annotation,
+					},
+				},
+				Spec: dataplanev1.OpenStackDataPlaneNodeSetSpec{
+					Decommission: tt.decommission,
+				},
+				Status: dataplanev1.OpenStackDataPlaneNodeSetStatus{
+					DecommissionStatuses: tt.statuses,
+				},
+			}
+			assert.Equal(t, tt.expected, IsNodeDecommissioning(instance, tt.hostName))
+		})
+	}
+}
+
+func TestGetControlPlaneJobNameAndLabels(t *testing.T) {
+	name, labels := GetControlPlaneJobNameAndLabels(
+		ControlPlaneActionRemove, "edpm-compute", "compute-0.ctlplane.example.com")
+	assert.Equal(t, "remove-edpm-compute-compute-0", name)
+	assert.Equal(t, map[string]string{
+		"openstackdataplanenodeset": "edpm-compute",
+		"openstackdataplanenode":    "compute-0",
+		"openstackcontrolplanejob":  "remove",
+	}, labels)
+}
@@ -21,6 +21,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"maps"
 	"path"
 	"reflect"
 	"slices"
@@ -33,6 +34,7 @@ import (
 	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	apimachineryvalidation "k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/iancoleman/strcase"
@@ -56,10 +58,22 @@ type Deployer struct {
 	InventorySecrets            map[string]string
 	AnsibleSSHPrivateKeySecrets map[string]string
 	Version                     *openstackv1.OpenStackVersion
+	Batch                       *dataplaneutil.ExecutionBatch
+	Recorder                    record.EventRecorder
+	EventLimiter                *EventRateLimiter
 }
 
 // Deploy function encapsulating primary deloyment handling
 func (d *Deployer) Deploy(services []string) (*ctrl.Result, error) {
+	if d.Deployment.Spec.ExecutionMode == dataplanev1.ExecutionModeSingleJob {
+		return d.deploySingleJob(services)
+	}
+	return d.deployServices(services, services)
+}
+
+// deployServices deploys services in a job per service. allServices are all
+// the services deployed on the NodeSet.
+func (d *Deployer) deployServices(services []string, allServices []string) (*ctrl.Result, error) {
 	log := d.Helper.GetLogger()
 
 	var readyCondition condition.Type
@@ -72,6 +86,11 @@ func (d *Deployer) Deploy(services []string) (*ctrl.Result, error) {
 	// service deployment
 	aeeSpecMounts := make([]storage.VolMounts, len(d.AeeSpec.ExtraMounts))
 	copy(aeeSpecMounts, d.AeeSpec.ExtraMounts)
+	// A service starts once all the services before it are ready, unless
+	// it shares the parallel group of the services that are not ready yet
+	var pending bool
+	var pendingErr error
+	var parallelGroup string
 	// Deploy the composable services
 	for _, service := range services {
 		deployName = service
@@ -94,10 +113,18 @@ func (d *Deployer) Deploy(services []string) (*ctrl.Result, error) {
 			return &ctrl.Result{}, err
 		}
 
+		if pending && (parallelGroup == "" || foundService.Spec.ParallelGroup != parallelGroup) {
+			return &ctrl.Result{}, pendingErr
+		}
+		parallelGroup = foundService.Spec.ParallelGroup
+
 		containerImages := dataplaneutil.GetContainerImages(d.Version)
 		if containerImages.AnsibleeeImage != nil {
 			d.AeeSpec.OpenStackAnsibleEERunnerImage = *containerImages.AnsibleeeImage
 		}
+		if containerImages.OrasImage != nil {
+			d.AeeSpec.PlaybookSourceImage = *containerImages.OrasImage
+		}
 		if len(foundService.Spec.OpenStackAnsibleEERunnerImage) > 0 {
 			d.AeeSpec.OpenStackAnsibleEERunnerImage = foundService.Spec.OpenStackAnsibleEERunnerImage
 		}
@@ -120,7 +147,7 @@ func (d *Deployer) Deploy(services []string) (*ctrl.Result, error) {
 
 		// Add certMounts
 		if foundService.Spec.AddCertMounts {
-			d.AeeSpec, err = d.addCertMounts(services)
+			d.AeeSpec, err = d.addCertMounts(allServices)
 			if err != nil {
 				nsConditions.Set(condition.FalseCondition(
 					readyCondition,
@@ -161,23 +188,25 @@ func (d *Deployer) Deploy(services []string) (*ctrl.Result, error) {
 		nsConditions = d.Status.NodeSetConditions[d.NodeSet.Name]
 		if err != nil || !nsConditions.IsTrue(readyCondition) {
 			log.Info(fmt.Sprintf("Condition %s not ready", readyCondition))
-			return &ctrl.Result{}, err
+			if parallelGroup == "" {
+				return &ctrl.Result{}, err
+			}
+			// Keep starting the other services of the parallel group
+			pending = true
+			if pendingErr == nil {
+				pendingErr = err
+			}
+			continue
 		}
 
 		log.Info(fmt.Sprintf("Condition %s ready", readyCondition))
 
-		// (TODO) Only considers the container image values from the Version
-		// for the time being. Can be expanded later to look at the actual
-		// values used from the inventory, etc.
-		if d.Version != nil {
-			vContainerImages := reflect.ValueOf(d.Version.Status.ContainerImages)
-			for _, cif := range foundService.Spec.ContainerImageFields {
-				d.Deployment.Status.ContainerImages[cif] = reflect.Indirect(vContainerImages.FieldByName(cif)).String()
-			}
-		}
-
+		d.storeContainerImages(foundService)
 	}
 
+	if pending {
+		return &ctrl.Result{}, pendingErr
+	}
 	return nil, nil
 }
 
@@ -211,10 +240,12 @@ func (d *Deployer) ConditionalDeploy(
 
 	}
 
-	var ansibleCondition batchv1.JobCondition
 	if nsConditions.IsFalse(readyCondition) {
 		var ansibleJob *batchv1.Job
 		_, labelSelector := dataplaneutil.GetAnsibleExecutionNameAndLabels(&foundService, d.Deployment.Name, d.NodeSet.Name)
+		if d.Batch != nil {
+			_, labelSelector = dataplaneutil.GetBatchAnsibleExecutionNameAndLabels(&foundService, d.Deployment.Name, d.NodeSet.Name, d.Batch.Index)
+		}
 		ansibleJob, err = dataplaneutil.GetAnsibleExecution(d.Ctx, d.Helper, d.Deployment, labelSelector)
 		if err != nil {
 			// Return nil if we don't have AnsibleEE available yet
@@ -231,32 +262,37 @@ func (d *Deployer) ConditionalDeploy(
 				err.Error()))
 		}
 		if ansibleJob.Status.Succeeded > 0 {
+			d.recordJobSucceeded(ansibleJob)
 			d.storeExecutionSummary(ansibleJob)
+			d.storePlaybookSourceRevisions(ansibleJob, []dataplanev1.OpenStackDataPlaneService{foundService})
+			d.storeExecutionProgress(ansibleJob)
+			d.clearExecutionProgress(ansibleJob)
 			log.Info(fmt.Sprintf("Condition %s ready", readyCondition))
 			nsConditions.Set(condition.TrueCondition(
 				readyCondition,
 				"%s", readyMessage))
 		} else if ansibleJob.Status.Failed > *ansibleJob.Spec.BackoffLimit {
-			errorMsg := fmt.Sprintf("execution.name %s execution.namespace %s failed pods: %d", ansibleJob.Name, ansibleJob.Namespace, ansibleJob.Status.Failed)
-			for _, condition := range ansibleJob.Status.Conditions {
-				if condition.Type == batchv1.JobFailed {
-					ansibleCondition = condition
-				}
-			}
-			if ansibleCondition.Reason == condition.JobReasonBackoffLimitExceeded {
-				errorMsg = fmt.Sprintf("backoff limit reached for execution.name %s execution.namespace %s execution.condition.message: %s", ansibleJob.Name, ansibleJob.Namespace, ansibleCondition.Message)
+			summary := d.storeExecutionSummary(ansibleJob)
+			d.storePlaybookSourceRevisions(ansibleJob, []dataplanev1.OpenStackDataPlaneService{foundService})
+			d.storeExecutionProgress(ansibleJob)
+			d.clearExecutionProgress(ansibleJob)
+			var reason condition.Reason
+			reason, err = getAnsibleJobError(ansibleJob, summary)
+			// the failure is recorded once, the condition keeps the error
+			if cond := nsConditions.Get(readyCondition); cond == nil || cond.Severity != condition.SeverityError {
+				d.recordJobFailed(ansibleJob, err)
 			}
-			d.storeExecutionSummary(ansibleJob)
 			log.Info(fmt.Sprintf("Condition %s error", readyCondition))
-			err = fmt.Errorf("%s", errorMsg)
 			nsConditions.Set(condition.FalseCondition(
 				readyCondition,
-				condition.Reason(ansibleCondition.Reason),
+				reason,
 				condition.SeverityError,
 				readyErrorMessage,
 				err.Error()))
 		} else {
 			log.Info(fmt.Sprintf("AnsibleEE job is not yet completed: Execution: %s, Active pods: %d, Failed pods: %d", ansibleJob.Name, ansibleJob.Status.Active, ansibleJob.Status.Failed))
+			d.recordJobStarted(ansibleJob)
+			d.storeExecutionProgress(ansibleJob)
 			nsConditions.Set(condition.FalseCondition(
 				readyCondition,
 				condition.RequestedReason,
@@ -269,22 +305,102 @@ func (d *Deployer) ConditionalDeploy(
 	return err
 }
 
+// getAnsibleJobError returns the reason and the error of a failed ansible job,
+// with the first failed task of its summary if any
+func getAnsibleJobError(
+	ansibleJob *batchv1.Job,
+	summary *dataplanev1.AnsibleExecutionSummary,
+) (condition.Reason, error) {
+	var ansibleCondition batchv1.JobCondition
+	errorMsg := fmt.Sprintf("execution.name %s execution.namespace %s failed pods: %d", ansibleJob.Name, ansibleJob.Namespace, ansibleJob.Status.Failed)
+	for _, condition := range ansibleJob.Status.Conditions {
+		if condition.Type == batchv1.JobFailed {
+			ansibleCondition = condition
+		}
+	}
+	if ansibleCondition.Reason == condition.JobReasonBackoffLimitExceeded {
+		errorMsg = fmt.Sprintf("backoff limit reached for execution.name %s execution.namespace %s execution.condition.message: %s", ansibleJob.Name, ansibleJob.Namespace, ansibleCondition.Message)
+	}
+	if summary != nil && summary.TaskFailures != nil && len(*summary.TaskFailures) > 0 {
+		failure := (*summary.TaskFailures)[0]
+		errorMsg = fmt.Sprintf("%s, task %q failed on host %s: %s",
+			errorMsg, failure.Task, failure.Host, failure.Message)
+	}
+	return condition.Reason(ansibleCondition.Reason), fmt.Errorf("%s", errorMsg)
+}
+
+// storeContainerImages records the container images deployed by a service
+// in the deployment status
+func (d *Deployer) storeContainerImages(service dataplanev1.OpenStackDataPlaneService) {
+	// (TODO) Only considers the container image values from the Version
+	// for the time being. Can be expanded later to look at the actual
+	// values used from the inventory, etc.
+	if d.Version != nil {
+		vContainerImages := reflect.ValueOf(d.Version.Status.ContainerImages)
+		for _, cif := range service.Spec.ContainerImageFields {
+			d.Deployment.Status.ContainerImages[cif] = reflect.Indirect(vContainerImages.FieldByName(cif)).String()
+		}
+	}
+}
+
 // storeExecutionSummary fetches and stores the ansible execution summary for a
-// completed or failed Job into the deployment status.
-func (d *Deployer) storeExecutionSummary(ansibleJob *batchv1.Job) {
+// completed or failed Job into the deployment status, and returns it. The task
+// results are added to the summary by the Job itself, from the job events of
+// ansible-runner.
+func (d *Deployer) storeExecutionSummary(ansibleJob *batchv1.Job) *dataplanev1.AnsibleExecutionSummary {
 	log := d.Helper.GetLogger()
 	summary, err := dataplaneutil.GetAnsibleExecutionSummary(d.Ctx, d.Helper, ansibleJob)
 	if err != nil {
 		log.Error(err, "Unable to get ansible execution summary", "execution", ansibleJob.Name)
-		return
+		return nil
 	}
 	if summary == nil {
-		return
+		return nil
 	}
 	if d.Status.AnsibleExecutionSummaries == nil {
 		d.Status.AnsibleExecutionSummaries = make(map[string]dataplanev1.AnsibleExecutionSummary)
 	}
 	d.Status.AnsibleExecutionSummaries[ansibleJob.Name] = *summary
+	return summary
+}
+
+// storePlaybookSourceRevisions records the revisions of the playbook sources
+// of services fetched by a finished Job into the deployment status, and into
+// its ConfigMap hashes so they are recorded even if the deployment fails
+func (d *Deployer) storePlaybookSourceRevisions(
+	ansibleJob *batchv1.Job,
+	services []dataplanev1.OpenStackDataPlaneService,
+) {
+	log := d.Helper.GetLogger()
+	serviceNames := []string{}
+	for _, service := range services {
+		if service.Spec.PlaybookSource != nil {
+			serviceNames = append(serviceNames, service.Name)
+		}
+	}
+	if len(serviceNames) == 0 {
+		return
+	}
+
+	revisions, err := dataplaneutil.GetPlaybookSourceRevisions(d.Ctx, d.Helper, ansibleJob, serviceNames)
+	if err != nil {
+		log.Error(err, "Unable to get playbook source revisions", "execution", ansibleJob.Name)
+		return
+	}
+	if len(revisions) == 0 {
+		return
+	}
+	if d.Status.PlaybookSourceRevisions == nil {
+		d.Status.PlaybookSourceRevisions = make(map[string]map[string]string)
+	}
+	if d.Status.PlaybookSourceRevisions[d.NodeSet.Name] == nil {
+		d.Status.PlaybookSourceRevisions[d.NodeSet.Name] = make(map[string]string)
+	}
+	maps.Copy(d.Status.PlaybookSourceRevisions[d.NodeSet.Name], revisions)
+	if d.Status.ConfigMapHashes != nil {
+		GetPlaybookSourceHashes(
+			map[string]map[string]string{d.NodeSet.Name: revisions}, d.Status.ConfigMapHashes)
+	}
 }
 
 // addCertMounts adds the cert mounts to the aeeSpec for the install-certs service
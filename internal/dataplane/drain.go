@@ -0,0 +1,167 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployment
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	dataplanev1 "github.com/openstack-k8s-operators/openstack-operator/api/dataplane/v1beta1"
+	dataplaneutil "github.com/openstack-k8s-operators/openstack-operator/internal/dataplane/util"
+)
+
+// DeployInBatches deploys the services on the hosts of the NodeSet one batch
+// at a time. The hosts of a batch are drained before the services run, and
+// their nova-compute service is enabled again once the services succeeded.
+// The service conditions track the current batch, they are reset once a batch
+// is updated so that the next one runs its own jobs.
+func (d *Deployer) DeployInBatches(services []string) (*ctrl.Result, error) {
+	log := d.Helper.GetLogger()
+
+	batches := GetUpdateBatches(d.NodeSet, d.Deployment.Spec.DrainBeforeUpdate.BatchSize)
+	drainEnv := map[string]string{
+		"DISABLE_REASON": fmt.Sprintf("Update by OpenStackDataPlaneDeployment %s", d.Deployment.Name),
+	}
+
+	for idx := d.Status.UpdatedBatches[d.NodeSet.Name]; idx < len(batches); idx++ {
+		hosts := batches[idx]
+		d.Batch = &dataplaneutil.ExecutionBatch{Index: idx, Hosts: hosts}
+
+		done, err := d.ensureBatchControlPlaneJobs(ControlPlaneActionDrain, hosts, drainEnv)
+		if err != nil {
+			d.setDrainError(err)
+			return &ctrl.Result{}, err
+		}
+		if !done {
+			log.Info("Draining hosts", "nodeSet", d.NodeSet.Name, "hosts", hosts)
+			d.setDrainWaiting("draining", hosts, idx, len(batches))
+			return &ctrl.Result{}, nil
+		}
+
+		d.setDrainWaiting("updating", hosts, idx, len(batches))
+		result, err := d.Deploy(services)
+		if err != nil || result != nil {
+			return result, err
+		}
+
+		done, err = d.ensureBatchControlPlaneJobs(ControlPlaneActionEnable, hosts, nil)
+		if err != nil {
+			d.setDrainError(err)
+			return &ctrl.Result{}, err
+		}
+		if !done {
+			log.Info("Enabling hosts", "nodeSet", d.NodeSet.Name, "hosts", hosts)
+			d.setDrainWaiting("enabling", hosts, idx, len(batches))
+			return &ctrl.Result{}, nil
+		}
+		d.Status.UpdatedBatches[d.NodeSet.Name] = idx + 1
+		if idx+1 < len(batches) {
+			d.resetServiceConditions(services)
+		}
+	}
+	d.Batch = nil
+
+	nsConditions := d.Status.NodeSetConditions[d.NodeSet.Name]
+	nsConditions.MarkTrue(dataplanev1.NodeSetDrainReadyCondition, dataplanev1.NodeSetDrainReadyMessage)
+	d.Status.NodeSetConditions[d.NodeSet.Name] = nsConditions
+
+	return nil, nil
+}
+
+// GetUpdateBatches splits the hosts of the NodeSet inventory in batches of
+// batchSize hosts. Hosts are named as in the inventory.
+func GetUpdateBatches(nodeSet *dataplanev1.OpenStackDataPlaneNodeSet, batchSize int) [][]string {
+	if batchSize < 1 {
+		batchSize = 1
+	}
+
+	hosts := []string{}
+	for _, node := range nodeSet.Spec.Nodes {
+		if IsNodeDecommissioning(nodeSet, node.HostName) || IsNodeInMaintenance(node) {
+			continue
+		}
+		hosts = append(hosts, strings.Split(node.HostName, ".")[0])
+	}
+	sort.Strings(hosts)
+
+	batches := [][]string{}
+	for start := 0; start < len(hosts); start += batchSize {
+		end := min(start+batchSize, len(hosts))
+		batches = append(batches, hosts[start:end])
+	}
+	return batches
+}
+
+// ensureBatchControlPlaneJobs runs action for all the hosts of a batch and
+// returns true once all of them have succeeded
+func (d *Deployer) ensureBatchControlPlaneJobs(
+	action ControlPlaneAction,
+	hosts []string,
+	envVars map[string]string,
+) (bool, error) {
+	allDone := true
+	for _, host := range hosts {
+		done, err := EnsureControlPlaneJob(d.Ctx, d.Helper, d.Deployment, action, host, envVars)
+		if err != nil {
+			return false, err
+		}
+		allDone = allDone && done
+	}
+	return allDone, nil
+}
+
+// resetServiceConditions sets the Service*DeploymentReady conditions of the
+// services back to Unknown, so that they are deployed again
+func (d *Deployer) resetServiceConditions(services []string) {
+	nsConditions := d.Status.NodeSetConditions[d.NodeSet.Name]
+	for _, service := range services {
+		nsConditions.Set(condition.UnknownCondition(
+			serviceReadyCondition(service),
+			condition.InitReason,
+			condition.InitReason))
+	}
+	d.Status.NodeSetConditions[d.NodeSet.Name] = nsConditions
+}
+
+func (d *Deployer) setDrainWaiting(step string, hosts []string, idx int, total int) {
+	nsConditions := d.Status.NodeSetConditions[d.NodeSet.Name]
+	nsConditions.Set(condition.FalseCondition(
+		dataplanev1.NodeSetDrainReadyCondition,
+		condition.RequestedReason,
+		condition.SeverityInfo,
+		dataplanev1.NodeSetDrainReadyWaitingMessage,
+		step,
+		strings.Join(hosts, ","),
+		idx+1,
+		total))
+	d.Status.NodeSetConditions[d.NodeSet.Name] = nsConditions
+}
+
+func (d *Deployer) setDrainError(err error) {
+	nsConditions := d.Status.NodeSetConditions[d.NodeSet.Name]
+	nsConditions.Set(condition.FalseCondition(
+		dataplanev1.NodeSetDrainReadyCondition,
+		condition.ErrorReason,
+		condition.SeverityError,
+		dataplanev1.NodeSetDrainErrorMessage,
+		err.Error()))
+	d.Status.NodeSetConditions[d.NodeSet.Name] = nsConditions
+}
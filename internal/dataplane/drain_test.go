@@ -0,0 +1,81 @@
+package deployment
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	dataplanev1 "github.com/openstack-k8s-operators/openstack-operator/api/dataplane/v1beta1"
+)
+
+func TestGetUpdateBatches(t *testing.T) {
+	nodeSet := &dataplanev1.OpenStackDataPlaneNodeSet{
+		Spec: dataplanev1.OpenStackDataPlaneNodeSetSpec{
+			Nodes: map[string]dataplanev1.NodeSection{
+				"compute-2": {HostName: "compute-2.example.com"},
+				"compute-0": {HostName: "compute-0.example.com"},
+				"compute-1": {HostName: "compute-1"},
+				"compute-3": {
+					HostName:    "compute-3",
+					Maintenance: &dataplanev1.MaintenanceSpec{},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		batchSize int
+		expected  [][]string
+	}{
+		{
+			name:      "one host per batch",
+			batchSize: 1,
+			expected:  [][]string{{"compute-0"}, {"compute-1"}, {"compute-2"}},
+		},
+		{
+			name:      "last batch is smaller",
+			batchSize: 2,
+			expected:  [][]string{{"compute-0", "compute-1"}, {"compute-2"}},
+		},
+		{
+			name:      "unset batch size defaults to one",
+			batchSize: 0,
+			expected:  [][]string{{"compute-0"}, {"compute-1"}, {"compute-2"}},
+		},
+		{
+			name:      "single batch",
+			batchSize: 5,
+			expected:  [][]string{{"compute-0", "compute-1", "compute-2"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, GetUpdateBatches(nodeSet, tt.batchSize))
+		})
+	}
+}
+
+func TestResetServiceConditions(t *testing.T) {
+	nodeSet := &dataplanev1.OpenStackDataPlaneNodeSet{}
+	nodeSet.Name = "edpm-compute"
+	nsConditions := condition.Conditions{}
+	nsConditions.Set(condition.TrueCondition(serviceReadyCondition("install-os"), "ready"))
+	nsConditions.Set(condition.TrueCondition(serviceReadyCondition("update"), "ready"))
+	nsConditions.Set(condition.TrueCondition(dataplanev1.NodeSetDrainReadyCondition, "ready"))
+	d := &Deployer{
+		NodeSet: nodeSet,
+		Status: &dataplanev1.OpenStackDataPlaneDeploymentStatus{
+			NodeSetConditions: map[string]condition.Conditions{nodeSet.Name: nsConditions},
+		},
+	}
+
+	d.resetServiceConditions([]string{"install-os", "update"})
+
+	nsConditions = d.Status.NodeSetConditions[nodeSet.Name]
+	assert.True(t, nsConditions.IsUnknown(serviceReadyCondition("install-os")))
+	assert.True(t, nsConditions.IsUnknown(serviceReadyCondition("update")))
+	assert.True(t, nsConditions.IsTrue(dataplanev1.NodeSetDrainReadyCondition))
+}
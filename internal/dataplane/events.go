@@ -0,0 +1,157 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployment
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// AnsibleEventBurst is the number of ansible events recorded at once on
+	// an object
+	AnsibleEventBurst = 10
+	// AnsibleEventInterval is the interval at which one more ansible event
+	// can be recorded on an object once the burst is used
+	AnsibleEventInterval = 30 * time.Second
+)
+
+// EventRateLimiter limits the rate of the ansible events recorded on each
+// object with a token bucket per object, and counts the events left out
+type EventRateLimiter struct {
+	mu      sync.Mutex
+	clock   clock.PassiveClock
+	objects map[types.UID]*objectEventLimiter
+}
+
+// objectEventLimiter is the token bucket of an object
+type objectEventLimiter struct {
+	limiter  flowcontrol.PassiveRateLimiter
+	lastUsed time.Time
+	skipped  int
+}
+
+// NewEventRateLimiter returns an EventRateLimiter recording AnsibleEventBurst
+// events at once on an object, then one every AnsibleEventInterval
+func NewEventRateLimiter() *EventRateLimiter {
+	return newEventRateLimiterWithClock(clock.RealClock{})
+}
+
+func newEventRateLimiterWithClock(c clock.PassiveClock) *EventRateLimiter {
+	return &EventRateLimiter{
+		clock:   c,
+		objects: map[types.UID]*objectEventLimiter{},
+	}
+}
+
+// Allow returns true when an event can be recorded on obj, with the number of
+// events of obj left out since the last recorded one. Events are always
+// allowed by a nil EventRateLimiter.
+func (l *EventRateLimiter) Allow(obj client.Object) (bool, int) {
+	if l == nil {
+		return true, 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock.Now()
+	// the token bucket of an object that had time to fill up again is the
+	// same as a new one
+	for uid, objLimiter := range l.objects {
+		if objLimiter.skipped == 0 && now.Sub(objLimiter.lastUsed) > AnsibleEventBurst*AnsibleEventInterval {
+			delete(l.objects, uid)
+		}
+	}
+
+	objLimiter, ok := l.objects[obj.GetUID()]
+	if !ok {
+		objLimiter = &objectEventLimiter{
+			limiter: flowcontrol.NewTokenBucketPassiveRateLimiterWithClock(
+				float32(time.Second)/float32(AnsibleEventInterval), AnsibleEventBurst, l.clock),
+		}
+		l.objects[obj.GetUID()] = objLimiter
+	}
+	objLimiter.lastUsed = now
+	if !objLimiter.limiter.TryAccept() {
+		objLimiter.skipped++
+		return false, 0
+	}
+	skipped := objLimiter.skipped
+	objLimiter.skipped = 0
+	return true, skipped
+}
+
+// recordAnsibleEvent records an ansible event on the deployment and on the
+// NodeSet within the rate limit of each of them. The first event recorded
+// after some were left out is preceded by an AnsibleEventsSkipped event.
+func (d *Deployer) recordAnsibleEvent(eventType string, reason string, message string) {
+	if d.Recorder == nil {
+		return
+	}
+	for _, obj := range []client.Object{d.Deployment, d.NodeSet} {
+		allowed, skipped := d.EventLimiter.Allow(obj)
+		if !allowed {
+			continue
+		}
+		if skipped > 0 {
+			d.Recorder.Eventf(obj, corev1.EventTypeWarning, "AnsibleEventsSkipped",
+				"%d ansible events were not recorded", skipped)
+		}
+		d.Recorder.Event(obj, eventType, reason, message)
+	}
+}
+
+// recordJobEvent records an event about an AnsibleEE job on the deployment
+// and on the NodeSet. Job events are few and not rate limited.
+func (d *Deployer) recordJobEvent(eventType string, reason string, message string) {
+	if d.Recorder == nil {
+		return
+	}
+	for _, obj := range []client.Object{d.Deployment, d.NodeSet} {
+		d.Recorder.Event(obj, eventType, reason, message)
+	}
+}
+
+// recordJobStarted records an AnsibleJobStarted event the first time a job is
+// seen running, before its progress is stored in the deployment status
+func (d *Deployer) recordJobStarted(ansibleJob *batchv1.Job) {
+	if _, ok := d.Status.AnsibleExecutionProgress[ansibleJob.Name]; ok || ansibleJob.Status.StartTime == nil {
+		return
+	}
+	d.recordJobEvent(corev1.EventTypeNormal, "AnsibleJobStarted",
+		fmt.Sprintf("%s: job started", ansibleJob.Name))
+}
+
+// recordJobSucceeded records an AnsibleJobSucceeded event
+func (d *Deployer) recordJobSucceeded(ansibleJob *batchv1.Job) {
+	d.recordJobEvent(corev1.EventTypeNormal, "AnsibleJobSucceeded",
+		fmt.Sprintf("%s: job succeeded", ansibleJob.Name))
+}
+
+// recordJobFailed records an AnsibleJobFailed event with the error of the job
+func (d *Deployer) recordJobFailed(ansibleJob *batchv1.Job, err error) {
+	d.recordJobEvent(corev1.EventTypeWarning, "AnsibleJobFailed",
+		fmt.Sprintf("%s: job failed: %s", ansibleJob.Name, err))
+}
@@ -0,0 +1,87 @@
+package deployment
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	dataplanev1 "github.com/openstack-k8s-operators/openstack-operator/api/dataplane/v1beta1"
+)
+
+func TestEventRateLimiter(t *testing.T) {
+	fakeClock := clocktesting.NewFakePassiveClock(time.Now())
+	limiter := newEventRateLimiterWithClock(fakeClock)
+	deployment := &dataplanev1.OpenStackDataPlaneDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "edpm-deployment", UID: "deployment-uid"},
+	}
+	nodeSet := &dataplanev1.OpenStackDataPlaneNodeSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "edpm-compute", UID: "nodeset-uid"},
+	}
+
+	// The burst is recorded, then the events are left out
+	for range AnsibleEventBurst {
+		allowed, skipped := limiter.Allow(deployment)
+		assert.True(t, allowed)
+		assert.Equal(t, 0, skipped)
+	}
+	for range 3 {
+		allowed, _ := limiter.Allow(deployment)
+		assert.False(t, allowed)
+	}
+
+	// The rate is limited per object
+	allowed, _ := limiter.Allow(nodeSet)
+	assert.True(t, allowed)
+
+	// One more event is recorded each interval, with the number of events
+	// left out before it
+	fakeClock.SetTime(fakeClock.Now().Add(AnsibleEventInterval))
+	allowed, skipped := limiter.Allow(deployment)
+	assert.True(t, allowed)
+	assert.Equal(t, 3, skipped)
+	allowed, _ = limiter.Allow(deployment)
+	assert.False(t, allowed)
+
+	// The token buckets filled up again are dropped
+	fakeClock.SetTime(fakeClock.Now().Add(2 * AnsibleEventBurst * AnsibleEventInterval))
+	allowed, skipped = limiter.Allow(deployment)
+	assert.True(t, allowed)
+	assert.Equal(t, 1, skipped)
+	assert.Len(t, limiter.objects, 1)
+
+	// A nil limiter doesn't limit the rate
+	var nilLimiter *EventRateLimiter
+	allowed, _ = nilLimiter.Allow(deployment)
+	assert.True(t, allowed)
+}
+
+func TestRecordJobEvents(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	d := &Deployer{
+		Deployment: &dataplanev1.OpenStackDataPlaneDeployment{},
+		NodeSet:    &dataplanev1.OpenStackDataPlaneNodeSet{},
+		Status:     &dataplanev1.OpenStackDataPlaneDeploymentStatus{},
+		Recorder:   recorder,
+	}
+	job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "bootstrap-edpm-deployment-edpm-compute"}}
+
+	// The start is recorded once the job started, and until its progress is
+	// stored
+	d.recordJobStarted(job)
+	assert.Empty(t, recorder.Events)
+	job.Status.StartTime = &metav1.Time{Time: time.Now()}
+	d.recordJobStarted(job)
+	assert.Equal(t, "Normal AnsibleJobStarted bootstrap-edpm-deployment-edpm-compute: job started", <-recorder.Events)
+	assert.Equal(t, "Normal AnsibleJobStarted bootstrap-edpm-deployment-edpm-compute: job started", <-recorder.Events)
+	d.Status.AnsibleExecutionProgress = map[string]dataplanev1.AnsibleExecutionProgress{job.Name: {}}
+	d.recordJobStarted(job)
+	assert.Empty(t, recorder.Events)
+
+	d.recordJobSucceeded(job)
+	assert.Equal(t, "Normal AnsibleJobSucceeded bootstrap-edpm-deployment-edpm-compute: job succeeded", <-recorder.Events)
+}
@@ -18,6 +18,7 @@ package deployment
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/openstack-k8s-operators/lib-common/modules/common/configmap"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
@@ -101,6 +102,20 @@ func GetDeploymentHashesForService(
 	return nil
 }
 
+// GetPlaybookSourceHashes - Add the revisions of the playbook sources fetched
+// per NodeSet and service to the ConfigMap hashes. They are keyed by
+// playbookSource/<nodeSet>/<service>, which can't be the name of a ConfigMap.
+func GetPlaybookSourceHashes(
+	revisions map[string]map[string]string,
+	configMapHashes map[string]string,
+) {
+	for nodeSet, serviceRevisions := range revisions {
+		for service, revision := range serviceRevisions {
+			configMapHashes[fmt.Sprintf("playbookSource/%s/%s", nodeSet, service)] = revision
+		}
+	}
+}
+
 // ProcessAnsibleVarsFrom computes hashes for ConfigMaps and Secrets
 // referenced in the NodeSet's AnsibleVarsFrom field (both NodeTemplate and
 // individual Nodes)
@@ -116,12 +116,61 @@ func GenerateNodeSetInventory(ctx context.Context, helper *helper.Helper,
 	containerImages openstackv1.ContainerImages,
 	netServiceNetMap map[string]string,
 ) (string, error) {
+	inventory, err := buildNodeSetInventory(ctx, helper, instance,
+		allIPSets, dnsAddresses, containerImages, netServiceNetMap)
+	if err != nil {
+		return "", err
+	}
+
+	invData, err := inventory.MarshalYAML()
+	if err != nil {
+		utils.LogErrorForObject(helper, err, "Could not parse NodeSet inventory", instance)
+		return "", err
+	}
+	secretData := map[string]string{
+		"inventory": string(invData),
+	}
+	secretName := fmt.Sprintf("dataplanenodeset-%s", instance.Name)
+	labels := map[string]string{
+		"openstack.org/operator-name": "dataplane",
+		"openstackdataplanenodeset":   instance.Name,
+		"inventory":                   "true",
+	}
+	for key, val := range instance.Labels {
+		labels[key] = val
+	}
+	template := []utils.Template{
+		// Secret
+		{
+			Name:         secretName,
+			Namespace:    instance.Namespace,
+			Type:         utils.TemplateTypeNone,
+			InstanceType: instance.Kind,
+			CustomData:   secretData,
+			Labels:       labels,
+		},
+	}
+	err = secret.EnsureSecrets(ctx, helper, instance, template, nil)
+	if err == nil {
+		instance.Status.InventorySecretName = secretName
+	}
+	return secretName, err
+}
+
+// buildNodeSetInventory returns the inventory of the NodeSet, with a group
+// named after the NodeSet holding its nodes
+func buildNodeSetInventory(ctx context.Context, helper *helper.Helper,
+	instance *dataplanev1.OpenStackDataPlaneNodeSet,
+	allIPSets map[string]infranetworkv1.IPSet, dnsAddresses []string,
+	containerImages openstackv1.ContainerImages,
+	netServiceNetMap map[string]string,
+) (ansible.Inventory, error) {
 	inventory := ansible.MakeInventory()
 	nodeSetGroup := inventory.AddGroup(instance.Name)
 	groupVars, err := getAnsibleVarsFrom(ctx, helper, instance.Namespace, &instance.Spec.NodeTemplate.Ansible)
 	if err != nil {
 		utils.LogErrorForObject(helper, err, "could not get ansible group vars from configMap/secret", instance)
-		return "", err
+		return inventory, err
 	}
 	for k, v := range groupVars {
 		nodeSetGroup.Vars[k] = v
@@ -130,7 +179,7 @@ func GenerateNodeSetInventory(ctx context.Context, helper *helper.Helper,
 		&nodeSetGroup, containerImages, netServiceNetMap)
 	if err != nil {
 		utils.LogErrorForObject(helper, err, "Could not resolve ansible group vars", instance)
-		return "", err
+		return inventory, err
 	}
 
 	// add the NodeSet name variable
@@ -138,7 +187,7 @@ func GenerateNodeSetInventory(ctx context.Context, helper *helper.Helper,
 
 	hasMirrorRegistries, err := util.HasMirrorRegistries(ctx, helper)
 	if err != nil {
-		return "", err
+		return inventory, err
 	}
 
 	if hasMirrorRegistries {
@@ -150,7 +199,7 @@ func GenerateNodeSetInventory(ctx context.Context, helper *helper.Helper,
 				helper.GetLogger().Info("MachineConfig CRD not available; registry config will not be propagated",
 					"error", err.Error())
 			} else {
-				return "", fmt.Errorf("failed to get MachineConfig registry configuration: %w", err)
+				return inventory, fmt.Errorf("failed to get MachineConfig registry configuration: %w", err)
 			}
 		} else {
 			helper.GetLogger().Info("Mirror registries detected via IDMS/ICSP. Using OCP registry configuration.")
@@ -160,12 +209,12 @@ func GenerateNodeSetInventory(ctx context.Context, helper *helper.Helper,
 
 		mirrorScopes, sourceByMirror, err := util.GetMirrorRegistryScopes(ctx, helper)
 		if err != nil {
-			return "", fmt.Errorf("failed to get mirror registries for sigstore verification: %w", err)
+			return inventory, fmt.Errorf("failed to get mirror registries for sigstore verification: %w", err)
 		}
 
 		sigstorePolicy, err := util.GetSigstoreImagePolicy(ctx, helper, mirrorScopes, sourceByMirror)
 		if err != nil {
-			return "", fmt.Errorf("failed to get ClusterImagePolicy for sigstore verification: %w", err)
+			return inventory, fmt.Errorf("failed to get ClusterImagePolicy for sigstore verification: %w", err)
 		}
 		if sigstorePolicy != nil {
 			nodeSetGroup.Vars["edpm_container_signature_verification"] = true
@@ -201,11 +250,15 @@ func GenerateNodeSetInventory(ctx context.Context, helper *helper.Helper,
 	nodeSetGroup.Vars["ansible_ssh_private_key_file"] = fmt.Sprintf("/runner/env/ssh_key/ssh_key_%s", instance.Name)
 
 	for _, node := range instance.Spec.Nodes {
+		// Decommissioning and cordoned nodes are not deployed anymore
+		if IsNodeDecommissioning(instance, node.HostName) || IsNodeInMaintenance(node) {
+			continue
+		}
 		host := nodeSetGroup.AddHost(strings.Split(node.HostName, ".")[0])
 		hostVars, err := getAnsibleVarsFrom(ctx, helper, instance.Namespace, &node.Ansible)
 		if err != nil {
 			utils.LogErrorForObject(helper, err, "could not get ansible host vars from configMap/secret", instance)
-			return "", err
+			return inventory, err
 		}
 		for k, v := range hostVars {
 			host.Vars[k] = v
@@ -221,50 +274,18 @@ func GenerateNodeSetInventory(ctx context.Context, helper *helper.Helper,
 		err = resolveHostAnsibleVars(&node, &host, netServiceNetMap)
 		if err != nil {
 			utils.LogErrorForObject(helper, err, "could not resolve ansible host vars", instance)
-			return "", err
+			return inventory, err
 		}
 
 		ipSet, ok := allIPSets[node.HostName]
 		if !ok {
 			err := fmt.Errorf("no IPSet found for host: %s", node.HostName)
-			return "", err
+			return inventory, err
 		}
 		populateInventoryFromIPAM(&ipSet, host, dnsAddresses, node.HostName)
 	}
 
-	invData, err := inventory.MarshalYAML()
-	if err != nil {
-		utils.LogErrorForObject(helper, err, "Could not parse NodeSet inventory", instance)
-		return "", err
-	}
-	secretData := map[string]string{
-		"inventory": string(invData),
-	}
-	secretName := fmt.Sprintf("dataplanenodeset-%s", instance.Name)
-	labels := map[string]string{
-		"openstack.org/operator-name": "dataplane",
-		"openstackdataplanenodeset":   instance.Name,
-		"inventory":                   "true",
-	}
-	for key, val := range instance.Labels {
-		labels[key] = val
-	}
-	template := []utils.Template{
-		// Secret
-		{
-			Name:         secretName,
-			Namespace:    instance.Namespace,
-			Type:         utils.TemplateTypeNone,
-			InstanceType: instance.Kind,
-			CustomData:   secretData,
-			Labels:       labels,
-		},
-	}
-	err = secret.EnsureSecrets(ctx, helper, instance, template, nil)
-	if err == nil {
-		instance.Status.InventorySecretName = secretName
-	}
-	return secretName, err
+	return inventory, nil
 }
 
 // populateInventoryFromIPAM populates inventory from IPAM
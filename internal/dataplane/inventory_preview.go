@@ -0,0 +1,295 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployment
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	yaml "gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	infranetworkv1 "github.com/openstack-k8s-operators/infra-operator/apis/network/v1beta1"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	openstackv1 "github.com/openstack-k8s-operators/openstack-operator/api/core/v1beta1"
+	dataplanev1 "github.com/openstack-k8s-operators/openstack-operator/api/dataplane/v1beta1"
+	dataplaneutil "github.com/openstack-k8s-operators/openstack-operator/internal/dataplane/util"
+)
+
+const (
+	// InventoryPreviewLabel label for marking the ConfigMaps holding the
+	// inventory preview of a NodeSet
+	InventoryPreviewLabel = "inventory-preview"
+
+	// RedactedValue replaces the values coming from Secrets in the inventory
+	// preview
+	RedactedValue = "<redacted>"
+)
+
+// GetInventoryPreviewConfigMapName returns the name of the ConfigMap holding
+// the inventory preview of a NodeSet
+func GetInventoryPreviewConfigMapName(nodeSetName string) string {
+	return fmt.Sprintf("dataplanenodeset-%s-preview", nodeSetName)
+}
+
+// EnsureInventoryPreview renders the inventory of the NodeSet spec proposed
+// by the InventoryPreviewAnnotation into the preview ConfigMap, and deletes
+// the ConfigMap once the annotation is removed. The inventory is not used by
+// any deployment. Failing to render it is reported in the ConfigMap.
+func EnsureInventoryPreview(ctx context.Context, helper *helper.Helper,
+	instance *dataplanev1.OpenStackDataPlaneNodeSet,
+	allIPSets map[string]infranetworkv1.IPSet, dnsAddresses []string,
+	containerImages openstackv1.ContainerImages,
+	netServiceNetMap map[string]string,
+) error {
+	previewConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: instance.Namespace,
+			Name:      GetInventoryPreviewConfigMapName(instance.Name),
+		},
+	}
+
+	patch, ok := instance.Annotations[dataplanev1.InventoryPreviewAnnotation]
+	if !ok {
+		err := helper.GetClient().Get(ctx, types.NamespacedName{
+			Namespace: previewConfigMap.Namespace,
+			Name:      previewConfigMap.Name,
+		}, previewConfigMap)
+		if err != nil {
+			if k8s_errors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		err = helper.GetClient().Delete(ctx, previewConfigMap)
+		if err != nil && !k8s_errors.IsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+
+	data := map[string]string{}
+	inventory, warnings, err := renderInventoryPreview(ctx, helper, instance, patch,
+		allIPSets, dnsAddresses, containerImages, netServiceNetMap)
+	if err != nil {
+		helper.GetLogger().Info("Could not render the inventory preview", "error", err.Error())
+		data["error"] = err.Error()
+	} else {
+		data["inventory"] = inventory
+		if len(warnings) > 0 {
+			data["warnings"] = strings.Join(warnings, "\n")
+		}
+	}
+
+	_, err = controllerutil.CreateOrPatch(ctx, helper.GetClient(), previewConfigMap, func() error {
+		previewConfigMap.Labels = map[string]string{
+			NodeSetLabel:          instance.Name,
+			InventoryPreviewLabel: "true",
+		}
+		previewConfigMap.Data = data
+		return controllerutil.SetControllerReference(
+			helper.GetBeforeObject(), previewConfigMap, helper.GetScheme())
+	})
+	return err
+}
+
+// renderInventoryPreview returns the inventory of the NodeSet with the spec
+// patched, the values coming from Secrets redacted, and warnings about the
+// nodes the inventory would differ for once deployed
+func renderInventoryPreview(ctx context.Context, helper *helper.Helper,
+	instance *dataplanev1.OpenStackDataPlaneNodeSet, patch string,
+	allIPSets map[string]infranetworkv1.IPSet, dnsAddresses []string,
+	containerImages openstackv1.ContainerImages,
+	netServiceNetMap map[string]string,
+) (string, []string, error) {
+	var warnings []string
+
+	proposed, err := ApplyInventoryPreviewPatch(instance, patch)
+	if err != nil {
+		return "", warnings, err
+	}
+
+	// The IPs of new nodes are only reserved once the spec is applied
+	ipSets := make(map[string]infranetworkv1.IPSet, len(allIPSets))
+	for k, v := range allIPSets {
+		ipSets[k] = v
+	}
+	nodeNames := make([]string, 0, len(proposed.Spec.Nodes))
+	for name := range proposed.Spec.Nodes {
+		nodeNames = append(nodeNames, name)
+	}
+	sort.Strings(nodeNames)
+	for _, nodeName := range nodeNames {
+		hostName := proposed.Spec.Nodes[nodeName].HostName
+		if _, ok := ipSets[hostName]; !ok {
+			ipSets[hostName] = infranetworkv1.IPSet{}
+			warnings = append(warnings, fmt.Sprintf(
+				"no IPs reserved yet for node %s, the network variables of the node are missing", nodeName))
+		}
+	}
+
+	inventory, err := buildNodeSetInventory(ctx, helper, proposed,
+		ipSets, dnsAddresses, containerImages, netServiceNetMap)
+	if err != nil {
+		return "", warnings, err
+	}
+	invData, err := inventory.MarshalYAML()
+	if err != nil {
+		return "", warnings, err
+	}
+
+	groupSecretVars, err := getSecretAnsibleVars(ctx, helper, proposed.Namespace,
+		&proposed.Spec.NodeTemplate.Ansible)
+	if err != nil {
+		return "", warnings, err
+	}
+	hostSecretVars := map[string]map[string]bool{}
+	for _, nodeName := range nodeNames {
+		node := proposed.Spec.Nodes[nodeName]
+		secretVars, err := getSecretAnsibleVars(ctx, helper, proposed.Namespace, &node.Ansible)
+		if err != nil {
+			return "", warnings, err
+		}
+		hostSecretVars[strings.Split(node.HostName, ".")[0]] = secretVars
+	}
+
+	redacted, err := RedactInventory(invData, proposed.Name, groupSecretVars, hostSecretVars)
+	if err != nil {
+		return "", warnings, err
+	}
+	return string(redacted), warnings, nil
+}
+
+// ApplyInventoryPreviewPatch returns a copy of the NodeSet with the strategic
+// merge patch of the InventoryPreviewAnnotation, in JSON or YAML, applied to
+// its spec. The spec declares no merge keys, so lists are replaced.
+func ApplyInventoryPreviewPatch(instance *dataplanev1.OpenStackDataPlaneNodeSet,
+	patch string,
+) (*dataplanev1.OpenStackDataPlaneNodeSet, error) {
+	proposed := instance.DeepCopy()
+	if strings.TrimSpace(patch) == "" {
+		return proposed, nil
+	}
+
+	patchData := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(patch), &patchData); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %w", dataplanev1.InventoryPreviewAnnotation, err)
+	}
+	patchJSON, err := json.Marshal(patchData)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %w", dataplanev1.InventoryPreviewAnnotation, err)
+	}
+	specJSON, err := json.Marshal(instance.Spec)
+	if err != nil {
+		return nil, err
+	}
+	proposedJSON, err := strategicpatch.StrategicMergePatch(specJSON, patchJSON,
+		dataplanev1.OpenStackDataPlaneNodeSetSpec{})
+	if err != nil {
+		return nil, fmt.Errorf("could not apply the %s annotation: %w", dataplanev1.InventoryPreviewAnnotation, err)
+	}
+	proposed.Spec = dataplanev1.OpenStackDataPlaneNodeSetSpec{}
+	if err := json.Unmarshal(proposedJSON, &proposed.Spec); err != nil {
+		return nil, fmt.Errorf("could not apply the %s annotation: %w", dataplanev1.InventoryPreviewAnnotation, err)
+	}
+	return proposed, nil
+}
+
+// getSecretAnsibleVars returns the ansible variables whose value is coming
+// from a Secret of the ansibleVarsFrom, and not overridden by a later
+// ConfigMap or by the ansibleVars
+func getSecretAnsibleVars(ctx context.Context, helper *helper.Helper, namespace string,
+	ansibleOpts *dataplanev1.AnsibleOpts,
+) (map[string]bool, error) {
+	secretVars := map[string]bool{}
+	for _, dataSource := range ansibleOpts.AnsibleVarsFrom {
+		configMap, secret, err := dataplaneutil.GetDataSourceCmSecret(ctx, helper, namespace, dataSource)
+		if err != nil {
+			return secretVars, err
+		}
+
+		vars := map[string]interface{}{}
+		if configMap != nil {
+			if err := processConfigMapData(configMap.Data, dataSource.Prefix, vars); err != nil {
+				return secretVars, err
+			}
+		}
+		for k := range vars {
+			delete(secretVars, k)
+		}
+		vars = map[string]interface{}{}
+		if secret != nil {
+			if err := processSecretData(secret.Data, dataSource.Prefix, vars); err != nil {
+				return secretVars, err
+			}
+		}
+		for k := range vars {
+			secretVars[k] = true
+		}
+	}
+	for k := range ansibleOpts.AnsibleVars {
+		delete(secretVars, k)
+	}
+	return secretVars, nil
+}
+
+// RedactInventory replaces the given group and host variables of the group of
+// the NodeSet in the inventory with RedactedValue
+func RedactInventory(invData []byte, groupName string,
+	groupVars map[string]bool, hostVars map[string]map[string]bool,
+) ([]byte, error) {
+	inventory := map[string]interface{}{}
+	if err := yaml.Unmarshal(invData, &inventory); err != nil {
+		return nil, err
+	}
+
+	group, _ := inventory[groupName].(map[string]interface{})
+	if vars, ok := group["vars"].(map[string]interface{}); ok {
+		redactVars(vars, groupVars)
+	}
+	if hosts, ok := group["hosts"].(map[string]interface{}); ok {
+		for hostName, host := range hosts {
+			if vars, ok := host.(map[string]interface{}); ok {
+				redactVars(vars, hostVars[hostName])
+			}
+		}
+	}
+	var out bytes.Buffer
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(inventory); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), encoder.Close()
+}
+
+func redactVars(vars map[string]interface{}, redact map[string]bool) {
+	for k := range vars {
+		if redact[k] {
+			vars[k] = RedactedValue
+		}
+	}
+}
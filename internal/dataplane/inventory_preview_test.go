@@ -0,0 +1,113 @@
+package deployment
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	yaml "gopkg.in/yaml.v3"
+
+	dataplanev1 "github.com/openstack-k8s-operators/openstack-operator/api/dataplane/v1beta1"
+)
+
+func TestApplyInventoryPreviewPatch(t *testing.T) {
+	instance := &dataplanev1.OpenStackDataPlaneNodeSet{
+		Spec: dataplanev1.OpenStackDataPlaneNodeSetSpec{
+			Services: []string{"bootstrap", "configure-network"},
+			NodeTemplate: dataplanev1.NodeTemplate{
+				Ansible: dataplanev1.AnsibleOpts{
+					AnsibleUser: "cloud-admin",
+					AnsibleVars: map[string]json.RawMessage{
+						"edpm_network_config_debug": json.RawMessage(`false`),
+						"timesync_ntp_servers":      json.RawMessage(`[{"hostname": "pool.ntp.org"}]`),
+					},
+				},
+			},
+			Nodes: map[string]dataplanev1.NodeSection{
+				"edpm-compute-0": {HostName: "edpm-compute-0"},
+				"edpm-compute-1": {HostName: "edpm-compute-1"},
+			},
+		},
+	}
+
+	proposed, err := ApplyInventoryPreviewPatch(instance, "")
+	assert.NoError(t, err)
+	assert.Equal(t, instance, proposed)
+
+	proposed, err = ApplyInventoryPreviewPatch(instance, `
+services: [bootstrap]
+nodeTemplate:
+  ansible:
+    ansibleVars:
+      edpm_network_config_debug: true
+nodes:
+  edpm-compute-1: null
+  edpm-compute-2:
+    hostName: edpm-compute-2.example.com
+`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"bootstrap"}, proposed.Spec.Services)
+	assert.Equal(t, "cloud-admin", proposed.Spec.NodeTemplate.Ansible.AnsibleUser)
+	assert.JSONEq(t, `true`,
+		string(proposed.Spec.NodeTemplate.Ansible.AnsibleVars["edpm_network_config_debug"]))
+	assert.JSONEq(t, `[{"hostname": "pool.ntp.org"}]`,
+		string(proposed.Spec.NodeTemplate.Ansible.AnsibleVars["timesync_ntp_servers"]))
+	assert.Equal(t, map[string]dataplanev1.NodeSection{
+		"edpm-compute-0": {HostName: "edpm-compute-0"},
+		"edpm-compute-2": {HostName: "edpm-compute-2.example.com"},
+	}, proposed.Spec.Nodes)
+
+	// The NodeSet itself is left untouched
+	assert.Len(t, instance.Spec.Services, 2)
+	assert.Contains(t, instance.Spec.Nodes, "edpm-compute-1")
+
+	_, err = ApplyInventoryPreviewPatch(instance, "nodes: [")
+	assert.ErrorContains(t, err, "invalid dataplane.openstack.org/inventory-preview annotation")
+}
+
+func TestRedactInventory(t *testing.T) {
+	invData := []byte(`
+openstack-edpm:
+  hosts:
+    edpm-compute-0:
+      ansible_host: 192.168.122.100
+      edpm_root_password: secret0
+      edpm_token: token0
+    edpm-compute-1:
+      ansible_host: 192.168.122.101
+      edpm_root_password: secret1
+  vars:
+    edpm_nodeset_name: openstack-edpm
+    edpm_registry_password: secret
+`)
+
+	redacted, err := RedactInventory(invData, "openstack-edpm",
+		map[string]bool{"edpm_registry_password": true},
+		map[string]map[string]bool{
+			"edpm-compute-0": {"edpm_root_password": true, "edpm_token": true},
+			"edpm-compute-1": {"edpm_root_password": true},
+		})
+	assert.NoError(t, err)
+
+	inventory := map[string]interface{}{}
+	assert.NoError(t, yaml.Unmarshal(redacted, &inventory))
+	assert.Equal(t, map[string]interface{}{
+		"openstack-edpm": map[string]interface{}{
+			"hosts": map[string]interface{}{
+				"edpm-compute-0": map[string]interface{}{
+					"ansible_host":       "192.168.122.100",
+					"edpm_root_password": RedactedValue,
+					"edpm_token":         RedactedValue,
+				},
+				"edpm-compute-1": map[string]interface{}{
+					"ansible_host":       "192.168.122.101",
+					"edpm_root_password": RedactedValue,
+				},
+			},
+			"vars": map[string]interface{}{
+				"edpm_nodeset_name":      "openstack-edpm",
+				"edpm_registry_password": RedactedValue,
+			},
+		},
+	}, inventory)
+}
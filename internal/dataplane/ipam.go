@@ -303,6 +303,11 @@ func EnsureIPSets(ctx context.Context, helper *helper.Helper,
 // cleanupStaleReservations Cleanup stale ipset reservations
 func cleanupStaleReservations(ctx context.Context, helper *helper.Helper,
 	instance *dataplanev1.OpenStackDataPlaneNodeSet) error {
+	// Removed nodes are released by EnsureDecommission once decommissioned
+	if instance.Spec.Decommission != nil {
+		return nil
+	}
+
 	ipSetList := &infranetworkv1.IPSetList{}
 	labelSelector := labels.GetLabels(instance,
 		labels.GetGroupLabel(NodeSetLabel), map[string]string{})
@@ -0,0 +1,194 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	utils "github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	dataplanev1 "github.com/openstack-k8s-operators/openstack-operator/api/dataplane/v1beta1"
+	"github.com/openstack-k8s-operators/openstack-operator/internal/dataplane/util"
+)
+
+// EnsureMaintenance reports the nodes in maintenance in the NodeSet status and
+// disables their nova-compute service when requested. The nova-compute
+// service is enabled again once a node leaves maintenance. The failures are
+// reported in the NodeSetMaintenanceReady condition, a failed node doesn't
+// hold back the other ones.
+func EnsureMaintenance(ctx context.Context, helper *helper.Helper,
+	instance *dataplanev1.OpenStackDataPlaneNodeSet,
+) error {
+	var errs []error
+	pendingNodes := []string{}
+	cordonedNodes := []string{}
+	disabledNodes := []string{}
+	for _, node := range instance.Spec.Nodes {
+		if node.Maintenance == nil {
+			continue
+		}
+		cordonedNodes = append(cordonedNodes, node.HostName)
+		if !node.Maintenance.DisableComputeService {
+			continue
+		}
+		disabledNodes = append(disabledNodes, strings.Split(node.HostName, ".")[0])
+
+		done, err := disableNode(ctx, helper, instance, node)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", node.HostName, err))
+		} else if !done {
+			pendingNodes = append(pendingNodes, node.HostName)
+		}
+	}
+	sort.Strings(cordonedNodes)
+	instance.Status.CordonedNodes = cordonedNodes
+
+	// Nodes left with a disable job are not in maintenance anymore
+	disableJobs := &batchv1.JobList{}
+	err := helper.GetClient().List(ctx, disableJobs,
+		client.InNamespace(instance.Namespace),
+		client.MatchingLabels{
+			"openstackdataplanenodeset": instance.Name,
+			"openstackcontrolplanejob":  string(ControlPlaneActionDisable),
+		})
+	if err != nil {
+		errs = append(errs, err)
+	}
+	for idx := range disableJobs.Items {
+		disableJob := &disableJobs.Items[idx]
+		shortName := disableJob.Labels["openstackdataplanenode"]
+		if slices.Contains(disabledNodes, shortName) || isShortNameDecommissioning(instance, shortName) {
+			continue
+		}
+		done, err := enableNode(ctx, helper, instance, disableJob, shortName)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", shortName, err))
+		} else if !done {
+			pendingNodes = append(pendingNodes, shortName)
+		}
+	}
+
+	err = errors.Join(errs...)
+	if err != nil {
+		instance.Status.Conditions.MarkFalse(
+			dataplanev1.NodeSetMaintenanceReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityError,
+			dataplanev1.NodeSetMaintenanceErrorMessage,
+			err.Error())
+		return err
+	}
+	if len(pendingNodes) > 0 {
+		instance.Status.Conditions.MarkFalse(
+			dataplanev1.NodeSetMaintenanceReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			dataplanev1.NodeSetMaintenanceReadyWaitingMessage,
+			strings.Join(pendingNodes, ","))
+		return nil
+	}
+	// The condition is only set while nodes are in maintenance
+	if instance.Status.Conditions.Has(dataplanev1.NodeSetMaintenanceReadyCondition) {
+		instance.Status.Conditions.MarkTrue(
+			dataplanev1.NodeSetMaintenanceReadyCondition,
+			dataplanev1.NodeSetMaintenanceReadyMessage)
+	}
+	return nil
+}
+
+// disableNode disables the nova-compute service of a node in maintenance and
+// returns true once it is disabled
+func disableNode(ctx context.Context, helper *helper.Helper,
+	instance *dataplanev1.OpenStackDataPlaneNodeSet,
+	node dataplanev1.NodeSection,
+) (bool, error) {
+	reason := node.Maintenance.Reason
+	if reason == "" {
+		reason = fmt.Sprintf("Maintenance of %s", node.HostName)
+	}
+	// The node is back in maintenance before the previous one was wrapped
+	// up, start over from the disable
+	_, enableLabels := GetControlPlaneJobNameAndLabels(ControlPlaneActionEnable, instance.Name, node.HostName)
+	_, err := util.GetAnsibleExecution(ctx, helper, instance, enableLabels)
+	if err == nil {
+		for _, action := range []ControlPlaneAction{ControlPlaneActionDisable, ControlPlaneActionEnable} {
+			err = DeleteControlPlaneJob(ctx, helper, instance, action, node.HostName)
+			if err != nil {
+				return false, err
+			}
+		}
+		return false, nil
+	} else if !k8s_errors.IsNotFound(err) {
+		return false, err
+	}
+	return EnsureControlPlaneJob(ctx, helper, instance, ControlPlaneActionDisable, node.HostName,
+		map[string]string{"DISABLE_REASON": reason})
+}
+
+// enableNode enables the nova-compute service of a node which left
+// maintenance and returns true once it is enabled and its jobs are deleted
+func enableNode(ctx context.Context, helper *helper.Helper,
+	instance *dataplanev1.OpenStackDataPlaneNodeSet,
+	disableJob *batchv1.Job,
+	shortName string,
+) (bool, error) {
+	// Wait for the disable to finish before enabling the service back
+	if done, err := isJobDone(disableJob); !done && err == nil {
+		return false, nil
+	}
+
+	utils.LogForObject(helper, fmt.Sprintf("Node %s left maintenance, enabling nova-compute", shortName), instance)
+	done, err := EnsureControlPlaneJob(ctx, helper, instance, ControlPlaneActionEnable, shortName, nil)
+	if err != nil || !done {
+		return false, err
+	}
+	err = DeleteControlPlaneJob(ctx, helper, instance, ControlPlaneActionDisable, shortName)
+	if err != nil {
+		return false, err
+	}
+	err = DeleteControlPlaneJob(ctx, helper, instance, ControlPlaneActionEnable, shortName)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// IsNodeInMaintenance returns true if the node is cordoned
+func IsNodeInMaintenance(node dataplanev1.NodeSection) bool {
+	return node.Maintenance != nil
+}
+
+// isShortNameDecommissioning returns true if a node with the given short
+// hostname is being decommissioned, its jobs are then cleaned up on release
+func isShortNameDecommissioning(instance *dataplanev1.OpenStackDataPlaneNodeSet, shortName string) bool {
+	for hostName := range instance.Status.DecommissionStatuses {
+		if strings.Split(hostName, ".")[0] == shortName {
+			return true
+		}
+	}
+	return false
+}
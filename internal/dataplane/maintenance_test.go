@@ -0,0 +1,30 @@
+package deployment
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	dataplanev1 "github.com/openstack-k8s-operators/openstack-operator/api/dataplane/v1beta1"
+)
+
+func TestIsShortNameDecommissioning(t *testing.T) {
+	instance := &dataplanev1.OpenStackDataPlaneNodeSet{
+		Status: dataplanev1.OpenStackDataPlaneNodeSetStatus{
+			DecommissionStatuses: map[string]dataplanev1.DecommissionPhase{
+				"compute-0.example.com": dataplanev1.DecommissionPhaseDraining,
+			},
+		},
+	}
+
+	assert.True(t, isShortNameDecommissioning(instance, "compute-0"))
+	assert.False(t, isShortNameDecommissioning(instance, "compute-1"))
+}
+
+func TestIsNodeInMaintenance(t *testing.T) {
+	assert.False(t, IsNodeInMaintenance(dataplanev1.NodeSection{HostName: "compute-0"}))
+	assert.True(t, IsNodeInMaintenance(dataplanev1.NodeSection{
+		HostName:    "compute-0",
+		Maintenance: &dataplanev1.MaintenanceSpec{DisableComputeService: true},
+	}))
+}
@@ -0,0 +1,340 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployment
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"sort"
+	"strings"
+
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	infranetworkv1 "github.com/openstack-k8s-operators/infra-operator/apis/network/v1beta1"
+	"github.com/openstack-k8s-operators/lib-common/modules/ansible"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	openstackv1 "github.com/openstack-k8s-operators/openstack-operator/api/core/v1beta1"
+	dataplanev1 "github.com/openstack-k8s-operators/openstack-operator/api/dataplane/v1beta1"
+)
+
+// NetworkConfigTemplateVar is the ansible variable holding the os-net-config
+// template rendered on each node by the edpm_network_config role
+const NetworkConfigTemplateVar = "edpm_network_config_template"
+
+// networkVarSuffixes are the suffixes of the per network variables set in the
+// inventory for each network of a node
+var networkVarSuffixes = []string{
+	"_ip", "_cidr", "_mtu", "_vlan_id", "_gateway_ip", "_host_routes",
+}
+
+// templateVarRegex matches the words of a template which can be variable
+// names
+var templateVarRegex = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*`)
+
+// ValidateNetworkConfigTemplates checks the network config template of each
+// node of the NodeSet against the variables the inventory would provide to the
+// node, and validates the node networks against the NetConfig and the IPSet
+// reservations. The networks which don't match the NetConfig are returned as
+// field errors. The network variable names used by the template which the
+// inventory doesn't provide, and the checks which can't be done yet, are
+// returned as warnings.
+func ValidateNetworkConfigTemplates(ctx context.Context, helper *helper.Helper,
+	instance *dataplanev1.OpenStackDataPlaneNodeSet,
+) (admission.Warnings, field.ErrorList, error) {
+	var warnings admission.Warnings
+	var errors field.ErrorList
+
+	inventory := ansible.MakeInventory()
+	group := inventory.AddGroup(instance.Name)
+	groupVars, err := getAnsibleVarsFrom(ctx, helper, instance.Namespace, &instance.Spec.NodeTemplate.Ansible)
+	if err != nil {
+		// The ConfigMaps and Secrets may be created after the NodeSet
+		warnings = append(warnings, fmt.Sprintf(
+			"could not get ansible group vars to validate the network config template: %s", err))
+		return warnings, errors, nil
+	}
+	for k, v := range groupVars {
+		group.Vars[k] = v
+	}
+
+	var netConfig *infranetworkv1.NetConfig
+	netServiceNetMap := map[string]string{}
+	netNames := map[string]bool{}
+	netConfigList := &infranetworkv1.NetConfigList{}
+	err = helper.GetClient().List(ctx, netConfigList, client.InNamespace(instance.Namespace))
+	if err != nil {
+		return warnings, errors, err
+	}
+	if len(netConfigList.Items) > 0 {
+		netConfig = &netConfigList.Items[0]
+		netServiceNetMap = BuildNetServiceNetMap(*netConfig)
+		for _, name := range netServiceNetMap {
+			netNames[name] = true
+		}
+	}
+
+	err = resolveGroupAnsibleVars(&instance.Spec.NodeTemplate, &group,
+		openstackv1.ContainerImages{}, netServiceNetMap)
+	if err != nil {
+		return warnings, errors, err
+	}
+
+	nodeNames := make([]string, 0, len(instance.Spec.Nodes))
+	for name := range instance.Spec.Nodes {
+		nodeNames = append(nodeNames, name)
+	}
+	sort.Strings(nodeNames)
+
+	for _, nodeName := range nodeNames {
+		node := instance.Spec.Nodes[nodeName]
+		nodePath := field.NewPath("spec", "nodes").Key(nodeName)
+
+		host := group.AddHost(strings.Split(node.HostName, ".")[0])
+		hostVars, err := getAnsibleVarsFrom(ctx, helper, instance.Namespace, &node.Ansible)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf(
+				"could not get ansible host vars of node %s to validate the network config template: %s",
+				nodeName, err))
+			continue
+		}
+		for k, v := range hostVars {
+			host.Vars[k] = v
+		}
+		err = resolveHostAnsibleVars(&node, &host, netServiceNetMap)
+		if err != nil {
+			return warnings, errors, err
+		}
+
+		vars := mergeAnsibleVars(group.Vars, host.Vars)
+		tmpl, ok := vars[NetworkConfigTemplateVar].(string)
+		if !ok || tmpl == "" {
+			continue
+		}
+		tmplPath := ansibleVarPath(instance, nodeName, NetworkConfigTemplateVar, hostVars, groupVars)
+
+		if netConfig == nil {
+			warnings = append(warnings, fmt.Sprintf(
+				"network config template of node %s not validated against the networks, no NetConfig exists yet",
+				nodeName))
+		} else {
+			networks := node.Networks
+			networksPath := nodePath.Child("networks")
+			if len(networks) == 0 {
+				networks = instance.Spec.NodeTemplate.Networks
+				networksPath = field.NewPath("spec", "nodeTemplate", "networks")
+			}
+			errors = append(errors, validateNodeNetworks(networks, netConfig, networksPath)...)
+
+			ipSet := &infranetworkv1.IPSet{}
+			err = helper.GetClient().Get(ctx,
+				types.NamespacedName{Name: node.HostName, Namespace: instance.Namespace}, ipSet)
+			if err != nil && !k8s_errors.IsNotFound(err) {
+				return warnings, errors, err
+			}
+			for k, v := range getNodeNetworkVars(networks, netConfig, ipSet) {
+				vars[k] = v
+			}
+		}
+
+		warnings = append(warnings, validateNetworkConfigTemplate(tmpl, vars, netNames, tmplPath, nodeName)...)
+	}
+
+	return warnings, errors, nil
+}
+
+// mergeAnsibleVars returns the vars of a host, host vars taking precedence
+// over the group vars
+func mergeAnsibleVars(groupVars map[string]interface{}, hostVars map[string]interface{}) map[string]interface{} {
+	vars := make(map[string]interface{}, len(groupVars)+len(hostVars))
+	for k, v := range groupVars {
+		vars[k] = v
+	}
+	for k, v := range hostVars {
+		vars[k] = v
+	}
+	return vars
+}
+
+// ansibleVarPath returns the path of the field an ansible variable of a node
+// is coming from. The path of the NodeSet ansibleVars key is returned when
+// the variable isn't set.
+func ansibleVarPath(instance *dataplanev1.OpenStackDataPlaneNodeSet, nodeName string, name string,
+	hostVarsFrom map[string]interface{}, groupVarsFrom map[string]interface{},
+) *field.Path {
+	node := instance.Spec.Nodes[nodeName]
+	nodePath := field.NewPath("spec", "nodes").Key(nodeName).Child("ansible")
+	templatePath := field.NewPath("spec", "nodeTemplate", "ansible")
+	switch {
+	case node.Ansible.AnsibleVars[name] != nil:
+		return nodePath.Child("ansibleVars").Key(name)
+	case hostVarsFrom[name] != nil:
+		return nodePath.Child("ansibleVarsFrom")
+	case instance.Spec.NodeTemplate.Ansible.AnsibleVars[name] != nil:
+		return templatePath.Child("ansibleVars").Key(name)
+	case groupVarsFrom[name] != nil:
+		return templatePath.Child("ansibleVarsFrom")
+	}
+	return templatePath.Child("ansibleVars").Key(name)
+}
+
+// validateNodeNetworks checks the networks of a node exist in the NetConfig
+// and their fixed IPs are part of the subnet
+func validateNodeNetworks(networks []infranetworkv1.IPSetNetwork,
+	netConfig *infranetworkv1.NetConfig, path *field.Path,
+) field.ErrorList {
+	var errors field.ErrorList
+	for idx, network := range networks {
+		netPath := path.Index(idx)
+		netDef := getNetConfigNetwork(netConfig, string(network.Name))
+		if netDef == nil {
+			errors = append(errors, field.NotFound(netPath.Child("name"), network.Name))
+			continue
+		}
+		subnet := getNetConfigSubnet(netDef, string(network.SubnetName))
+		if subnet == nil {
+			errors = append(errors, field.NotFound(netPath.Child("subnetName"), network.SubnetName))
+			continue
+		}
+		if network.FixedIP == nil || *network.FixedIP == "" {
+			continue
+		}
+		ip := net.ParseIP(*network.FixedIP)
+		_, ipNet, err := net.ParseCIDR(subnet.Cidr)
+		if ip == nil || err != nil || !ipNet.Contains(ip) {
+			errors = append(errors, field.Invalid(netPath.Child("fixedIP"), *network.FixedIP,
+				fmt.Sprintf("not part of subnet %s %s of network %s", subnet.Name, subnet.Cidr, netDef.Name)))
+		}
+	}
+	return errors
+}
+
+func getNetConfigNetwork(netConfig *infranetworkv1.NetConfig, name string) *infranetworkv1.Network {
+	for idx := range netConfig.Spec.Networks {
+		if strings.EqualFold(string(netConfig.Spec.Networks[idx].Name), name) {
+			return &netConfig.Spec.Networks[idx]
+		}
+	}
+	return nil
+}
+
+func getNetConfigSubnet(network *infranetworkv1.Network, name string) *infranetworkv1.Subnet {
+	for idx := range network.Subnets {
+		if strings.EqualFold(string(network.Subnets[idx].Name), name) {
+			return &network.Subnets[idx]
+		}
+	}
+	return nil
+}
+
+// getNodeNetworkVars returns the per network variables the inventory provides
+// for the networks of a node. Reserved addresses are used when the IPSet has
+// a reservation for the network, the NetConfig subnet otherwise.
+func getNodeNetworkVars(networks []infranetworkv1.IPSetNetwork,
+	netConfig *infranetworkv1.NetConfig, ipSet *infranetworkv1.IPSet,
+) map[string]interface{} {
+	vars := map[string]interface{}{}
+	serviceNetMap := BuildNetServiceNetMap(*netConfig)
+
+	reserved := &infranetworkv1.IPSet{}
+	for _, network := range networks {
+		netDef := getNetConfigNetwork(netConfig, string(network.Name))
+		if netDef == nil {
+			continue
+		}
+		subnet := getNetConfigSubnet(netDef, string(network.SubnetName))
+		if subnet == nil {
+			continue
+		}
+		reservation := false
+		for _, res := range ipSet.Status.Reservation {
+			if strings.EqualFold(string(res.Network), string(network.Name)) &&
+				strings.EqualFold(string(res.Subnet), string(network.SubnetName)) {
+				reserved.Status.Reservation = append(reserved.Status.Reservation, res)
+				reservation = true
+			}
+		}
+		if reservation {
+			continue
+		}
+
+		entry := serviceNetMap[strings.ToLower(string(network.Name))]
+		address := ""
+		if network.FixedIP != nil {
+			address = *network.FixedIP
+		}
+		vars[entry+"_ip"] = address
+		_, ipNet, err := net.ParseCIDR(subnet.Cidr)
+		if err == nil {
+			netCidr, _ := ipNet.Mask.Size()
+			vars[entry+"_cidr"] = netCidr
+		}
+		if subnet.Vlan != nil {
+			vars[entry+"_vlan_id"] = subnet.Vlan
+		}
+		vars[entry+"_mtu"] = netDef.MTU
+		vars[entry+"_gateway_ip"] = subnet.Gateway
+		vars[entry+"_host_routes"] = subnet.Routes
+	}
+
+	host := ansible.Host{Vars: map[string]interface{}{}}
+	populateInventoryFromIPAM(reserved, host, nil, ipSet.Name)
+	for k, v := range host.Vars {
+		vars[k] = v
+	}
+	return vars
+}
+
+// validateNetworkConfigTemplate returns a warning for each per network
+// variable name, e.g. internalapi_ip, used in the template for a network in
+// netNames which isn't defined in vars. This only checks variable names: the
+// template is neither parsed nor rendered, so the names guarded by a default
+// filter or an is defined test are reported as well, and the names built at
+// render time, e.g. with lookup('vars', ...), are not checked.
+func validateNetworkConfigTemplate(tmpl string, vars map[string]interface{},
+	netNames map[string]bool, path *field.Path, nodeName string,
+) admission.Warnings {
+	var warnings admission.Warnings
+
+	undefined := map[string]bool{}
+	for _, name := range templateVarRegex.FindAllString(tmpl, -1) {
+		if _, ok := vars[name]; ok {
+			continue
+		}
+		for _, suffix := range networkVarSuffixes {
+			if strings.HasSuffix(name, suffix) && netNames[strings.TrimSuffix(name, suffix)] {
+				undefined[name] = true
+			}
+		}
+	}
+
+	names := make([]string, 0, len(undefined))
+	for name := range undefined {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		warnings = append(warnings, fmt.Sprintf(
+			"%s: template references %s which is not defined for node %s", path, name, nodeName))
+	}
+	return warnings
+}
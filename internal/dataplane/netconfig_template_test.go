@@ -0,0 +1,146 @@
+package deployment
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	infranetworkv1 "github.com/openstack-k8s-operators/infra-operator/apis/network/v1beta1"
+)
+
+const testNetworkConfigTemplate = `---
+network_config:
+- type: ovs_bridge
+  name: {{ neutron_physical_bridge_name }}
+  mtu: {{ min_viable_mtu }}
+  addresses:
+  - ip_netmask: {{ ctlplane_ip }}/{{ ctlplane_cidr }}
+  members:
+{% for network in nodeset_networks %}
+  - type: vlan
+    mtu: {{ lookup('vars', networks_lower[network] ~ '_mtu') }}
+    vlan_id: {{ lookup('vars', networks_lower[network] ~ '_vlan_id') }}
+    addresses:
+    - ip_netmask: {{ lookup('vars', networks_lower[network] ~ '_ip') }}/{{ lookup('vars', networks_lower[network] ~ '_cidr') }}
+{% endfor %}
+`
+
+func TestValidateNetworkConfigTemplate(t *testing.T) {
+	netNames := map[string]bool{"ctlplane": true, "internalapi": true, "tenant": true}
+	path := field.NewPath("spec", "nodeTemplate", "ansible", "ansibleVars").Key(NetworkConfigTemplateVar)
+	baseVars := func() map[string]interface{} {
+		return map[string]interface{}{
+			"nodeset_networks": []string{"InternalApi", "Tenant"},
+			"networks_lower":   map[string]string{"InternalApi": "internalapi", "Tenant": "tenant"},
+			"ctlplane_ip":      "192.168.122.100",
+			"ctlplane_cidr":    24,
+			"internalapi_ip":   "172.17.0.100",
+			"internalapi_cidr": 24,
+			"internalapi_mtu":  1500,
+			"tenant_ip":        "172.19.0.100",
+			"tenant_cidr":      24,
+			"tenant_mtu":       1500,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		vars     func() map[string]interface{}
+		warnings []string
+	}{
+		{
+			name:     "all network vars are defined",
+			template: testNetworkConfigTemplate,
+			vars:     baseVars,
+		},
+		{
+			name:     "lookups are not checked",
+			template: "{{ lookup('vars', networks_lower[network] ~ '_gateway_ip') }}",
+			vars:     baseVars,
+		},
+		{
+			name:     "network the node is not attached to",
+			template: "{{ ctlplane_ip }} {{ storage_ip }} {{ tenant_ip }}",
+			vars:     baseVars,
+		},
+		{
+			name:     "reference to a known network not attached to the node",
+			template: "{{ ctlplane_ip }}\n{{ tenant_gateway_ip }}\n{{ ctlplane_vlan_id }}",
+			vars:     baseVars,
+			warnings: []string{
+				"template references ctlplane_vlan_id which is not defined for node edpm-compute-0",
+				"template references tenant_gateway_ip which is not defined for node edpm-compute-0",
+			},
+		},
+		{
+			name:     "references with a default are reported",
+			template: "{{ tenant_gateway_ip | default('') }}",
+			vars:     baseVars,
+			warnings: []string{"template references tenant_gateway_ip which is not defined for node edpm-compute-0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings := validateNetworkConfigTemplate(tt.template, tt.vars(), netNames, path, "edpm-compute-0")
+			details := []string{}
+			for _, warning := range warnings {
+				detail, ok := strings.CutPrefix(warning, path.String()+": ")
+				assert.True(t, ok)
+				details = append(details, detail)
+			}
+			if tt.warnings == nil {
+				tt.warnings = []string{}
+			}
+			assert.Equal(t, tt.warnings, details)
+		})
+	}
+}
+
+func TestValidateNodeNetworks(t *testing.T) {
+	gateway := "172.17.0.1"
+	vlan := 20
+	netConfig := &infranetworkv1.NetConfig{
+		Spec: infranetworkv1.NetConfigSpec{
+			Networks: []infranetworkv1.Network{
+				{
+					Name: "InternalApi",
+					MTU:  1500,
+					Subnets: []infranetworkv1.Subnet{
+						{Name: "subnet1", Cidr: "172.17.0.0/24", Gateway: &gateway, Vlan: &vlan},
+					},
+				},
+			},
+		},
+	}
+	path := field.NewPath("spec", "nodeTemplate", "networks")
+	fixedIP := func(ip string) *string { return &ip }
+
+	errs := validateNodeNetworks([]infranetworkv1.IPSetNetwork{
+		{Name: "internalapi", SubnetName: "subnet1", FixedIP: fixedIP("172.17.0.10")},
+		{Name: "InternalApi", SubnetName: "subnet1", FixedIP: fixedIP("172.18.0.10")},
+		{Name: "InternalApi", SubnetName: "subnet2"},
+		{Name: "Storage", SubnetName: "subnet1"},
+	}, netConfig, path)
+
+	fields := []string{}
+	for _, err := range errs {
+		fields = append(fields, err.Field)
+	}
+	assert.Equal(t, []string{
+		"spec.nodeTemplate.networks[1].fixedIP",
+		"spec.nodeTemplate.networks[2].subnetName",
+		"spec.nodeTemplate.networks[3].name",
+	}, fields)
+
+	vars := getNodeNetworkVars([]infranetworkv1.IPSetNetwork{
+		{Name: "InternalApi", SubnetName: "subnet1", FixedIP: fixedIP("172.17.0.10")},
+	}, netConfig, &infranetworkv1.IPSet{})
+	assert.Equal(t, "172.17.0.10", vars["internalapi_ip"])
+	assert.Equal(t, 24, vars["internalapi_cidr"])
+	assert.Equal(t, 1500, vars["internalapi_mtu"])
+	assert.Equal(t, &vlan, vars["internalapi_vlan_id"])
+}
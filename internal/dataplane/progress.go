@@ -0,0 +1,249 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	dataplanev1 "github.com/openstack-k8s-operators/openstack-operator/api/dataplane/v1beta1"
+	dataplaneutil "github.com/openstack-k8s-operators/openstack-operator/internal/dataplane/util"
+)
+
+// ProgressLabel label for marking the ConfigMaps the AnsibleEE jobs report
+// their progress to
+const ProgressLabel = "osdp-progress"
+
+// IsProgressReported returns true when a deployment of the NodeSet which is
+// not deleted nor deployed yet asks its AnsibleEE jobs to report their progress
+func IsProgressReported(ctx context.Context, helper *helper.Helper,
+	instance *dataplanev1.OpenStackDataPlaneNodeSet,
+) (bool, error) {
+	deployments := &dataplanev1.OpenStackDataPlaneDeploymentList{}
+	err := helper.GetClient().List(ctx, deployments, client.InNamespace(instance.Namespace))
+	if err != nil {
+		return false, err
+	}
+	for _, deployment := range deployments.Items {
+		if deployment.Spec.ReportProgress && deployment.DeletionTimestamp.IsZero() &&
+			slices.Contains(deployment.Spec.NodeSets, instance.Name) &&
+			!deployment.Status.Conditions.IsTrue(condition.DeploymentReadyCondition) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// EnsureProgressReporting creates the ConfigMap the AnsibleEE jobs of the
+// NodeSet report their progress to, and allows the service account of the
+// NodeSet to patch it
+func EnsureProgressReporting(ctx context.Context, helper *helper.Helper,
+	instance *dataplanev1.OpenStackDataPlaneNodeSet,
+) error {
+	name := dataplaneutil.GetProgressConfigMapName(instance.Name)
+
+	progressConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: instance.Namespace,
+			Name:      name,
+		},
+	}
+	_, err := controllerutil.CreateOrPatch(ctx, helper.GetClient(), progressConfigMap, func() error {
+		progressConfigMap.Labels = map[string]string{
+			NodeSetLabel:  instance.Name,
+			ProgressLabel: "true",
+		}
+		return controllerutil.SetControllerReference(
+			helper.GetBeforeObject(), progressConfigMap, helper.GetScheme())
+	})
+	if err != nil {
+		return err
+	}
+
+	role := &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: instance.Namespace,
+			Name:      name,
+		},
+	}
+	_, err = controllerutil.CreateOrPatch(ctx, helper.GetClient(), role, func() error {
+		role.Rules = []rbacv1.PolicyRule{
+			{
+				APIGroups:     []string{""},
+				Resources:     []string{"configmaps"},
+				ResourceNames: []string{name},
+				Verbs:         []string{"get", "patch"},
+			},
+		}
+		return controllerutil.SetControllerReference(
+			helper.GetBeforeObject(), role, helper.GetScheme())
+	})
+	if err != nil {
+		return err
+	}
+
+	roleBinding := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: instance.Namespace,
+			Name:      name,
+		},
+	}
+	_, err = controllerutil.CreateOrPatch(ctx, helper.GetClient(), roleBinding, func() error {
+		roleBinding.RoleRef = rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "Role",
+			Name:     name,
+		}
+		roleBinding.Subjects = []rbacv1.Subject{
+			{
+				Kind:      "ServiceAccount",
+				Name:      instance.Name,
+				Namespace: instance.Namespace,
+			},
+		}
+		return controllerutil.SetControllerReference(
+			helper.GetBeforeObject(), roleBinding, helper.GetScheme())
+	})
+	return err
+}
+
+// DeleteProgressReporting deletes the progress ConfigMap of the NodeSet and
+// the Role and RoleBinding allowing the service account of the NodeSet to
+// patch it
+func DeleteProgressReporting(ctx context.Context, helper *helper.Helper,
+	instance *dataplanev1.OpenStackDataPlaneNodeSet,
+) error {
+	objectMeta := metav1.ObjectMeta{
+		Namespace: instance.Namespace,
+		Name:      dataplaneutil.GetProgressConfigMapName(instance.Name),
+	}
+	for _, obj := range []client.Object{
+		&rbacv1.RoleBinding{ObjectMeta: objectMeta},
+		&rbacv1.Role{ObjectMeta: objectMeta},
+		&corev1.ConfigMap{ObjectMeta: objectMeta},
+	} {
+		err := helper.GetClient().Delete(ctx, obj)
+		if err != nil && !k8s_errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// storeExecutionProgress stores the progress reported by a running job into
+// the deployment status, and records the ansible events reported since the
+// last time as Kubernetes Events
+func (d *Deployer) storeExecutionProgress(ansibleJob *batchv1.Job) {
+	log := d.Helper.GetLogger()
+	progress, events, err := dataplaneutil.GetAnsibleExecutionProgress(d.getProgressConfigMap(ansibleJob), ansibleJob)
+	if err != nil {
+		log.Error(err, "Unable to get ansible execution progress", "execution", ansibleJob.Name)
+		return
+	}
+	lastSequence := d.Status.AnsibleExecutionProgress[ansibleJob.Name].LastEventSequence
+	progress.LastEventSequence = lastSequence
+	if len(events) > 0 && d.Recorder != nil {
+		d.recordAnsibleEvents(ansibleJob, events, ptr.Deref(lastSequence, 0))
+		progress.LastEventSequence = ptr.To(events[len(events)-1].Sequence)
+	}
+	if d.Status.AnsibleExecutionProgress == nil {
+		d.Status.AnsibleExecutionProgress = make(map[string]dataplanev1.AnsibleExecutionProgress)
+	}
+	d.Status.AnsibleExecutionProgress[ansibleJob.Name] = *progress
+}
+
+// getProgressConfigMap returns the progress ConfigMap of the NodeSet, nil if
+// the jobs didn't report their progress
+func (d *Deployer) getProgressConfigMap(ansibleJob *batchv1.Job) *corev1.ConfigMap {
+	progressConfigMap := &corev1.ConfigMap{}
+	err := d.Helper.GetClient().Get(d.Ctx, types.NamespacedName{
+		Namespace: d.NodeSet.Namespace,
+		Name:      dataplaneutil.GetProgressConfigMapName(d.NodeSet.Name),
+	}, progressConfigMap)
+	if err != nil {
+		if !k8s_errors.IsNotFound(err) {
+			d.Helper.GetLogger().Error(err, "Unable to get ansible execution progress", "execution", ansibleJob.Name)
+		}
+		return nil
+	}
+	return progressConfigMap
+}
+
+// recordAnsibleEvents records the ansible events that follow the last recorded
+// one as Kubernetes Events of the deployment and the NodeSet
+func (d *Deployer) recordAnsibleEvents(ansibleJob *batchv1.Job,
+	events []dataplaneutil.AnsibleEvent, lastSequence int,
+) {
+	for _, event := range dataplaneutil.GetNewAnsibleEvents(events, lastSequence) {
+		eventType := corev1.EventTypeNormal
+		var message string
+		switch event.Reason {
+		case "AnsiblePlayStarted":
+			message = fmt.Sprintf("%s: play %q started", ansibleJob.Name, event.Play)
+		case "AnsibleHostFailed":
+			eventType = corev1.EventTypeWarning
+			message = fmt.Sprintf("%s: task %q failed on host %s: %s",
+				ansibleJob.Name, event.Task, event.Host, event.Message)
+		case "AnsibleHostUnreachable":
+			eventType = corev1.EventTypeWarning
+			message = fmt.Sprintf("%s: host %s is unreachable: %s",
+				ansibleJob.Name, event.Host, event.Message)
+		case "AnsibleRecap":
+			message = fmt.Sprintf("%s: %s", ansibleJob.Name, event.Message)
+		default:
+			continue
+		}
+		d.recordAnsibleEvent(eventType, event.Reason, message)
+	}
+}
+
+// clearExecutionProgress removes the progress of a finished job from the
+// deployment status and from the progress ConfigMap
+func (d *Deployer) clearExecutionProgress(ansibleJob *batchv1.Job) {
+	delete(d.Status.AnsibleExecutionProgress, ansibleJob.Name)
+
+	progressConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: d.NodeSet.Namespace,
+			Name:      dataplaneutil.GetProgressConfigMapName(d.NodeSet.Name),
+		},
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"data": map[string]interface{}{ansibleJob.Name: nil},
+	})
+	if err == nil {
+		err = d.Helper.GetClient().Patch(d.Ctx, progressConfigMap,
+			client.RawPatch(types.MergePatchType, patch))
+	}
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		d.Helper.GetLogger().Error(err, fmt.Sprintf("Unable to clear the progress of %s", ansibleJob.Name))
+	}
+}
@@ -54,7 +54,8 @@ func (d *Deployer) DeployService(foundService dataplanev1.OpenStackDataPlaneServ
 		d.AnsibleSSHPrivateKeySecrets,
 		d.InventorySecrets,
 		d.AeeSpec,
-		d.NodeSet)
+		d.NodeSet,
+		d.Batch)
 	if err != nil {
 		d.Helper.GetLogger().Error(err, fmt.Sprintf("Unable to execute Ansible for %s", foundService.Name))
 		return err
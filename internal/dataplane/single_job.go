@@ -0,0 +1,383 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployment
+
+import (
+	"fmt"
+
+	"github.com/iancoleman/strcase"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/storage"
+	dataplanev1 "github.com/openstack-k8s-operators/openstack-operator/api/dataplane/v1beta1"
+	dataplaneutil "github.com/openstack-k8s-operators/openstack-operator/internal/dataplane/util"
+)
+
+// deploySingleJob deploys the services of the NodeSet in a single job. The
+// services deployed on all the NodeSets share their job with the other
+// NodeSets, so they still run in their own job, and the services before and
+// after them in separate single jobs.
+func (d *Deployer) deploySingleJob(services []string) (*ctrl.Result, error) {
+	composed := []dataplanev1.OpenStackDataPlaneService{}
+	for _, service := range services {
+		foundService, err := GetService(d.Ctx, d.Helper, service)
+		if err != nil {
+			nsConditions := d.Status.NodeSetConditions[d.NodeSet.Name]
+			nsConditions.Set(condition.FalseCondition(
+				serviceReadyCondition(service),
+				condition.ErrorReason,
+				condition.SeverityError,
+				fmt.Sprintf(dataplanev1.NodeSetServiceDeploymentErrorMessage, service)+" error %s",
+				err.Error()))
+			d.Status.NodeSetConditions[d.NodeSet.Name] = nsConditions
+			return &ctrl.Result{}, err
+		}
+		if !foundService.Spec.DeployOnAllNodeSets {
+			composed = append(composed, foundService)
+			continue
+		}
+
+		if len(composed) > 0 {
+			result, err := d.deployComposedServices(composed, services)
+			if err != nil || result != nil {
+				return result, err
+			}
+			composed = []dataplanev1.OpenStackDataPlaneService{}
+		}
+		result, err := d.deployServices([]string{service}, services)
+		if err != nil || result != nil {
+			return result, err
+		}
+	}
+
+	if len(composed) > 0 {
+		return d.deployComposedServices(composed, services)
+	}
+	return nil, nil
+}
+
+// deployComposedServices deploys services in a single job, and maps the state
+// of each service reported by the job to its Service*DeploymentReady condition.
+// Without the progress reported by the job, all the services are ready once
+// the job succeeded, and all of them failed when the job failed.
+func (d *Deployer) deployComposedServices(
+	services []dataplanev1.OpenStackDataPlaneService,
+	allServices []string,
+) (*ctrl.Result, error) {
+	log := d.Helper.GetLogger()
+
+	nsConditions := d.Status.NodeSetConditions[d.NodeSet.Name]
+	ready := true
+	started := true
+	for _, service := range services {
+		ready = ready && nsConditions.IsTrue(serviceReadyCondition(service.Name))
+		started = started && !nsConditions.IsUnknown(serviceReadyCondition(service.Name))
+	}
+	if ready {
+		return nil, nil
+	}
+
+	if !started {
+		log.Info("Starting composed services", "services", getServiceNames(services))
+		aeeSpec, err := d.getComposedAeeSpec(services, allServices)
+		if err == nil {
+			err = dataplaneutil.ComposedAnsibleExecution(
+				d.Ctx,
+				d.Helper,
+				d.Deployment,
+				services,
+				d.AnsibleSSHPrivateKeySecrets,
+				d.InventorySecrets,
+				aeeSpec,
+				d.NodeSet,
+				d.Batch)
+		}
+		if err != nil {
+			log.Error(err, fmt.Sprintf("Unable to execute Ansible for %s", services[0].Name))
+			d.setComposedServicesError(services, condition.ErrorReason, err)
+			return &ctrl.Result{}, err
+		}
+		d.setComposedServicesWaiting(services, nil)
+	}
+
+	_, labelSelector := dataplaneutil.GetComposedAnsibleExecutionNameAndLabels(
+		services, d.Deployment.Name, d.NodeSet.Name, d.Batch)
+	ansibleJob, err := dataplaneutil.GetAnsibleExecution(d.Ctx, d.Helper, d.Deployment, labelSelector)
+	if err != nil {
+		// Requeue if we don't have AnsibleEE available yet
+		if k8s_errors.IsNotFound(err) {
+			log.Info("AnsibleEE job of composed services is not yet found", "services", getServiceNames(services))
+			return &ctrl.Result{}, nil
+		}
+		d.setComposedServicesError(services, condition.ErrorReason, err)
+		return &ctrl.Result{}, err
+	}
+
+	switch {
+	case ansibleJob.Status.Succeeded > 0:
+		d.recordJobSucceeded(ansibleJob)
+		d.storeExecutionSummary(ansibleJob)
+		d.storePlaybookSourceRevisions(ansibleJob, services)
+		d.storeExecutionProgress(ansibleJob)
+		d.clearExecutionProgress(ansibleJob)
+		nsConditions = d.Status.NodeSetConditions[d.NodeSet.Name]
+		for _, service := range services {
+			nsConditions.Set(condition.TrueCondition(
+				serviceReadyCondition(service.Name),
+				dataplanev1.NodeSetServiceDeploymentReadyMessage,
+				service.Name))
+			d.storeContainerImages(service)
+		}
+		d.Status.NodeSetConditions[d.NodeSet.Name] = nsConditions
+		return nil, nil
+
+	case ansibleJob.Status.Failed > *ansibleJob.Spec.BackoffLimit:
+		states := d.getComposedServiceStates(ansibleJob)
+		summary := d.storeExecutionSummary(ansibleJob)
+		d.storePlaybookSourceRevisions(ansibleJob, services)
+		d.storeExecutionProgress(ansibleJob)
+		d.clearExecutionProgress(ansibleJob)
+		reason, err := getAnsibleJobError(ansibleJob, summary)
+		// the error is set once, the progress of the job is cleared
+		// afterwards and the states it reported are lost
+		if d.hasComposedServicesError(services) {
+			return &ctrl.Result{}, err
+		}
+		d.recordJobFailed(ansibleJob, err)
+		d.setComposedServicesWaiting(services, states)
+		d.setComposedServicesError(getFailedComposedServices(services, states), reason, err)
+		return &ctrl.Result{}, err
+
+	default:
+		log.Info(fmt.Sprintf("AnsibleEE job is not yet completed: Execution: %s, Active pods: %d, Failed pods: %d", ansibleJob.Name, ansibleJob.Status.Active, ansibleJob.Status.Failed))
+		states := d.getComposedServiceStates(ansibleJob)
+		d.recordJobStarted(ansibleJob)
+		d.storeExecutionProgress(ansibleJob)
+		d.setComposedServicesWaiting(services, states)
+		return &ctrl.Result{}, nil
+	}
+}
+
+// getComposedAeeSpec returns the AeeSpec of the job deploying composed
+// services, with their image and the mounts of all of them. The AeeSpec of
+// the Deployer is left unchanged.
+func (d *Deployer) getComposedAeeSpec(
+	services []dataplanev1.OpenStackDataPlaneService,
+	allServices []string,
+) (*dataplanev1.AnsibleEESpec, error) {
+	aeeSpec := d.AeeSpec
+	defer func() { d.AeeSpec = aeeSpec }()
+	composedAeeSpec := *d.AeeSpec
+	composedAeeSpec.ExtraMounts = make([]storage.VolMounts, len(aeeSpec.ExtraMounts))
+	copy(composedAeeSpec.ExtraMounts, aeeSpec.ExtraMounts)
+	d.AeeSpec = &composedAeeSpec
+
+	containerImages := dataplaneutil.GetContainerImages(d.Version)
+	if containerImages.AnsibleeeImage != nil {
+		composedAeeSpec.OpenStackAnsibleEERunnerImage = *containerImages.AnsibleeeImage
+	}
+	if containerImages.OrasImage != nil {
+		composedAeeSpec.PlaybookSourceImage = *containerImages.OrasImage
+	}
+	var imageService string
+	for _, service := range services {
+		if len(service.Spec.OpenStackAnsibleEERunnerImage) == 0 {
+			continue
+		}
+		if len(imageService) > 0 && composedAeeSpec.OpenStackAnsibleEERunnerImage != service.Spec.OpenStackAnsibleEERunnerImage {
+			return nil, fmt.Errorf(
+				"services %s and %s set different openStackAnsibleEERunnerImage and can't be deployed in a single job",
+				imageService, service.Name)
+		}
+		imageService = service.Name
+		composedAeeSpec.OpenStackAnsibleEERunnerImage = service.Spec.OpenStackAnsibleEERunnerImage
+	}
+
+	var err error
+	certMounts := false
+	for _, service := range services {
+		d.AeeSpec, err = d.addServiceExtraMounts(service)
+		if err != nil {
+			return nil, err
+		}
+		if service.Spec.AddCertMounts {
+			// The certs of all the services are mounted once
+			if !certMounts {
+				d.AeeSpec, err = d.addCertMounts(allServices)
+				certMounts = true
+			}
+		} else if len(service.Spec.CACerts) > 0 {
+			d.AeeSpec, err = d.addCACertMount(service)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	composedAeeSpec.ExtraMounts = dedupeExtraMounts(composedAeeSpec.ExtraMounts)
+	return &composedAeeSpec, nil
+}
+
+// dedupeExtraMounts drops the volumes and the mounts added more than once
+// when several composed services use the same data sources or certificates
+func dedupeExtraMounts(extraMounts []storage.VolMounts) []storage.VolMounts {
+	volumes := map[string]bool{}
+	mountPaths := map[string]bool{}
+	deduped := []storage.VolMounts{}
+	for _, volMounts := range extraMounts {
+		dedupedVolMounts := volMounts
+		dedupedVolMounts.Volumes = []storage.Volume{}
+		dedupedVolMounts.Mounts = []corev1.VolumeMount{}
+		for _, volume := range volMounts.Volumes {
+			if !volumes[volume.Name] {
+				volumes[volume.Name] = true
+				dedupedVolMounts.Volumes = append(dedupedVolMounts.Volumes, volume)
+			}
+		}
+		for _, mount := range volMounts.Mounts {
+			if !mountPaths[mount.MountPath] {
+				mountPaths[mount.MountPath] = true
+				dedupedVolMounts.Mounts = append(dedupedVolMounts.Mounts, mount)
+			}
+		}
+		deduped = append(deduped, dedupedVolMounts)
+	}
+	return deduped
+}
+
+// getComposedServiceStates returns the state of the composed services
+// reported by the job, if any
+func (d *Deployer) getComposedServiceStates(ansibleJob *batchv1.Job) map[string]string {
+	log := d.Helper.GetLogger()
+	progressConfigMap := &corev1.ConfigMap{}
+	err := d.Helper.GetClient().Get(d.Ctx, types.NamespacedName{
+		Namespace: d.NodeSet.Namespace,
+		Name:      dataplaneutil.GetProgressConfigMapName(d.NodeSet.Name),
+	}, progressConfigMap)
+	if err != nil {
+		if !k8s_errors.IsNotFound(err) {
+			log.Error(err, "Unable to get the state of the composed services", "execution", ansibleJob.Name)
+		}
+		return nil
+	}
+	states, err := dataplaneutil.GetComposedServiceStates(progressConfigMap, ansibleJob)
+	if err != nil {
+		log.Error(err, "Unable to get the state of the composed services", "execution", ansibleJob.Name)
+		return nil
+	}
+	return states
+}
+
+// getFailedComposedServices returns the composed services a failed job sets
+// the error on: the ones reported as failed by the job or, when the job didn't
+// report a failed service, e.g. without the states reported by the job or when
+// its pod was killed, all the services that didn't complete
+func getFailedComposedServices(
+	services []dataplanev1.OpenStackDataPlaneService,
+	states map[string]string,
+) []dataplanev1.OpenStackDataPlaneService {
+	failed := []dataplanev1.OpenStackDataPlaneService{}
+	for _, service := range services {
+		if states[service.Name] == dataplaneutil.ServiceStateFailed {
+			failed = append(failed, service)
+		}
+	}
+	if len(failed) > 0 {
+		return failed
+	}
+	for _, service := range services {
+		if states[service.Name] != dataplaneutil.ServiceStateCompleted {
+			failed = append(failed, service)
+		}
+	}
+	return failed
+}
+
+// setComposedServicesWaiting marks the composed services that completed
+// ready, and the other ones waiting for the job
+func (d *Deployer) setComposedServicesWaiting(
+	services []dataplanev1.OpenStackDataPlaneService,
+	states map[string]string,
+) {
+	nsConditions := d.Status.NodeSetConditions[d.NodeSet.Name]
+	for _, service := range services {
+		if states[service.Name] == dataplaneutil.ServiceStateCompleted {
+			nsConditions.Set(condition.TrueCondition(
+				serviceReadyCondition(service.Name),
+				dataplanev1.NodeSetServiceDeploymentReadyMessage,
+				service.Name))
+			d.storeContainerImages(service)
+			continue
+		}
+		nsConditions.Set(condition.FalseCondition(
+			serviceReadyCondition(service.Name),
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			dataplanev1.NodeSetServiceDeploymentReadyWaitingMessage,
+			service.Name))
+	}
+	d.Status.NodeSetConditions[d.NodeSet.Name] = nsConditions
+}
+
+// setComposedServicesError sets the error of the composed services
+func (d *Deployer) setComposedServicesError(
+	services []dataplanev1.OpenStackDataPlaneService,
+	reason condition.Reason,
+	err error,
+) {
+	nsConditions := d.Status.NodeSetConditions[d.NodeSet.Name]
+	for _, service := range services {
+		nsConditions.Set(condition.FalseCondition(
+			serviceReadyCondition(service.Name),
+			reason,
+			condition.SeverityError,
+			fmt.Sprintf(dataplanev1.NodeSetServiceDeploymentErrorMessage, service.Name)+" error %s",
+			err.Error()))
+	}
+	d.Status.NodeSetConditions[d.NodeSet.Name] = nsConditions
+}
+
+// hasComposedServicesError returns true when the error of the job was already
+// set on a composed service
+func (d *Deployer) hasComposedServicesError(services []dataplanev1.OpenStackDataPlaneService) bool {
+	nsConditions := d.Status.NodeSetConditions[d.NodeSet.Name]
+	for _, service := range services {
+		cond := nsConditions.Get(serviceReadyCondition(service.Name))
+		if cond != nil && cond.Severity == condition.SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// serviceReadyCondition returns the Service*DeploymentReady condition of a service
+func serviceReadyCondition(service string) condition.Type {
+	return condition.Type(fmt.Sprintf("Service%sDeploymentReady", strcase.ToCamel(service)))
+}
+
+// getServiceNames returns the names of services
+func getServiceNames(services []dataplanev1.OpenStackDataPlaneService) []string {
+	names := []string{}
+	for _, service := range services {
+		names = append(names, service.Name)
+	}
+	return names
+}
@@ -0,0 +1,86 @@
+package deployment
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/storage"
+	dataplanev1 "github.com/openstack-k8s-operators/openstack-operator/api/dataplane/v1beta1"
+	dataplaneutil "github.com/openstack-k8s-operators/openstack-operator/internal/dataplane/util"
+)
+
+func TestDedupeExtraMounts(t *testing.T) {
+	volume := func(name string) storage.Volume {
+		return storage.Volume{
+			Name: name,
+			VolumeSource: storage.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: name},
+			},
+		}
+	}
+	mount := func(name string, mountPath string) corev1.VolumeMount {
+		return corev1.VolumeMount{Name: name, MountPath: mountPath}
+	}
+
+	extraMounts := []storage.VolMounts{
+		{
+			Volumes: []storage.Volume{volume("nova-cell1-compute-config-0")},
+			Mounts:  []corev1.VolumeMount{mount("nova-cell1-compute-config-0", "/var/lib/openstack/configs/nova/01-nova.conf")},
+		},
+		{
+			Volumes: []storage.Volume{volume("nova-cell1-compute-config-0"), volume("ssh-key")},
+			Mounts: []corev1.VolumeMount{
+				mount("nova-cell1-compute-config-0", "/var/lib/openstack/configs/nova/01-nova.conf"),
+				mount("nova-cell1-compute-config-0", "/var/lib/openstack/configs/nova-custom/01-nova.conf"),
+				mount("ssh-key", "/runner/env/ssh_key"),
+			},
+		},
+	}
+
+	assert.Equal(t, []storage.VolMounts{
+		{
+			Volumes: []storage.Volume{volume("nova-cell1-compute-config-0")},
+			Mounts:  []corev1.VolumeMount{mount("nova-cell1-compute-config-0", "/var/lib/openstack/configs/nova/01-nova.conf")},
+		},
+		{
+			Volumes: []storage.Volume{volume("ssh-key")},
+			Mounts: []corev1.VolumeMount{
+				mount("nova-cell1-compute-config-0", "/var/lib/openstack/configs/nova-custom/01-nova.conf"),
+				mount("ssh-key", "/runner/env/ssh_key"),
+			},
+		},
+	}, dedupeExtraMounts(extraMounts))
+}
+
+func TestGetFailedComposedServices(t *testing.T) {
+	services := []dataplanev1.OpenStackDataPlaneService{}
+	for _, name := range []string{"bootstrap", "configure-network", "install-os", "run-os"} {
+		services = append(services, dataplanev1.OpenStackDataPlaneService{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+		})
+	}
+
+	// The services reported as failed get the error
+	states := map[string]string{
+		"bootstrap":         dataplaneutil.ServiceStateCompleted,
+		"configure-network": dataplaneutil.ServiceStateFailed,
+	}
+	assert.Equal(t, []string{"configure-network"}, getServiceNames(getFailedComposedServices(services, states)))
+
+	// Without a failed service reported, all the services that didn't
+	// complete get the error
+	states = map[string]string{
+		"bootstrap":         dataplaneutil.ServiceStateCompleted,
+		"configure-network": dataplaneutil.ServiceStateRunning,
+	}
+	assert.Equal(t, []string{"configure-network", "install-os", "run-os"},
+		getServiceNames(getFailedComposedServices(services, states)))
+	assert.Equal(t, []string{"bootstrap", "configure-network", "install-os", "run-os"},
+		getServiceNames(getFailedComposedServices(services, map[string]string{})))
+	assert.Equal(t, []string{"bootstrap", "configure-network", "install-os", "run-os"},
+		getServiceNames(getFailedComposedServices(services, nil)))
+}
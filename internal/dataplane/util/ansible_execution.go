@@ -24,6 +24,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -53,19 +54,66 @@ func AnsibleExecution(
 	inventorySecrets map[string]string,
 	aeeSpec *dataplanev1.AnsibleEESpec,
 	nodeSet client.Object,
+	batch *ExecutionBatch,
 ) error {
 	var err error
 
 	executionName, labels := GetAnsibleExecutionNameAndLabels(service, deployment.GetName(), nodeSet.GetName())
+	if batch != nil {
+		executionName, labels = GetBatchAnsibleExecutionNameAndLabels(service, deployment.GetName(), nodeSet.GetName(), batch.Index)
+		// Limit the execution to the hosts of the batch
+		batchSpec := *aeeSpec
+		batchSpec.AnsibleLimit = strings.Join(batch.Hosts, ",")
+		aeeSpec = &batchSpec
+	}
+
+	ansibleEE, err := newAnsibleExecution(ctx, helper, deployment, aeeSpec, executionName, labels)
+	if err != nil || ansibleEE == nil {
+		return err
+	}
+
+	ansibleEE.BuildAeeJobSpec(aeeSpec, deployment, service, nodeSet)
 
+	ansibleEEMounts := storage.VolMounts{}
+	SetAeeSSHMounts(nodeSet, service, sshKeySecrets, &ansibleEEMounts)
+	SetAeeInvMounts(nodeSet, service, inventorySecrets, &ansibleEEMounts)
+
+	err = runAnsibleExecution(ctx, helper, deployment, ansibleEE, aeeSpec, ansibleEEMounts)
+	if err != nil {
+		return err
+	}
+
+	// Record where the artifacts of the service jobs of the NodeSet are persisted
+	if ansibleEE.ArtifactSink != nil {
+		if deployment.Status.ArtifactLocations[nodeSet.GetName()] == nil {
+			deployment.Status.ArtifactLocations[nodeSet.GetName()] = make(map[string]string)
+		}
+		deployment.Status.ArtifactLocations[nodeSet.GetName()][service.Name] = GetArtifactLocation(
+			ansibleEE.ArtifactSink, ansibleEE.ArtifactPath)
+	}
+
+	return nil
+}
+
+// newAnsibleExecution returns the EEJob of an execution with its name, labels,
+// environment ConfigMap and network attachments set, or nil if the job of the
+// execution already succeeded
+func newAnsibleExecution(
+	ctx context.Context,
+	helper *helper.Helper,
+	deployment *dataplanev1.OpenStackDataPlaneDeployment,
+	aeeSpec *dataplanev1.AnsibleEESpec,
+	executionName string,
+	labels map[string]string,
+) (*EEJob, error) {
 	existingAnsibleEE, err := GetAnsibleExecution(ctx, helper, deployment, labels)
 	if err != nil && !k8serrors.IsNotFound(err) {
-		return err
+		return nil, err
 	}
 
 	// Don't patch and re-run jobs if the job status is already completed.
 	if existingAnsibleEE != nil && existingAnsibleEE.Status.Succeeded > 0 {
-		return nil
+		return nil, nil
 	}
 
 	// Use Deployment's ansibleEEEnvConfigMapName if set, otherwise default
@@ -76,7 +124,7 @@ func AnsibleExecution(
 		envConfigMapName = "openstack-aee-default-env"
 	}
 
-	ansibleEE := EEJob{
+	ansibleEE := &EEJob{
 		Name:             executionName,
 		Namespace:        deployment.GetNamespace(),
 		Labels:           labels,
@@ -89,7 +137,7 @@ func AnsibleExecution(
 	for _, netAtt := range ansibleEE.NetworkAttachments {
 		nad, err := nad.GetNADWithName(ctx, helper, netAtt, deployment.Namespace)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		if nad != nil {
@@ -98,15 +146,22 @@ func AnsibleExecution(
 	}
 	ansibleEE.Annotations, err = nad.EnsureNetworksAnnotation(nadList)
 	if err != nil {
-		return fmt.Errorf("failed to create NetworkAttachment annotation. Error: %w", err)
+		return nil, fmt.Errorf("failed to create NetworkAttachment annotation. Error: %w", err)
 	}
 
-	ansibleEE.BuildAeeJobSpec(aeeSpec, deployment, service, nodeSet)
-
-	ansibleEEMounts := storage.VolMounts{}
-	SetAeeSSHMounts(nodeSet, service, sshKeySecrets, &ansibleEEMounts)
-	SetAeeInvMounts(nodeSet, service, inventorySecrets, &ansibleEEMounts)
+	return ansibleEE, nil
+}
 
+// runAnsibleExecution creates or updates the job of an execution, with the
+// SSH key and inventory mounts of the NodeSets
+func runAnsibleExecution(
+	ctx context.Context,
+	helper *helper.Helper,
+	deployment *dataplanev1.OpenStackDataPlaneDeployment,
+	ansibleEE *EEJob,
+	aeeSpec *dataplanev1.AnsibleEESpec,
+	ansibleEEMounts storage.VolMounts,
+) error {
 	ansibleEE.ExtraMounts = append(aeeSpec.ExtraMounts, []storage.VolMounts{ansibleEEMounts}...)
 	ansibleEE.Env = aeeSpec.Env
 	ansibleEE.NodeSelector = deployment.Spec.AnsibleJobNodeSelector
@@ -183,21 +238,12 @@ func GetAnsibleExecutionSummary(
 	helper *helper.Helper,
 	job *batchv1.Job,
 ) (*dataplanev1.AnsibleExecutionSummary, error) {
-	podList := &corev1.PodList{}
-	if err := helper.GetClient().List(
-		ctx,
-		podList,
-		client.InNamespace(job.Namespace),
-		client.MatchingLabels{"batch.kubernetes.io/job-name": job.Name},
-	); err != nil {
+	pods, err := getJobPods(ctx, helper, job)
+	if err != nil {
 		return nil, err
 	}
 
-	sort.Slice(podList.Items, func(i, j int) bool {
-		return podList.Items[j].CreationTimestamp.Before(&podList.Items[i].CreationTimestamp)
-	})
-
-	for _, pod := range podList.Items {
+	for _, pod := range pods {
 		summary, err := ParseAnsibleExecutionSummaryFromPod(&pod)
 		if err != nil {
 			return nil, err
@@ -210,6 +256,28 @@ func GetAnsibleExecutionSummary(
 	return nil, nil
 }
 
+// getJobPods returns the pods of a job, the latest first
+func getJobPods(
+	ctx context.Context,
+	helper *helper.Helper,
+	job *batchv1.Job,
+) ([]corev1.Pod, error) {
+	podList := &corev1.PodList{}
+	if err := helper.GetClient().List(
+		ctx,
+		podList,
+		client.InNamespace(job.Namespace),
+		client.MatchingLabels{"batch.kubernetes.io/job-name": job.Name},
+	); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(podList.Items, func(i, j int) bool {
+		return podList.Items[j].CreationTimestamp.Before(&podList.Items[i].CreationTimestamp)
+	})
+	return podList.Items, nil
+}
+
 // ParseAnsibleExecutionSummaryFromPod parses the AEE pod termination message JSON.
 func ParseAnsibleExecutionSummaryFromPod(
 	pod *corev1.Pod,
@@ -253,23 +321,59 @@ func GetAnsibleExecutionNameAndLabels(service *dataplanev1.OpenStackDataPlaneSer
 		executionName = fmt.Sprintf("%s-%s", executionName, nodeSetName)
 	}
 
-	if len(executionName) > apimachineryvalidation.DNS1123LabelMaxLength {
-		hash := sha256.Sum256([]byte(executionName))
-		hashSuffix := hex.EncodeToString(hash[:])[:8]
-		// 9 = "-" + 8 char hash suffix
-		maxPrefix := apimachineryvalidation.DNS1123LabelMaxLength - 9
-		prefix := strings.TrimRight(executionName[:maxPrefix], "-.")
-		executionName = fmt.Sprintf("%s-%s", prefix, hashSuffix)
+	executionName = GetTruncatedName(executionName)
+
+	labels := map[string]string{
+		"openstackdataplaneservice":    service.Name,
+		"openstackdataplanedeployment": deploymentName,
+		"openstackdataplanenodeset":    nodeSetName,
 	}
+	return executionName, labels
+}
+
+// ExecutionBatch is a subset of the hosts of a NodeSet the services are
+// deployed on at a time
+type ExecutionBatch struct {
+	// Index of the batch in the NodeSet
+	Index int
+	// Hosts of the batch, as named in the inventory
+	Hosts []string
+}
+
+// GetBatchAnsibleExecutionNameAndLabels Name and Labels of the AnsibleEE
+// deploying a service on a batch of hosts
+func GetBatchAnsibleExecutionNameAndLabels(service *dataplanev1.OpenStackDataPlaneService,
+	deploymentName string,
+	nodeSetName string,
+	batchIndex int,
+) (string, map[string]string) {
+	executionName := GetTruncatedName(
+		fmt.Sprintf("%s-%s-%s-batch-%d", service.Name, deploymentName, nodeSetName, batchIndex))
 
 	labels := map[string]string{
 		"openstackdataplaneservice":    service.Name,
 		"openstackdataplanedeployment": deploymentName,
 		"openstackdataplanenodeset":    nodeSetName,
+		"openstackdataplanebatch":      strconv.Itoa(batchIndex),
 	}
 	return executionName, labels
 }
 
+// GetTruncatedName returns name unchanged if it fits in a DNS1123 label,
+// otherwise it is truncated and suffixed with a short hash of the full name
+// so that it remains unique.
+func GetTruncatedName(name string) string {
+	if len(name) <= apimachineryvalidation.DNS1123LabelMaxLength {
+		return name
+	}
+	hash := sha256.Sum256([]byte(name))
+	hashSuffix := hex.EncodeToString(hash[:])[:8]
+	// 9 = "-" + 8 char hash suffix
+	maxPrefix := apimachineryvalidation.DNS1123LabelMaxLength - 9
+	prefix := strings.TrimRight(name[:maxPrefix], "-.")
+	return fmt.Sprintf("%s-%s", prefix, hashSuffix)
+}
+
 // BuildAeeJobSpec builds the job specification for Ansible Execution Environment
 func (a *EEJob) BuildAeeJobSpec(
 	aeeSpec *dataplanev1.AnsibleEESpec,
@@ -293,9 +397,27 @@ func (a *EEJob) BuildAeeJobSpec(
 	if len(service.Spec.Role) > 0 {
 		a.Role = service.Spec.Role
 	}
+	if service.Spec.PlaybookSource != nil {
+		a.PlaybookSources = []PlaybookSource{{Service: service.Name, Spec: *service.Spec.PlaybookSource}}
+		if len(a.Playbook) > 0 {
+			a.Playbook = GetPlaybookSourcePath(service.Name, a.Playbook)
+		}
+	}
 
 	a.BackoffLimit = deployment.Spec.BackoffLimit
 	a.PreserveJobs = deployment.Spec.PreserveJobs
+	if deployment.Spec.ArtifactSink != nil {
+		a.ArtifactSink = deployment.Spec.ArtifactSink
+		a.ArtifactPath = GetArtifactPath(deployment.Spec.ArtifactSink,
+			deployment.Name, nodeSet.GetName(), service.Name)
+	}
+	// The fields set on the service override the ones of the deployment
+	// and the NodeSet
+	a.SetJobTemplate(aeeSpec.JobTemplate.Merge(service.Spec.AnsibleEEJobTemplate))
+	// The jobs report their progress with the service account of the NodeSet
+	if deployment.Spec.ReportProgress && aeeSpec.ServiceAccountName == nodeSet.GetName() {
+		a.ProgressConfigMap = GetProgressConfigMapName(nodeSet.GetName())
+	}
 	a.FormatAEECmdLineArguments(aeeSpec)
 	a.FormatAEEExtraVars(aeeSpec, service, deployment, nodeSet)
 	a.DetermineAeeImage(aeeSpec)
@@ -361,6 +483,11 @@ func (a *EEJob) DetermineAeeImage(aeeSpec *dataplanev1.AnsibleEESpec) {
 	} else {
 		a.Image = *dataplanev1.ContainerImageDefaults.AnsibleeeImage
 	}
+	if len(aeeSpec.PlaybookSourceImage) > 0 {
+		a.PlaybookSourceImage = aeeSpec.PlaybookSourceImage
+	} else {
+		a.PlaybookSourceImage = *dataplanev1.ContainerImageDefaults.OrasImage
+	}
 }
 
 // SetAeeSSHMounts determines the required SSH key mounts for the Ansible Execution Job.
@@ -18,6 +18,7 @@ package util //nolint:revive // util is an acceptable package name in this conte
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	. "github.com/onsi/gomega" //revive:disable:dot-imports
@@ -25,6 +26,7 @@ import (
 	dataplanev1 "github.com/openstack-k8s-operators/openstack-operator/api/dataplane/v1beta1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 )
@@ -108,3 +110,111 @@ func TestGetAnsibleExecutionSummary(t *testing.T) {
 		UnreachableHostList: &[]string{},
 	}))
 }
+
+func TestGetTruncatedName(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(GetTruncatedName("cleanup-edpm-compute-compute-0")).To(Equal("cleanup-edpm-compute-compute-0"))
+
+	longName := strings.Repeat("a", 70)
+	truncated := GetTruncatedName(longName)
+	g.Expect(truncated).To(HaveLen(63))
+	g.Expect(truncated).To(HavePrefix(strings.Repeat("a", 54) + "-"))
+	g.Expect(GetTruncatedName(longName + "b")).ToNot(Equal(truncated))
+}
+
+func TestGetBatchAnsibleExecutionNameAndLabels(t *testing.T) {
+	g := NewWithT(t)
+
+	service := &dataplanev1.OpenStackDataPlaneService{
+		ObjectMeta: metav1.ObjectMeta{Name: "update"},
+	}
+	name, labels := GetBatchAnsibleExecutionNameAndLabels(service, "edpm-update", "edpm-compute", 1)
+	g.Expect(name).To(Equal("update-edpm-update-edpm-compute-batch-1"))
+	g.Expect(labels).To(Equal(map[string]string{
+		"openstackdataplaneservice":    "update",
+		"openstackdataplanedeployment": "edpm-update",
+		"openstackdataplanenodeset":    "edpm-compute",
+		"openstackdataplanebatch":      "1",
+	}))
+}
+
+func TestBuildAeeJobSpecWithJobTemplate(t *testing.T) {
+	g := NewWithT(t)
+	h := setupTestHelper(false)
+
+	nodeSetResources := &corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+	}
+	serviceResources := &corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+	}
+	tolerations := []corev1.Toleration{
+		{Key: "node-role.kubernetes.io/infra", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+	}
+	affinity := &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+					MatchExpressions: []corev1.NodeSelectorRequirement{{
+						Key:      "node-role.kubernetes.io/infra",
+						Operator: corev1.NodeSelectorOpExists,
+					}},
+				}},
+			},
+		},
+	}
+
+	nodeSet := &dataplanev1.OpenStackDataPlaneNodeSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "compute", Namespace: "test-namespace"},
+		Spec: dataplanev1.OpenStackDataPlaneNodeSetSpec{
+			AnsibleEEJobTemplate: &dataplanev1.AnsibleEEJobTemplate{
+				Resources:         nodeSetResources,
+				Tolerations:       tolerations,
+				PriorityClassName: "edpm-low",
+			},
+		},
+	}
+	deployment := &dataplanev1.OpenStackDataPlaneDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "edpm-deployment", Namespace: "test-namespace"},
+		Spec: dataplanev1.OpenStackDataPlaneDeploymentSpec{
+			AnsibleEEJobTemplate: &dataplanev1.AnsibleEEJobTemplate{
+				Affinity:              affinity,
+				PriorityClassName:     "edpm-high",
+				ActiveDeadlineSeconds: ptr.To[int64](3600),
+			},
+		},
+	}
+	service := &dataplanev1.OpenStackDataPlaneService{
+		ObjectMeta: metav1.ObjectMeta{Name: "configure-network"},
+		Spec: dataplanev1.OpenStackDataPlaneServiceSpec{
+			Playbook: "osp.edpm.configure_network",
+			AnsibleEEJobTemplate: &dataplanev1.AnsibleEEJobTemplate{
+				Resources: serviceResources,
+			},
+		},
+	}
+
+	aeeSpec := nodeSet.GetAnsibleEESpec()
+	aeeSpec.OpenStackAnsibleEERunnerImage = "ansibleee:latest"
+	aeeSpec.JobTemplate = aeeSpec.JobTemplate.Merge(deployment.Spec.AnsibleEEJobTemplate)
+
+	eeJob := EEJob{
+		Name:      "configure-network-edpm-deployment-compute",
+		Namespace: "test-namespace",
+	}
+	eeJob.BuildAeeJobSpec(&aeeSpec, deployment, service, nodeSet)
+	job, err := eeJob.JobForOpenStackAnsibleEE(h)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	podSpec := job.Spec.Template.Spec
+	g.Expect(podSpec.Containers[0].Resources).To(Equal(*serviceResources))
+	g.Expect(podSpec.Tolerations).To(Equal(tolerations))
+	g.Expect(podSpec.Affinity).To(Equal(affinity))
+	g.Expect(podSpec.PriorityClassName).To(Equal("edpm-high"))
+	g.Expect(job.Spec.ActiveDeadlineSeconds).To(Equal(ptr.To[int64](3600)))
+
+	// The templates of the NodeSet and the deployment are left untouched
+	g.Expect(nodeSet.Spec.AnsibleEEJobTemplate.Resources).To(Equal(nodeSetResources))
+	g.Expect(nodeSet.Spec.AnsibleEEJobTemplate.PriorityClassName).To(Equal("edpm-low"))
+}
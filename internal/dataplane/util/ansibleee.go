@@ -12,6 +12,7 @@ import (
 
 	helper "github.com/openstack-k8s-operators/lib-common/modules/common/helper"
 	util "github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	dataplanev1 "github.com/openstack-k8s-operators/openstack-operator/api/dataplane/v1beta1"
 )
 
 // EEJob defines properties that will be applied to the Kubernetes jobs for Ansible EE pods
@@ -62,6 +63,39 @@ type EEJob struct {
 	Env []corev1.EnvVar `json:"env,omitempty"`
 	// NodeSelector to target subset of worker nodes running the ansible jobs
 	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// ArtifactSink is where the job_events and stdout artifacts are persisted once ansible-runner finished
+	ArtifactSink *dataplanev1.ArtifactSinkSpec `json:"artifactSink,omitempty"`
+	// ArtifactPath is the path the artifacts are persisted under in the ArtifactSink
+	ArtifactPath string `json:"artifactPath,omitempty"`
+	// Resources are the compute resources of the ansible-runner container
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+	// Tolerations of the job pod
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// Affinity of the job pod
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+	// PriorityClassName of the job pod
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+	// ActiveDeadlineSeconds is the duration the job may run before it is terminated
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty"`
+	// ProgressConfigMap is the name of the ConfigMap the job reports its progress to
+	ProgressConfigMap string `json:"progressConfigMap,omitempty"`
+	// PlaybookSources are fetched into the runner project by init containers before the execution
+	PlaybookSources []PlaybookSource `json:"playbookSources,omitempty"`
+	// PlaybookSourceImage is the oras image pulling the OCI playbook sources
+	PlaybookSourceImage string `json:"playbookSourceImage,omitempty"`
+}
+
+// SetJobTemplate sets the resources, scheduling and timeout of the job from
+// an AnsibleEEJobTemplate
+func (a *EEJob) SetJobTemplate(jobTemplate *dataplanev1.AnsibleEEJobTemplate) {
+	if jobTemplate == nil {
+		return
+	}
+	a.Resources = jobTemplate.Resources
+	a.Tolerations = jobTemplate.Tolerations
+	a.Affinity = jobTemplate.Affinity
+	a.PriorityClassName = jobTemplate.PriorityClassName
+	a.ActiveDeadlineSeconds = jobTemplate.ActiveDeadlineSeconds
 }
 
 // JobForOpenStackAnsibleEE returns a Job object
@@ -99,6 +133,15 @@ func (a *EEJob) JobForOpenStackAnsibleEE(h *helper.Helper) (*batchv1.Job, error)
 		args = append(args, []string{"-i", identifier}...)
 	}
 
+	runnerArgs := args
+	args = wrapArgsForTaskResults(args)
+	if a.ArtifactSink != nil {
+		args = wrapArgsForArtifactSink(args)
+	}
+	if len(a.ProgressConfigMap) > 0 {
+		args = wrapArgsForProgress(args)
+	}
+
 	podSpec := corev1.PodSpec{
 		RestartPolicy: corev1.RestartPolicyNever,
 		Containers: []corev1.Container{{
@@ -119,6 +162,19 @@ func (a *EEJob) JobForOpenStackAnsibleEE(h *helper.Helper) (*batchv1.Job, error)
 		podSpec.DNSPolicy = "None"
 	}
 
+	if a.Resources != nil {
+		podSpec.Containers[0].Resources = *a.Resources
+	}
+	if len(a.Tolerations) > 0 {
+		podSpec.Tolerations = a.Tolerations
+	}
+	if a.Affinity != nil {
+		podSpec.Affinity = a.Affinity
+	}
+	if len(a.PriorityClassName) > 0 {
+		podSpec.PriorityClassName = a.PriorityClassName
+	}
+
 	job := &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        a.Name,
@@ -127,7 +183,8 @@ func (a *EEJob) JobForOpenStackAnsibleEE(h *helper.Helper) (*batchv1.Job, error)
 			Labels:      ls,
 		},
 		Spec: batchv1.JobSpec{
-			BackoffLimit: a.BackoffLimit,
+			BackoffLimit:          a.BackoffLimit,
+			ActiveDeadlineSeconds: a.ActiveDeadlineSeconds,
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Annotations: a.Annotations,
@@ -171,6 +228,16 @@ func (a *EEJob) JobForOpenStackAnsibleEE(h *helper.Helper) (*batchv1.Job, error)
 		return nil, errMounts
 	}
 	a.addEnvFrom(job)
+	addTaskResults(job, runnerArgs)
+	if a.ArtifactSink != nil {
+		a.addArtifactSink(job, runnerArgs)
+	}
+	if len(a.ProgressConfigMap) > 0 {
+		a.addProgressReporter(job, runnerArgs)
+	}
+	if len(a.PlaybookSources) > 0 {
+		a.addPlaybookSources(job)
+	}
 
 	// if we have any extra vars for ansible to use set them in the RUNNER_EXTRA_VARS
 	if len(a.ExtraVars) > 0 {
@@ -0,0 +1,145 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util //nolint:revive // util is an acceptable package name in this context
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	dataplanev1 "github.com/openstack-k8s-operators/openstack-operator/api/dataplane/v1beta1"
+)
+
+const (
+	// artifactSinkVolume is the name of the volume of the PersistentVolumeClaim artifact sink
+	artifactSinkVolume = "artifact-sink"
+	// artifactSinkMountPath is where the PersistentVolumeClaim artifact sink is mounted
+	artifactSinkMountPath = "/runner/artifact-sink"
+)
+
+// artifactSinkScript runs ansible-runner with the arguments it is passed and
+// then persists the job_events and stdout artifacts of the execution. The
+// exit code of ansible-runner is preserved, failing to persist an artifact
+// doesn't fail the job. RUNNER_ARTIFACT_DIR defaults to the artifacts
+// directory of ansible-runner.
+const artifactSinkScript = `"$@"
+rc=$?
+cd "${RUNNER_ARTIFACT_DIR:-/runner/artifacts}/${ARTIFACT_IDENT}" || exit $rc
+for f in stdout job_events/*; do
+    [ -f "$f" ] || continue
+    if [ -n "${ARTIFACT_S3_URL}" ]; then
+        curl -sSf ${ARTIFACT_S3_INSECURE:+-k} --aws-sigv4 "aws:amz:${ARTIFACT_S3_REGION}:s3" \
+            --user "${AWS_ACCESS_KEY_ID}:${AWS_SECRET_ACCESS_KEY}" \
+            -T "$f" "${ARTIFACT_S3_URL}/${ARTIFACT_DEST}/$f"
+    else
+        mkdir -p "${ARTIFACT_DEST}/$(dirname "$f")" && cp "$f" "${ARTIFACT_DEST}/$f"
+    fi || echo "Could not persist artifact $f"
+done
+exit $rc
+`
+
+// GetArtifactPath returns the path the artifacts of the executions of a
+// service on a NodeSet are persisted under, relative to the artifact sink
+func GetArtifactPath(sink *dataplanev1.ArtifactSinkSpec,
+	deploymentName string,
+	nodeSetName string,
+	serviceName string,
+) string {
+	return path.Join(sink.Prefix, deploymentName, nodeSetName, serviceName)
+}
+
+// GetArtifactLocation returns the location of an artifact path in the sink,
+// as pvc://<claim>/<path> or s3://<bucket>/<path>
+func GetArtifactLocation(sink *dataplanev1.ArtifactSinkSpec, artifactPath string) string {
+	if sink.S3 != nil {
+		return fmt.Sprintf("s3://%s/%s", sink.S3.Bucket, artifactPath)
+	}
+	return fmt.Sprintf("pvc://%s/%s", sink.PVC.ClaimName, artifactPath)
+}
+
+// wrapArgsForArtifactSink returns the arguments running args with the artifact
+// sink script
+func wrapArgsForArtifactSink(args []string) []string {
+	return append([]string{"/bin/bash", "-c", artifactSinkScript, "artifact-sink"}, args...)
+}
+
+// getRunnerIdentifier returns the identifier passed to ansible-runner
+func getRunnerIdentifier(args []string) string {
+	for idx, arg := range args {
+		if (arg == "-i" || arg == "--ident") && idx+1 < len(args) {
+			return args[idx+1]
+		}
+	}
+	return ""
+}
+
+// addArtifactSink sets the volume and environment the artifact sink script
+// needs to persist the artifacts of the job
+func (a *EEJob) addArtifactSink(job *batchv1.Job, args []string) {
+	container := &job.Spec.Template.Spec.Containers[0]
+	env := []corev1.EnvVar{
+		{Name: "ARTIFACT_IDENT", Value: getRunnerIdentifier(args)},
+	}
+
+	if a.ArtifactSink.S3 != nil {
+		s3 := a.ArtifactSink.S3
+		env = append(env,
+			corev1.EnvVar{Name: "ARTIFACT_DEST", Value: a.ArtifactPath},
+			corev1.EnvVar{
+				Name:  "ARTIFACT_S3_URL",
+				Value: fmt.Sprintf("%s/%s", strings.TrimSuffix(s3.Endpoint, "/"), s3.Bucket),
+			},
+			corev1.EnvVar{Name: "ARTIFACT_S3_REGION", Value: s3.Region},
+		)
+		if s3.InsecureSkipVerify {
+			env = append(env, corev1.EnvVar{Name: "ARTIFACT_S3_INSECURE", Value: "true"})
+		}
+		for _, key := range []string{"AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY"} {
+			env = append(env, corev1.EnvVar{
+				Name: key,
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: s3.CredentialsSecretName},
+						Key:                  key,
+					},
+				},
+			})
+		}
+	} else {
+		env = append(env, corev1.EnvVar{
+			Name:  "ARTIFACT_DEST",
+			Value: path.Join(artifactSinkMountPath, a.ArtifactPath),
+		})
+		job.Spec.Template.Spec.Volumes = append(job.Spec.Template.Spec.Volumes, corev1.Volume{
+			Name: artifactSinkVolume,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: a.ArtifactSink.PVC.ClaimName,
+				},
+			},
+		})
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      artifactSinkVolume,
+			MountPath: artifactSinkMountPath,
+		})
+	}
+
+	container.Env = append(container.Env, env...)
+}
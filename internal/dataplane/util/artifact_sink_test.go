@@ -0,0 +1,217 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util //nolint:revive // util is an acceptable package name in this context
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	. "github.com/onsi/gomega" //revive:disable:dot-imports
+
+	dataplanev1 "github.com/openstack-k8s-operators/openstack-operator/api/dataplane/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestGetArtifactLocation(t *testing.T) {
+	g := NewWithT(t)
+
+	pvcSink := &dataplanev1.ArtifactSinkSpec{
+		PVC:    &dataplanev1.PVCArtifactSinkSpec{ClaimName: "artifacts"},
+		Prefix: "edpm",
+	}
+	artifactPath := GetArtifactPath(pvcSink, "edpm-deployment", "compute", "configure-network")
+	g.Expect(artifactPath).To(Equal("edpm/edpm-deployment/compute/configure-network"))
+	g.Expect(GetArtifactLocation(pvcSink, artifactPath)).To(
+		Equal("pvc://artifacts/edpm/edpm-deployment/compute/configure-network"))
+
+	s3Sink := &dataplanev1.ArtifactSinkSpec{
+		S3: &dataplanev1.S3ArtifactSinkSpec{Endpoint: "http://minio:9000", Bucket: "logs"},
+	}
+	artifactPath = GetArtifactPath(s3Sink, "edpm-deployment", "compute", "configure-network")
+	g.Expect(artifactPath).To(Equal("edpm-deployment/compute/configure-network"))
+	g.Expect(GetArtifactLocation(s3Sink, artifactPath)).To(
+		Equal("s3://logs/edpm-deployment/compute/configure-network"))
+}
+
+func TestJobForOpenStackAnsibleEEWithArtifactSink(t *testing.T) {
+	g := NewWithT(t)
+	h := setupTestHelper(false)
+
+	eeJob := EEJob{
+		Name:      "configure-network-edpm-deployment-compute",
+		Namespace: "test-namespace",
+		Playbook:  "osp.edpm.configure_network",
+		ArtifactSink: &dataplanev1.ArtifactSinkSpec{
+			PVC: &dataplanev1.PVCArtifactSinkSpec{ClaimName: "artifacts"},
+		},
+		ArtifactPath: "edpm-deployment/compute/configure-network",
+	}
+	job, err := eeJob.JobForOpenStackAnsibleEE(h)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	podSpec := job.Spec.Template.Spec
+	container := podSpec.Containers[0]
+	g.Expect(container.Args[:4]).To(Equal([]string{"/bin/bash", "-c", artifactSinkScript, "artifact-sink"}))
+	g.Expect(container.Args[4:8]).To(Equal([]string{"/bin/bash", "-c", taskResultsScript, "task-results"}))
+	g.Expect(container.Args[8:]).To(Equal([]string{
+		"ansible-runner", "run", "/runner", "-p", "osp.edpm.configure_network",
+		"-i", "configure-network-edpm-deployment-compute",
+	}))
+	g.Expect(container.Env).To(ContainElements(
+		corev1.EnvVar{Name: "ARTIFACT_IDENT", Value: "configure-network-edpm-deployment-compute"},
+		corev1.EnvVar{Name: "ARTIFACT_DEST", Value: "/runner/artifact-sink/edpm-deployment/compute/configure-network"},
+	))
+	g.Expect(container.VolumeMounts).To(ContainElement(
+		corev1.VolumeMount{Name: artifactSinkVolume, MountPath: artifactSinkMountPath}))
+	g.Expect(podSpec.Volumes).To(ContainElement(corev1.Volume{
+		Name: artifactSinkVolume,
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "artifacts"},
+		},
+	}))
+
+	eeJob.ArtifactSink = &dataplanev1.ArtifactSinkSpec{
+		S3: &dataplanev1.S3ArtifactSinkSpec{
+			Endpoint:              "http://minio:9000/",
+			Bucket:                "logs",
+			Region:                "us-east-1",
+			CredentialsSecretName: "minio-credentials",
+			InsecureSkipVerify:    true,
+		},
+	}
+	job, err = eeJob.JobForOpenStackAnsibleEE(h)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	container = job.Spec.Template.Spec.Containers[0]
+	g.Expect(container.Env).To(ContainElements(
+		corev1.EnvVar{Name: "ARTIFACT_DEST", Value: "edpm-deployment/compute/configure-network"},
+		corev1.EnvVar{Name: "ARTIFACT_S3_URL", Value: "http://minio:9000/logs"},
+		corev1.EnvVar{Name: "ARTIFACT_S3_REGION", Value: "us-east-1"},
+		corev1.EnvVar{Name: "ARTIFACT_S3_INSECURE", Value: "true"},
+		corev1.EnvVar{
+			Name: "AWS_ACCESS_KEY_ID",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "minio-credentials"},
+					Key:                  "AWS_ACCESS_KEY_ID",
+				},
+			},
+		},
+	))
+	g.Expect(job.Spec.Template.Spec.Volumes).ToNot(ContainElement(HaveField("Name", artifactSinkVolume)))
+}
+
+// runArtifactSinkScript runs the artifact sink script in place of the job
+// container, with the artifacts of an execution written in a temporary
+// runner directory, and returns its output and exit code
+func runArtifactSinkScript(t *testing.T, env []string, args ...string) (string, int) {
+	t.Helper()
+	g := NewWithT(t)
+
+	runnerDir := t.TempDir()
+	artifactDir := filepath.Join(runnerDir, "configure-network-edpm-deployment-compute")
+	g.Expect(os.MkdirAll(filepath.Join(artifactDir, "job_events"), 0o755)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(artifactDir, "stdout"), []byte("PLAY RECAP"), 0o600)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(artifactDir, "job_events", "1-playbook_on_start.json"),
+		[]byte(`{"event": "playbook_on_start"}`), 0o600)).To(Succeed())
+
+	cmd := exec.Command("/bin/bash", wrapArgsForArtifactSink(args)[1:]...)
+	cmd.Env = append([]string{
+		"PATH=" + os.Getenv("PATH"),
+		"RUNNER_ARTIFACT_DIR=" + runnerDir,
+		"ARTIFACT_IDENT=configure-network-edpm-deployment-compute",
+	}, env...)
+	out, err := cmd.CombinedOutput()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return string(out), exitErr.ExitCode()
+	}
+	g.Expect(err).ToNot(HaveOccurred())
+	return string(out), 0
+}
+
+func TestArtifactSinkScriptPVC(t *testing.T) {
+	g := NewWithT(t)
+	sinkDir := filepath.Join(t.TempDir(), "edpm-deployment", "compute", "configure-network")
+
+	// the exit code of ansible-runner is preserved
+	_, rc := runArtifactSinkScript(t, []string{"ARTIFACT_DEST=" + sinkDir}, "/bin/sh", "-c", "exit 2")
+	g.Expect(rc).To(Equal(2))
+
+	stdout, err := os.ReadFile(filepath.Join(sinkDir, "stdout"))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(stdout)).To(Equal("PLAY RECAP"))
+	event, err := os.ReadFile(filepath.Join(sinkDir, "job_events", "1-playbook_on_start.json"))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(event)).To(Equal(`{"event": "playbook_on_start"}`))
+}
+
+func TestArtifactSinkScriptS3(t *testing.T) {
+	g := NewWithT(t)
+	if _, err := exec.LookPath("curl"); err != nil {
+		t.Skip("curl is required to upload the artifacts")
+	}
+
+	var mu sync.Mutex
+	uploads := map[string]string{}
+	authorizations := []string{}
+	status := http.StatusOK
+	sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Method == http.MethodPut {
+			uploads[r.URL.Path] = string(body)
+			authorizations = append(authorizations, r.Header.Get("Authorization"))
+		}
+		w.WriteHeader(status)
+	}))
+	defer sink.Close()
+
+	env := []string{
+		"ARTIFACT_DEST=edpm-deployment/compute/configure-network",
+		"ARTIFACT_S3_URL=" + sink.URL + "/logs",
+		"ARTIFACT_S3_REGION=us-east-1",
+		"AWS_ACCESS_KEY_ID=access",
+		"AWS_SECRET_ACCESS_KEY=secret",
+	}
+	_, rc := runArtifactSinkScript(t, env, "/bin/true")
+	g.Expect(rc).To(Equal(0))
+	g.Expect(uploads).To(Equal(map[string]string{
+		"/logs/edpm-deployment/compute/configure-network/stdout":                              "PLAY RECAP",
+		"/logs/edpm-deployment/compute/configure-network/job_events/1-playbook_on_start.json": `{"event": "playbook_on_start"}`,
+	}))
+	for _, authorization := range authorizations {
+		g.Expect(authorization).To(HavePrefix("AWS4-HMAC-SHA256 Credential=access/"))
+	}
+
+	// failing to persist the artifacts doesn't fail the job
+	mu.Lock()
+	status = http.StatusInternalServerError
+	mu.Unlock()
+	out, rc := runArtifactSinkScript(t, env, "/bin/true")
+	g.Expect(rc).To(Equal(0))
+	g.Expect(strings.Count(out, "Could not persist artifact")).To(Equal(2))
+}
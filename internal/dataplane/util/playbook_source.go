@@ -0,0 +1,288 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util //nolint:revive // util is an acceptable package name in this context
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	dataplanev1 "github.com/openstack-k8s-operators/openstack-operator/api/dataplane/v1beta1"
+)
+
+const (
+	// PlaybookSourcesDir is the directory of the runner project the playbook
+	// sources are fetched into, one sub directory per service
+	PlaybookSourcesDir = "sources"
+	// playbookSourceVolume is the name of the volume the playbook sources
+	// are fetched into
+	playbookSourceVolume = "playbook-source"
+	// playbookSourceMountPath is where the init containers mount the
+	// playbook source volume
+	playbookSourceMountPath = "/playbook-source"
+	// playbookSourceAuthMountPath is where the oras init container mounts
+	// the pull secret of an OCI playbook source
+	playbookSourceAuthMountPath = "/playbook-source-auth"
+	// playbookSourceCollectionsDir is where the runner container finds the
+	// collections installed from the playbook sources
+	playbookSourceCollectionsDir = "/runner/project/collections"
+	// ansibleDefaultCollectionsPath is the default ansible collections path
+	ansibleDefaultCollectionsPath = "~/.ansible/collections:/usr/share/ansible/collections"
+	// ansibleDefaultRolesPath is the default ansible roles path
+	ansibleDefaultRolesPath = "~/.ansible/roles:/usr/share/ansible/roles:/etc/ansible/roles"
+)
+
+// playbookSourceScript fetches a playbook source from a ref of a Git
+// repository into SOURCE_DIR, or takes the OCI artifact pulled there by the
+// oras init container, and installs it into COLLECTIONS_DIR when it is a
+// collection. A username defaults to x-access-token with a token alone. The
+// fetched revision is written to the termination message of the container.
+const playbookSourceScript = `set -euo pipefail
+mkdir -p "${SOURCE_DIR}" "${COLLECTIONS_DIR}"
+if [ -n "${GIT_URL:-}" ]; then
+    git init -q "${SOURCE_DIR}"
+    cd "${SOURCE_DIR}"
+    if [ -n "${GIT_PASSWORD:-}${GIT_TOKEN:-}" ]; then
+        git config credential.helper '!f() { echo "username=${GIT_USERNAME:-x-access-token}"; echo "password=${GIT_PASSWORD:-${GIT_TOKEN}}"; }; f'
+    fi
+    git fetch -q --depth 1 "${GIT_URL}" "${GIT_REF}"
+    git checkout -q FETCH_HEAD
+    revision=$(git rev-parse HEAD)
+else
+    revision="${OCI_IMAGE##*@}"
+fi
+if [ -f "${SOURCE_DIR}/galaxy.yml" ]; then
+    ansible-galaxy collection install --no-deps -p "${COLLECTIONS_DIR}" "${SOURCE_DIR}"
+fi
+echo -n "${revision}" > /dev/termination-log
+`
+
+// PlaybookSource is the playbook source of a service fetched into the runner
+// before the execution
+type PlaybookSource struct {
+	// Service the playbook source belongs to
+	Service string
+	// Spec of the playbook source
+	Spec dataplanev1.PlaybookSourceSpec
+}
+
+// GetPlaybookSourcePath returns the playbook of a service relative to the
+// runner project when the service fetches its playbook source. The FQCN of a
+// playbook of a collection is returned unchanged.
+func GetPlaybookSourcePath(serviceName string, playbook string) string {
+	if !strings.Contains(playbook, "/") && !strings.HasSuffix(playbook, ".yml") &&
+		!strings.HasSuffix(playbook, ".yaml") {
+		return playbook
+	}
+	return path.Join(PlaybookSourcesDir, serviceName, playbook)
+}
+
+// getPlaybookSourceContainerName returns the name of the init container
+// fetching the playbook source of a service
+func getPlaybookSourceContainerName(serviceName string) string {
+	return GetTruncatedName(fmt.Sprintf("playbook-source-%s", serviceName))
+}
+
+// getPlaybookSourcePullContainerName returns the name of the init container
+// pulling the OCI playbook source of a service
+func getPlaybookSourcePullContainerName(serviceName string) string {
+	return GetTruncatedName(fmt.Sprintf("playbook-pull-%s", serviceName))
+}
+
+// addPlaybookSources adds an init container fetching each playbook source
+// into a volume the runner container mounts in its project. An OCI artifact is
+// pulled by an oras init container first. The collections installed from the
+// sources, and the roles of the sources found in their roles directory or at
+// their root, come first in the ansible paths.
+func (a *EEJob) addPlaybookSources(job *batchv1.Job) {
+	podSpec := &job.Spec.Template.Spec
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: playbookSourceVolume,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	})
+	volumeMounts := []corev1.VolumeMount{{
+		Name:      playbookSourceVolume,
+		MountPath: playbookSourceMountPath,
+	}}
+
+	for _, source := range a.PlaybookSources {
+		name := getPlaybookSourceContainerName(source.Service)
+		sourceDir := path.Join(playbookSourceMountPath, PlaybookSourcesDir, source.Service)
+		container := corev1.Container{
+			ImagePullPolicy: corev1.PullIfNotPresent,
+			Image:           a.Image,
+			Name:            name,
+			Args:            []string{"/bin/bash", "-c", playbookSourceScript, "playbook-source"},
+			Env: []corev1.EnvVar{
+				{
+					Name:  "SOURCE_DIR",
+					Value: sourceDir,
+				},
+				{
+					Name:  "COLLECTIONS_DIR",
+					Value: path.Join(playbookSourceMountPath, "collections"),
+				},
+			},
+			VolumeMounts:             volumeMounts,
+			TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+		}
+
+		if git := source.Spec.Git; git != nil {
+			container.Env = append(container.Env,
+				corev1.EnvVar{Name: "GIT_URL", Value: git.URL},
+				corev1.EnvVar{Name: "GIT_REF", Value: git.Ref},
+			)
+			if len(git.CredentialsSecretName) > 0 {
+				// the Secret holds a username and a password, or a token
+				for _, key := range []string{"username", "password", "token"} {
+					container.Env = append(container.Env, corev1.EnvVar{
+						Name: "GIT_" + strings.ToUpper(key),
+						ValueFrom: &corev1.EnvVarSource{
+							SecretKeyRef: &corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: git.CredentialsSecretName},
+								Key:                  key,
+								Optional:             ptr.To(true),
+							},
+						},
+					})
+				}
+			}
+		} else if oci := source.Spec.OCI; oci != nil {
+			container.Env = append(container.Env, corev1.EnvVar{Name: "OCI_IMAGE", Value: oci.Image})
+			pull := corev1.Container{
+				ImagePullPolicy: corev1.PullIfNotPresent,
+				Image:           a.PlaybookSourceImage,
+				Name:            getPlaybookSourcePullContainerName(source.Service),
+				Command:         []string{"oras"},
+				Args:            []string{"pull", "--output", sourceDir, oci.Image},
+				VolumeMounts:    volumeMounts,
+			}
+			if len(oci.PullSecretName) > 0 {
+				authVolume := GetTruncatedName(fmt.Sprintf("%s-auth", name))
+				podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+					Name: authVolume,
+					VolumeSource: corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{SecretName: oci.PullSecretName},
+					},
+				})
+				pull.Args = append(pull.Args, "--registry-config",
+					path.Join(playbookSourceAuthMountPath, corev1.DockerConfigJsonKey))
+				pull.VolumeMounts = append(slices.Clone(volumeMounts), corev1.VolumeMount{
+					Name:      authVolume,
+					MountPath: playbookSourceAuthMountPath,
+					ReadOnly:  true,
+				})
+			}
+			podSpec.InitContainers = append(podSpec.InitContainers, pull)
+		}
+		podSpec.InitContainers = append(podSpec.InitContainers, container)
+	}
+
+	runner := &podSpec.Containers[0]
+	runner.VolumeMounts = append(runner.VolumeMounts,
+		corev1.VolumeMount{
+			Name:      playbookSourceVolume,
+			MountPath: path.Join("/runner/project", PlaybookSourcesDir),
+			SubPath:   PlaybookSourcesDir,
+		},
+		corev1.VolumeMount{
+			Name:      playbookSourceVolume,
+			MountPath: "/runner/project/collections",
+			SubPath:   "collections",
+		},
+	)
+	rolesPath := []string{}
+	for _, source := range a.PlaybookSources {
+		sourcePath := path.Join("/runner/project", PlaybookSourcesDir, source.Service)
+		rolesPath = append(rolesPath, path.Join(sourcePath, "roles"), sourcePath)
+	}
+	runner.Env = addEnvPath(runner.Env, "ANSIBLE_COLLECTIONS_PATH",
+		playbookSourceCollectionsDir, ansibleDefaultCollectionsPath)
+	runner.Env = addEnvPath(runner.Env, "ANSIBLE_ROLES_PATH",
+		strings.Join(rolesPath, ":"), ansibleDefaultRolesPath)
+}
+
+// addEnvPath returns env with dirs added in front of the path environment
+// variable name, or of defaultPath when it isn't set. A path set from a
+// ConfigMap or a Secret is left unchanged.
+func addEnvPath(env []corev1.EnvVar, name string, dirs string, defaultPath string) []corev1.EnvVar {
+	env = slices.Clone(env)
+	for idx := range env {
+		if env[idx].Name != name {
+			continue
+		}
+		if env[idx].ValueFrom == nil {
+			env[idx].Value = dirs + ":" + env[idx].Value
+		}
+		return env
+	}
+	return append(env, corev1.EnvVar{Name: name, Value: dirs + ":" + defaultPath})
+}
+
+// GetPlaybookSourceRevisions returns the revision of the playbook source of
+// each service fetched by the latest pod of a job
+func GetPlaybookSourceRevisions(
+	ctx context.Context,
+	helper *helper.Helper,
+	job *batchv1.Job,
+	serviceNames []string,
+) (map[string]string, error) {
+	pods, err := getJobPods(ctx, helper, job)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pod := range pods {
+		revisions := ParsePlaybookSourceRevisionsFromPod(&pod, serviceNames)
+		if len(revisions) > 0 {
+			return revisions, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// ParsePlaybookSourceRevisionsFromPod parses the revisions the init containers
+// fetching the playbook sources of services wrote to their termination message
+func ParsePlaybookSourceRevisionsFromPod(
+	pod *corev1.Pod,
+	serviceNames []string,
+) map[string]string {
+	revisions := make(map[string]string)
+	for _, serviceName := range serviceNames {
+		name := getPlaybookSourceContainerName(serviceName)
+		for _, containerStatus := range pod.Status.InitContainerStatuses {
+			terminated := containerStatus.State.Terminated
+			if containerStatus.Name != name || terminated == nil || terminated.ExitCode != 0 {
+				continue
+			}
+			if revision := strings.TrimSpace(terminated.Message); len(revision) > 0 {
+				revisions[serviceName] = revision
+			}
+		}
+	}
+	return revisions
+}
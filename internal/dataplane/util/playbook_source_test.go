@@ -0,0 +1,231 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util //nolint:revive // util is an acceptable package name in this context
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega" //revive:disable:dot-imports
+
+	dataplanev1 "github.com/openstack-k8s-operators/openstack-operator/api/dataplane/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+const testOCIImage = "quay.io/example/playbooks@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+func TestGetPlaybookSourcePath(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(GetPlaybookSourcePath("custom", "playbooks/custom.yml")).To(Equal("sources/custom/playbooks/custom.yml"))
+	g.Expect(GetPlaybookSourcePath("custom", "site.yaml")).To(Equal("sources/custom/site.yaml"))
+	g.Expect(GetPlaybookSourcePath("custom", "example.custom.deploy")).To(Equal("example.custom.deploy"))
+}
+
+func TestBuildAeeJobSpecWithPlaybookSource(t *testing.T) {
+	g := NewWithT(t)
+	h := setupTestHelper(false)
+
+	nodeSet := &dataplanev1.OpenStackDataPlaneNodeSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "compute", Namespace: "test-namespace"},
+	}
+	deployment := &dataplanev1.OpenStackDataPlaneDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "edpm-deployment", Namespace: "test-namespace"},
+	}
+	service := &dataplanev1.OpenStackDataPlaneService{
+		ObjectMeta: metav1.ObjectMeta{Name: "custom"},
+		Spec: dataplanev1.OpenStackDataPlaneServiceSpec{
+			Playbook: "playbooks/custom.yml",
+			PlaybookSource: &dataplanev1.PlaybookSourceSpec{
+				Git: &dataplanev1.GitPlaybookSource{
+					URL:                   "https://git.example.com/playbooks.git",
+					Ref:                   "stable",
+					CredentialsSecretName: "git-credentials",
+				},
+			},
+		},
+	}
+
+	aeeSpec := nodeSet.GetAnsibleEESpec()
+	aeeSpec.OpenStackAnsibleEERunnerImage = "ansibleee:latest"
+	eeJob := EEJob{
+		Name:      "custom-edpm-deployment-compute",
+		Namespace: "test-namespace",
+	}
+	eeJob.BuildAeeJobSpec(&aeeSpec, deployment, service, nodeSet)
+	g.Expect(eeJob.Playbook).To(Equal("sources/custom/playbooks/custom.yml"))
+	g.Expect(eeJob.PlaybookSources).To(Equal([]PlaybookSource{
+		{Service: "custom", Spec: *service.Spec.PlaybookSource},
+	}))
+
+	job, err := eeJob.JobForOpenStackAnsibleEE(h)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	podSpec := job.Spec.Template.Spec
+	g.Expect(podSpec.InitContainers).To(HaveLen(1))
+	initContainer := podSpec.InitContainers[0]
+	g.Expect(initContainer.Name).To(Equal("playbook-source-custom"))
+	g.Expect(initContainer.Image).To(Equal("ansibleee:latest"))
+	g.Expect(initContainer.Args).To(Equal([]string{"/bin/bash", "-c", playbookSourceScript, "playbook-source"}))
+	g.Expect(initContainer.Env).To(ContainElements(
+		corev1.EnvVar{Name: "SOURCE_DIR", Value: "/playbook-source/sources/custom"},
+		corev1.EnvVar{Name: "COLLECTIONS_DIR", Value: "/playbook-source/collections"},
+		corev1.EnvVar{Name: "GIT_URL", Value: "https://git.example.com/playbooks.git"},
+		corev1.EnvVar{Name: "GIT_REF", Value: "stable"},
+		corev1.EnvVar{
+			Name: "GIT_PASSWORD",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "git-credentials"},
+					Key:                  "password",
+					Optional:             ptr.To(true),
+				},
+			},
+		},
+		// A Secret holding only a token is accepted
+		corev1.EnvVar{
+			Name: "GIT_TOKEN",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "git-credentials"},
+					Key:                  "token",
+					Optional:             ptr.To(true),
+				},
+			},
+		},
+	))
+
+	container := podSpec.Containers[0]
+	g.Expect(container.Args).To(ContainElement("sources/custom/playbooks/custom.yml"))
+	g.Expect(container.VolumeMounts).To(ContainElements(
+		corev1.VolumeMount{Name: playbookSourceVolume, MountPath: "/runner/project/sources", SubPath: "sources"},
+		corev1.VolumeMount{Name: playbookSourceVolume, MountPath: "/runner/project/collections", SubPath: "collections"},
+	))
+	g.Expect(container.Env).To(ContainElements(
+		corev1.EnvVar{
+			Name:  "ANSIBLE_COLLECTIONS_PATH",
+			Value: "/runner/project/collections:~/.ansible/collections:/usr/share/ansible/collections",
+		},
+		corev1.EnvVar{
+			Name: "ANSIBLE_ROLES_PATH",
+			Value: "/runner/project/sources/custom/roles:/runner/project/sources/custom:" +
+				"~/.ansible/roles:/usr/share/ansible/roles:/etc/ansible/roles",
+		},
+	))
+	g.Expect(podSpec.Volumes).To(ContainElement(corev1.Volume{
+		Name:         playbookSourceVolume,
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	}))
+}
+
+func TestJobForOpenStackAnsibleEEWithOCIPlaybookSource(t *testing.T) {
+	g := NewWithT(t)
+	h := setupTestHelper(false)
+
+	eeJob := EEJob{
+		Name:                "custom-edpm-deployment-compute",
+		Namespace:           "test-namespace",
+		Image:               "quay.io/example/ansibleee:latest",
+		Playbook:            "example.custom.deploy",
+		PlaybookSourceImage: "ghcr.io/oras-project/oras:v1.2.3",
+		Env:                 []corev1.EnvVar{{Name: "ANSIBLE_COLLECTIONS_PATH", Value: "/collections"}},
+		PlaybookSources: []PlaybookSource{{
+			Service: "custom",
+			Spec: dataplanev1.PlaybookSourceSpec{
+				OCI: &dataplanev1.OCIPlaybookSource{
+					Image:          testOCIImage,
+					PullSecretName: "registry-credentials",
+				},
+			},
+		}},
+	}
+	job, err := eeJob.JobForOpenStackAnsibleEE(h)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// The artifact is pulled by oras before the source is installed
+	podSpec := job.Spec.Template.Spec
+	g.Expect(podSpec.InitContainers).To(HaveLen(2))
+	pullContainer := podSpec.InitContainers[0]
+	g.Expect(pullContainer.Name).To(Equal("playbook-pull-custom"))
+	g.Expect(pullContainer.Image).To(Equal("ghcr.io/oras-project/oras:v1.2.3"))
+	g.Expect(pullContainer.Command).To(Equal([]string{"oras"}))
+	g.Expect(pullContainer.Args).To(Equal([]string{
+		"pull", "--output", "/playbook-source/sources/custom", testOCIImage,
+		"--registry-config", "/playbook-source-auth/.dockerconfigjson",
+	}))
+	g.Expect(pullContainer.VolumeMounts).To(ConsistOf(
+		corev1.VolumeMount{Name: playbookSourceVolume, MountPath: playbookSourceMountPath},
+		corev1.VolumeMount{
+			Name:      "playbook-source-custom-auth",
+			MountPath: playbookSourceAuthMountPath,
+			ReadOnly:  true,
+		},
+	))
+	initContainer := podSpec.InitContainers[1]
+	g.Expect(initContainer.Name).To(Equal("playbook-source-custom"))
+	g.Expect(initContainer.Env).To(ContainElement(corev1.EnvVar{Name: "OCI_IMAGE", Value: testOCIImage}))
+	g.Expect(initContainer.Env).ToNot(ContainElement(HaveField("Name", "GIT_URL")))
+	g.Expect(initContainer.VolumeMounts).To(ConsistOf(
+		corev1.VolumeMount{Name: playbookSourceVolume, MountPath: playbookSourceMountPath},
+	))
+	g.Expect(podSpec.Volumes).To(ContainElement(corev1.Volume{
+		Name: "playbook-source-custom-auth",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{SecretName: "registry-credentials"},
+		},
+	}))
+
+	// The collections path set on the job is extended
+	g.Expect(podSpec.Containers[0].Env).To(ConsistOf(
+		corev1.EnvVar{Name: "ANSIBLE_COLLECTIONS_PATH", Value: "/runner/project/collections:/collections"},
+		HaveField("Name", "ANSIBLE_ROLES_PATH"),
+		HaveField("Name", "RUNNER_PLAYBOOK"),
+	))
+	g.Expect(eeJob.Env).To(Equal([]corev1.EnvVar{{Name: "ANSIBLE_COLLECTIONS_PATH", Value: "/collections"}}))
+}
+
+func TestParsePlaybookSourceRevisionsFromPod(t *testing.T) {
+	g := NewWithT(t)
+
+	pod := &corev1.Pod{
+		Status: corev1.PodStatus{
+			InitContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name: "playbook-source-custom",
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							Message: "4b825dc642cb6eb9a060e54bf8d69288fbee4904",
+						},
+					},
+				},
+				{
+					Name: "playbook-source-failed",
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							ExitCode: 128,
+							Message:  "fatal: couldn't find remote ref stable",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	g.Expect(ParsePlaybookSourceRevisionsFromPod(pod, []string{"custom", "failed", "other"})).To(Equal(
+		map[string]string{"custom": "4b825dc642cb6eb9a060e54bf8d69288fbee4904"}))
+}
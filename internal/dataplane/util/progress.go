@@ -0,0 +1,238 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util //nolint:revive // util is an acceptable package name in this context
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	dataplanev1 "github.com/openstack-k8s-operators/openstack-operator/api/dataplane/v1beta1"
+)
+
+// ProgressReportInterval is how often the AnsibleEE jobs report their progress
+const ProgressReportInterval = 30 * time.Second
+
+// progressReporterScript runs ansible-runner with the arguments it is passed
+// while a background python process summarizes the job events written so far
+// and patches them into the progress ConfigMap of the NodeSet. The plays named
+// after ComposedServicePlayPrefix mark the start of the services of a
+// composed playbook, and are used to report the state of each service. The
+// exit code of ansible-runner is preserved, failing to report the progress
+// doesn't fail the job.
+const progressReporterScript = `python3 - <<'EOF' &
+import collections, glob, json, os, signal, ssl, threading, urllib.request
+
+stop = threading.Event()
+signal.signal(signal.SIGTERM, lambda *args: stop.set())
+sa = "/var/run/secrets/kubernetes.io/serviceaccount"
+job_events = "/runner/artifacts/%s/job_events" % os.environ["PROGRESS_IDENT"]
+url = "https://%s:%s/api/v1/namespaces/%s/configmaps/%s" % (
+    os.environ["KUBERNETES_SERVICE_HOST"], os.environ["KUBERNETES_SERVICE_PORT"],
+    os.environ["PROGRESS_NAMESPACE"], os.environ["PROGRESS_CONFIGMAP"])
+progress = {"play": "", "task": "", "completedHosts": 0, "totalHosts": 0}
+hosts, done, counter = set(), set(), 0
+events = collections.deque(maxlen=20)
+service_play, services, service = "Deploy OpenStackDataPlaneService ", {}, ""
+
+def notable(seq, reason, data, message=""):
+    events.append({"seq": seq, "reason": reason, "host": data.get("host", ""),
+                   "play": progress["play"], "task": progress["task"], "message": message[:256]})
+
+def update():
+    global done, counter, service
+    files = {}
+    for f in glob.glob(job_events + "/*.json"):
+        idx = os.path.basename(f).split("-")[0]
+        if idx.isdigit() and not f.endswith("-partial.json"):
+            files[int(idx)] = f
+    for idx in sorted(i for i in files if i > counter):
+        try:
+            with open(files[idx]) as fd:
+                event = json.load(fd)
+        except (OSError, ValueError):
+            break
+        counter = idx
+        data, name = event.get("event_data", {}), event.get("event", "")
+        if name == "playbook_on_play_start":
+            progress["play"] = data.get("play", "")
+            notable(idx, "AnsiblePlayStarted", data)
+            if progress["play"].startswith(service_play):
+                if services.get(service) == "Running":
+                    services[service] = "Completed"
+                service = progress["play"][len(service_play):]
+                services[service] = "Running"
+        elif name == "playbook_on_task_start":
+            progress["task"], done = data.get("task", ""), set()
+        elif name.startswith("runner_on_") and data.get("host"):
+            hosts.add(data["host"])
+            if name != "runner_on_start":
+                done.add(data["host"])
+            res = data.get("res") if isinstance(data.get("res"), dict) else {}
+            if name == "runner_on_failed" and not data.get("ignore_errors"):
+                notable(idx, "AnsibleHostFailed", data, str(res.get("msg", "")))
+                if service:
+                    services[service] = "Failed"
+            elif name == "runner_on_unreachable":
+                notable(idx, "AnsibleHostUnreachable", data, str(res.get("msg", "")))
+                if service:
+                    services[service] = "Failed"
+        elif name == "playbook_on_stats":
+            totals = {key: sum((data.get(stat) or {}).values())
+                      for key, stat in (("ok", "ok"), ("changed", "changed"),
+                                        ("failed", "failures"), ("unreachable", "dark"),
+                                        ("skipped", "skipped"))}
+            notable(idx, "AnsibleRecap", data, " ".join("%s=%d" % item for item in totals.items()))
+    progress["completedHosts"], progress["totalHosts"] = len(done), len(hosts)
+    progress["events"] = list(events)
+    progress["services"] = services
+
+def report():
+    try:
+        update()
+        with open(sa + "/token") as fd:
+            token = fd.read().strip()
+        body = json.dumps({"data": {os.environ["PROGRESS_KEY"]: json.dumps(progress)}})
+        req = urllib.request.Request(url, data=body.encode(), method="PATCH", headers={
+            "Authorization": "Bearer " + token,
+            "Content-Type": "application/merge-patch+json"})
+        urllib.request.urlopen(req, context=ssl.create_default_context(cafile=sa + "/ca.crt"), timeout=10)
+    except Exception as e:
+        print("Could not report progress: %s" % e)
+
+while not stop.wait(int(os.environ["PROGRESS_INTERVAL"])):
+    report()
+report()
+EOF
+reporter=$!
+"$@"
+rc=$?
+kill $reporter && wait $reporter
+exit $rc
+`
+
+// AnsibleEvent is a notable ansible-runner event reported by a running AEE job
+type AnsibleEvent struct {
+	// Sequence is the counter of the event in the ansible-runner job events
+	Sequence int `json:"seq"`
+	// Reason is the reason of the Kubernetes Event the event is recorded as
+	Reason string `json:"reason"`
+	// Host is the host the event happened on, if any
+	Host string `json:"host,omitempty"`
+	// Play is the play being executed
+	Play string `json:"play,omitempty"`
+	// Task is the task being executed
+	Task string `json:"task,omitempty"`
+	// Message is the error message of the task or the recap of the execution
+	Message string `json:"message,omitempty"`
+}
+
+// progressReport is the progress reported by a running AEE job
+type progressReport struct {
+	dataplanev1.AnsibleExecutionProgress
+	Events []AnsibleEvent `json:"events,omitempty"`
+	// Services is the state of the services of a composed playbook
+	Services map[string]string `json:"services,omitempty"`
+}
+
+// GetProgressConfigMapName returns the name of the ConfigMap the AnsibleEE
+// jobs of a NodeSet report their progress to
+func GetProgressConfigMapName(nodeSetName string) string {
+	return fmt.Sprintf("%s-ansibleee-progress", nodeSetName)
+}
+
+// GetAnsibleExecutionProgress returns the progress reported by a running AEE
+// job to the progress ConfigMap, with the time elapsed since the job started,
+// and the notable ansible events it reported
+func GetAnsibleExecutionProgress(
+	progressConfigMap *corev1.ConfigMap,
+	job *batchv1.Job,
+) (*dataplanev1.AnsibleExecutionProgress, []AnsibleEvent, error) {
+	report, err := getProgressReport(progressConfigMap, job)
+	if err != nil {
+		return nil, nil, err
+	}
+	if job.Status.StartTime != nil {
+		report.ElapsedTime = time.Since(job.Status.StartTime.Time).Round(time.Second).String()
+	}
+	return &report.AnsibleExecutionProgress, report.Events, nil
+}
+
+// GetComposedServiceStates returns the state of the services of a composed
+// playbook reported by the job, ServiceStateRunning, ServiceStateCompleted or
+// ServiceStateFailed, by service name
+func GetComposedServiceStates(
+	progressConfigMap *corev1.ConfigMap,
+	job *batchv1.Job,
+) (map[string]string, error) {
+	report, err := getProgressReport(progressConfigMap, job)
+	if err != nil {
+		return nil, err
+	}
+	return report.Services, nil
+}
+
+// getProgressReport parses the progress reported by a job to the progress
+// ConfigMap. The report is empty if the job didn't report its progress yet.
+func getProgressReport(progressConfigMap *corev1.ConfigMap, job *batchv1.Job) (*progressReport, error) {
+	report := &progressReport{}
+	if progressConfigMap != nil {
+		if data, ok := progressConfigMap.Data[job.Name]; ok {
+			if err := json.Unmarshal([]byte(data), report); err != nil {
+				return nil, fmt.Errorf("failed to parse ansible execution progress of %s: %w", job.Name, err)
+			}
+		}
+	}
+	return report, nil
+}
+
+// GetNewAnsibleEvents returns the events that follow the last recorded one
+func GetNewAnsibleEvents(events []AnsibleEvent, lastSequence int) []AnsibleEvent {
+	newEvents := []AnsibleEvent{}
+	for _, event := range events {
+		if event.Sequence > lastSequence {
+			newEvents = append(newEvents, event)
+		}
+	}
+	return newEvents
+}
+
+// wrapArgsForProgress returns the arguments running args with the progress
+// reporter script
+func wrapArgsForProgress(args []string) []string {
+	return append([]string{"/bin/bash", "-c", progressReporterScript, "progress"}, args...)
+}
+
+// addProgressReporter sets the environment the progress reporter script needs
+// to report the progress of the job
+func (a *EEJob) addProgressReporter(job *batchv1.Job, args []string) {
+	container := &job.Spec.Template.Spec.Containers[0]
+	container.Env = append(container.Env,
+		corev1.EnvVar{Name: "PROGRESS_IDENT", Value: getRunnerIdentifier(args)},
+		corev1.EnvVar{Name: "PROGRESS_NAMESPACE", Value: a.Namespace},
+		corev1.EnvVar{Name: "PROGRESS_CONFIGMAP", Value: a.ProgressConfigMap},
+		corev1.EnvVar{Name: "PROGRESS_KEY", Value: a.Name},
+		corev1.EnvVar{
+			Name:  "PROGRESS_INTERVAL",
+			Value: strconv.Itoa(int(ProgressReportInterval.Seconds())),
+		},
+	)
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util //nolint:revive // util is an acceptable package name in this context
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" //revive:disable:dot-imports
+
+	dataplanev1 "github.com/openstack-k8s-operators/openstack-operator/api/dataplane/v1beta1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestGetAnsibleExecutionProgress(t *testing.T) {
+	g := NewWithT(t)
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "configure-network-edpm-deployment-compute"},
+		Status: batchv1.JobStatus{
+			StartTime: &metav1.Time{Time: time.Now().Add(-90 * time.Second)},
+		},
+	}
+	progressConfigMap := &corev1.ConfigMap{
+		Data: map[string]string{
+			"configure-network-edpm-deployment-compute": `{"play": "Deploy EDPM network", "task": "Apply network config", "completedHosts": 1, "totalHosts": 3,
+"events": [{"seq": 1, "reason": "AnsiblePlayStarted", "play": "Deploy EDPM network"},
+{"seq": 42, "reason": "AnsibleHostFailed", "host": "edpm-compute-1", "play": "Deploy EDPM network", "task": "Apply network config", "message": "timeout"}]}`,
+		},
+	}
+
+	progress, events, err := GetAnsibleExecutionProgress(progressConfigMap, job)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(events).To(Equal([]AnsibleEvent{
+		{Sequence: 1, Reason: "AnsiblePlayStarted", Play: "Deploy EDPM network"},
+		{
+			Sequence: 42, Reason: "AnsibleHostFailed", Host: "edpm-compute-1",
+			Play: "Deploy EDPM network", Task: "Apply network config", Message: "timeout",
+		},
+	}))
+	g.Expect(progress).To(Equal(&dataplanev1.AnsibleExecutionProgress{
+		Play:           "Deploy EDPM network",
+		Task:           "Apply network config",
+		CompletedHosts: ptr.To(1),
+		TotalHosts:     ptr.To(3),
+		ElapsedTime:    "1m30s",
+	}))
+
+	// The job didn't report its progress yet
+	progress, events, err = GetAnsibleExecutionProgress(nil, job)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(progress).To(Equal(&dataplanev1.AnsibleExecutionProgress{ElapsedTime: "1m30s"}))
+	g.Expect(events).To(BeEmpty())
+
+	progressConfigMap.Data[job.Name] = "{"
+	_, _, err = GetAnsibleExecutionProgress(progressConfigMap, job)
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestGetComposedServiceStates(t *testing.T) {
+	g := NewWithT(t)
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "edpm-deployment-compute-bootstrap"},
+	}
+	progressConfigMap := &corev1.ConfigMap{
+		Data: map[string]string{
+			"edpm-deployment-compute-bootstrap": `{"play": "Deploy EDPM network",
+"services": {"bootstrap": "Completed", "configure-network": "Running"}}`,
+		},
+	}
+
+	states, err := GetComposedServiceStates(progressConfigMap, job)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(states).To(Equal(map[string]string{
+		"bootstrap":         ServiceStateCompleted,
+		"configure-network": ServiceStateRunning,
+	}))
+
+	// The job didn't report its progress yet
+	states, err = GetComposedServiceStates(nil, job)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(states).To(BeEmpty())
+}
+
+func TestGetNewAnsibleEvents(t *testing.T) {
+	g := NewWithT(t)
+
+	events := []AnsibleEvent{}
+	for seq := 1; seq <= 20; seq++ {
+		events = append(events, AnsibleEvent{Sequence: seq * 2, Reason: "AnsibleHostFailed"})
+	}
+
+	g.Expect(GetNewAnsibleEvents(events[:4], 4)).To(Equal(events[2:4]))
+	g.Expect(GetNewAnsibleEvents(events, 8)).To(Equal(events[4:]))
+	g.Expect(GetNewAnsibleEvents(events, 40)).To(BeEmpty())
+}
+
+func TestJobForOpenStackAnsibleEEWithProgress(t *testing.T) {
+	g := NewWithT(t)
+	h := setupTestHelper(false)
+
+	eeJob := EEJob{
+		Name:              "configure-network-edpm-deployment-compute",
+		Namespace:         "test-namespace",
+		Playbook:          "osp.edpm.configure_network",
+		ProgressConfigMap: GetProgressConfigMapName("compute"),
+		ArtifactSink: &dataplanev1.ArtifactSinkSpec{
+			PVC: &dataplanev1.PVCArtifactSinkSpec{ClaimName: "artifacts"},
+		},
+	}
+	job, err := eeJob.JobForOpenStackAnsibleEE(h)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	container := job.Spec.Template.Spec.Containers[0]
+	g.Expect(container.Args[:4]).To(Equal([]string{"/bin/bash", "-c", progressReporterScript, "progress"}))
+	g.Expect(container.Args[4:8]).To(Equal([]string{"/bin/bash", "-c", artifactSinkScript, "artifact-sink"}))
+	g.Expect(container.Args[8:12]).To(Equal([]string{"/bin/bash", "-c", taskResultsScript, "task-results"}))
+	g.Expect(container.Args[12:]).To(Equal([]string{
+		"ansible-runner", "run", "/runner", "-p", "osp.edpm.configure_network",
+		"-i", "configure-network-edpm-deployment-compute",
+	}))
+	g.Expect(container.Env).To(ContainElements(
+		corev1.EnvVar{Name: "PROGRESS_IDENT", Value: "configure-network-edpm-deployment-compute"},
+		corev1.EnvVar{Name: "PROGRESS_NAMESPACE", Value: "test-namespace"},
+		corev1.EnvVar{Name: "PROGRESS_CONFIGMAP", Value: "compute-ansibleee-progress"},
+		corev1.EnvVar{Name: "PROGRESS_KEY", Value: "configure-network-edpm-deployment-compute"},
+		corev1.EnvVar{Name: "PROGRESS_INTERVAL", Value: "30"},
+	))
+}
@@ -0,0 +1,251 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util //nolint:revive // util is an acceptable package name in this context
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/storage"
+	yaml "gopkg.in/yaml.v3"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dataplanev1 "github.com/openstack-k8s-operators/openstack-operator/api/dataplane/v1beta1"
+)
+
+// ComposedServicePlayPrefix prefixes the name of the play marking the start
+// of a service in a composed playbook. It must match the one the progress
+// reporter script looks for.
+const ComposedServicePlayPrefix = "Deploy OpenStackDataPlaneService "
+
+const (
+	// ServiceStateRunning - the service of a composed playbook is running
+	ServiceStateRunning = "Running"
+	// ServiceStateCompleted - the service of a composed playbook completed
+	// and the next one started
+	ServiceStateCompleted = "Completed"
+	// ServiceStateFailed - a task of the service of a composed playbook
+	// failed or a host was unreachable
+	ServiceStateFailed = "Failed"
+)
+
+// ComposedAnsibleExecution creates a batchv1 Job running the playbooks and
+// roles of services, in order, from a single generated playbook
+func ComposedAnsibleExecution(
+	ctx context.Context,
+	helper *helper.Helper,
+	deployment *dataplanev1.OpenStackDataPlaneDeployment,
+	services []dataplanev1.OpenStackDataPlaneService,
+	sshKeySecrets map[string]string,
+	inventorySecrets map[string]string,
+	aeeSpec *dataplanev1.AnsibleEESpec,
+	nodeSet client.Object,
+	batch *ExecutionBatch,
+) error {
+	executionName, labels := GetComposedAnsibleExecutionNameAndLabels(
+		services, deployment.GetName(), nodeSet.GetName(), batch)
+	if batch != nil {
+		// Limit the execution to the hosts of the batch
+		batchSpec := *aeeSpec
+		batchSpec.AnsibleLimit = strings.Join(batch.Hosts, ",")
+		aeeSpec = &batchSpec
+	}
+
+	ansibleEE, err := newAnsibleExecution(ctx, helper, deployment, aeeSpec, executionName, labels)
+	if err != nil || ansibleEE == nil {
+		return err
+	}
+
+	err = ansibleEE.BuildComposedAeeJobSpec(aeeSpec, deployment, services, nodeSet)
+	if err != nil {
+		return err
+	}
+
+	// The composed services are all deployed on the NodeSet only
+	ansibleEEMounts := storage.VolMounts{}
+	SetAeeSSHMounts(nodeSet, &services[0], sshKeySecrets, &ansibleEEMounts)
+	SetAeeInvMounts(nodeSet, &services[0], inventorySecrets, &ansibleEEMounts)
+
+	err = runAnsibleExecution(ctx, helper, deployment, ansibleEE, aeeSpec, ansibleEEMounts)
+	if err != nil {
+		return err
+	}
+
+	// The artifacts of all the composed services are persisted together
+	if ansibleEE.ArtifactSink != nil {
+		if deployment.Status.ArtifactLocations[nodeSet.GetName()] == nil {
+			deployment.Status.ArtifactLocations[nodeSet.GetName()] = make(map[string]string)
+		}
+		for _, service := range services {
+			deployment.Status.ArtifactLocations[nodeSet.GetName()][service.Name] = GetArtifactLocation(
+				ansibleEE.ArtifactSink, ansibleEE.ArtifactPath)
+		}
+	}
+
+	return nil
+}
+
+// GetComposedAnsibleExecutionNameAndLabels Name and Labels of the AnsibleEE
+// running composed services, named after the first of them. The batch is nil
+// unless the hosts of the NodeSet are deployed in batches.
+func GetComposedAnsibleExecutionNameAndLabels(services []dataplanev1.OpenStackDataPlaneService,
+	deploymentName string,
+	nodeSetName string,
+	batch *ExecutionBatch,
+) (string, map[string]string) {
+	executionName := fmt.Sprintf("%s-%s-%s", deploymentName, nodeSetName, services[0].Name)
+	labels := map[string]string{
+		"openstackdataplanesinglejob":  services[0].Name,
+		"openstackdataplanedeployment": deploymentName,
+		"openstackdataplanenodeset":    nodeSetName,
+	}
+	if batch != nil {
+		executionName = fmt.Sprintf("%s-batch-%d", executionName, batch.Index)
+		labels["openstackdataplanebatch"] = strconv.Itoa(batch.Index)
+	}
+	return GetTruncatedName(executionName), labels
+}
+
+// BuildComposedAeeJobSpec builds the job specification for Ansible Execution
+// Environment running composed services. The job templates of the services
+// are merged in order.
+func (a *EEJob) BuildComposedAeeJobSpec(
+	aeeSpec *dataplanev1.AnsibleEESpec,
+	deployment *dataplanev1.OpenStackDataPlaneDeployment,
+	services []dataplanev1.OpenStackDataPlaneService,
+	nodeSet client.Object,
+) error {
+	if aeeSpec.DNSConfig != nil {
+		a.DNSConfig = aeeSpec.DNSConfig
+	}
+	if len(aeeSpec.ServiceAccountName) > 0 {
+		a.ServiceAccountName = aeeSpec.ServiceAccountName
+	}
+
+	playbook, err := GenerateComposedPlaybook(services)
+	if err != nil {
+		return err
+	}
+	a.PlaybookContents = playbook
+
+	a.BackoffLimit = deployment.Spec.BackoffLimit
+	a.PreserveJobs = deployment.Spec.PreserveJobs
+	if deployment.Spec.ArtifactSink != nil {
+		a.ArtifactSink = deployment.Spec.ArtifactSink
+		a.ArtifactPath = GetArtifactPath(deployment.Spec.ArtifactSink,
+			deployment.Name, nodeSet.GetName(), services[0].Name)
+	}
+	jobTemplate := aeeSpec.JobTemplate
+	for _, service := range services {
+		jobTemplate = jobTemplate.Merge(service.Spec.AnsibleEEJobTemplate)
+		if service.Spec.PlaybookSource != nil {
+			a.PlaybookSources = append(a.PlaybookSources,
+				PlaybookSource{Service: service.Name, Spec: *service.Spec.PlaybookSource})
+		}
+	}
+	a.SetJobTemplate(jobTemplate)
+	// The jobs report their progress with the service account of the NodeSet
+	if deployment.Spec.ReportProgress && aeeSpec.ServiceAccountName == nodeSet.GetName() {
+		a.ProgressConfigMap = GetProgressConfigMapName(nodeSet.GetName())
+	}
+	a.FormatAEECmdLineArguments(aeeSpec)
+
+	// edpm_service_type is set on the plays of each service, as extra vars
+	// would take precedence over it
+	a.ExtraVars = make(map[string]json.RawMessage)
+	maps.Copy(a.ExtraVars, aeeSpec.ExtraVars)
+	a.ExtraVars["edpm_override_hosts"] = json.RawMessage([]byte(fmt.Sprintf("\"%s\"", nodeSet.GetName())))
+	if len(deployment.Spec.ServicesOverride) > 0 {
+		marshalServicesOverride, _ := json.Marshal(deployment.Spec.ServicesOverride)
+		a.ExtraVars["edpm_services_override"] = json.RawMessage(marshalServicesOverride)
+	}
+
+	a.DetermineAeeImage(aeeSpec)
+	return nil
+}
+
+// GenerateComposedPlaybook returns a playbook running the playbook, the
+// inline playbook or the role of each service, in order. The plays of each
+// service are preceded by a play marking its start, and get the
+// edpm_service_type of the service.
+func GenerateComposedPlaybook(services []dataplanev1.OpenStackDataPlaneService) (string, error) {
+	plays := []map[string]interface{}{}
+	for _, service := range services {
+		serviceType := service.Spec.EDPMServiceType
+		if serviceType == "" {
+			serviceType = service.Name
+		}
+		serviceVars := map[string]interface{}{"edpm_service_type": serviceType}
+
+		plays = append(plays, map[string]interface{}{
+			"name":         ComposedServicePlayPrefix + service.Name,
+			"hosts":        "all",
+			"gather_facts": false,
+			"tasks":        []interface{}{},
+		})
+
+		switch {
+		case len(service.Spec.Playbook) > 0:
+			playbook := service.Spec.Playbook
+			if service.Spec.PlaybookSource != nil {
+				playbook = GetPlaybookSourcePath(service.Name, playbook)
+			}
+			plays = append(plays, map[string]interface{}{
+				"import_playbook": playbook,
+				"vars":            serviceVars,
+			})
+		case len(service.Spec.PlaybookContents) > 0:
+			servicePlays := []map[string]interface{}{}
+			err := yaml.Unmarshal([]byte(service.Spec.PlaybookContents), &servicePlays)
+			if err != nil {
+				return "", fmt.Errorf("failed to parse the playbookContents of service %s: %w", service.Name, err)
+			}
+			for _, play := range servicePlays {
+				playVars, _ := play["vars"].(map[string]interface{})
+				if playVars == nil {
+					playVars = make(map[string]interface{})
+				}
+				maps.Copy(playVars, serviceVars)
+				play["vars"] = playVars
+				plays = append(plays, play)
+			}
+		case len(service.Spec.Role) > 0:
+			// Same play ansible-runner runs for a role
+			plays = append(plays, map[string]interface{}{
+				"name":  service.Name,
+				"hosts": "all",
+				"roles": []interface{}{
+					map[string]interface{}{"role": service.Spec.Role},
+				},
+				"vars": serviceVars,
+			})
+		default:
+			return "", fmt.Errorf("no playbook, playbookContents or role specified for service %s", service.Name)
+		}
+	}
+
+	playbook, err := yaml.Marshal(plays)
+	if err != nil {
+		return "", err
+	}
+	return string(playbook), nil
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util //nolint:revive // util is an acceptable package name in this context
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/gomega" //revive:disable:dot-imports
+	yaml "gopkg.in/yaml.v3"
+
+	dataplanev1 "github.com/openstack-k8s-operators/openstack-operator/api/dataplane/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGenerateComposedPlaybook(t *testing.T) {
+	g := NewWithT(t)
+
+	services := []dataplanev1.OpenStackDataPlaneService{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "bootstrap"},
+			Spec:       dataplanev1.OpenStackDataPlaneServiceSpec{Playbook: "osp.edpm.bootstrap"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "custom-ovn"},
+			Spec: dataplanev1.OpenStackDataPlaneServiceSpec{
+				EDPMServiceType: "ovn",
+				PlaybookContents: `- hosts: all
+  vars:
+    edpm_service_type: custom-ovn
+    foo: bar
+  tasks:
+    - ansible.builtin.ping:
+- import_playbook: osp.edpm.ovn
+`,
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "frr"},
+			Spec:       dataplanev1.OpenStackDataPlaneServiceSpec{Role: "osp.edpm.edpm_frr"},
+		},
+	}
+
+	playbook, err := GenerateComposedPlaybook(services)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	plays := []map[string]interface{}{}
+	g.Expect(yaml.Unmarshal([]byte(playbook), &plays)).To(Succeed())
+	marker := func(service string) map[string]interface{} {
+		return map[string]interface{}{
+			"name":         ComposedServicePlayPrefix + service,
+			"hosts":        "all",
+			"gather_facts": false,
+			"tasks":        []interface{}{},
+		}
+	}
+	g.Expect(plays).To(Equal([]map[string]interface{}{
+		marker("bootstrap"),
+		{
+			"import_playbook": "osp.edpm.bootstrap",
+			"vars":            map[string]interface{}{"edpm_service_type": "bootstrap"},
+		},
+		marker("custom-ovn"),
+		{
+			"hosts": "all",
+			"vars":  map[string]interface{}{"edpm_service_type": "ovn", "foo": "bar"},
+			"tasks": []interface{}{map[string]interface{}{"ansible.builtin.ping": nil}},
+		},
+		{
+			"import_playbook": "osp.edpm.ovn",
+			"vars":            map[string]interface{}{"edpm_service_type": "ovn"},
+		},
+		marker("frr"),
+		{
+			"name":  "frr",
+			"hosts": "all",
+			"roles": []interface{}{map[string]interface{}{"role": "osp.edpm.edpm_frr"}},
+			"vars":  map[string]interface{}{"edpm_service_type": "frr"},
+		},
+	}))
+
+	services[1].Spec.PlaybookContents = "hosts: all"
+	_, err = GenerateComposedPlaybook(services)
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestGetComposedAnsibleExecutionNameAndLabels(t *testing.T) {
+	g := NewWithT(t)
+
+	services := []dataplanev1.OpenStackDataPlaneService{
+		{ObjectMeta: metav1.ObjectMeta{Name: "bootstrap"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "configure-network"}},
+	}
+	name, labels := GetComposedAnsibleExecutionNameAndLabels(services, "edpm-deployment", "edpm-compute", nil)
+	g.Expect(name).To(Equal("edpm-deployment-edpm-compute-bootstrap"))
+	g.Expect(labels).To(Equal(map[string]string{
+		"openstackdataplanesinglejob":  "bootstrap",
+		"openstackdataplanedeployment": "edpm-deployment",
+		"openstackdataplanenodeset":    "edpm-compute",
+	}))
+
+	name, labels = GetComposedAnsibleExecutionNameAndLabels(
+		services, "edpm-deployment", "edpm-compute", &ExecutionBatch{Index: 2})
+	g.Expect(name).To(Equal("edpm-deployment-edpm-compute-bootstrap-batch-2"))
+	g.Expect(labels).To(HaveKeyWithValue("openstackdataplanebatch", "2"))
+}
+
+func TestBuildComposedAeeJobSpec(t *testing.T) {
+	g := NewWithT(t)
+	h := setupTestHelper(false)
+
+	nodeSet := &dataplanev1.OpenStackDataPlaneNodeSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "compute", Namespace: "test-namespace"},
+	}
+	deployment := &dataplanev1.OpenStackDataPlaneDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "edpm-deployment", Namespace: "test-namespace"},
+		Spec: dataplanev1.OpenStackDataPlaneDeploymentSpec{
+			ExecutionMode:  dataplanev1.ExecutionModeSingleJob,
+			ReportProgress: true,
+		},
+	}
+	services := []dataplanev1.OpenStackDataPlaneService{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "bootstrap"},
+			Spec:       dataplanev1.OpenStackDataPlaneServiceSpec{Playbook: "osp.edpm.bootstrap"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "configure-network"},
+			Spec:       dataplanev1.OpenStackDataPlaneServiceSpec{Playbook: "osp.edpm.configure_network"},
+		},
+	}
+
+	aeeSpec := nodeSet.GetAnsibleEESpec()
+	aeeSpec.ExtraVars = map[string]json.RawMessage{"foo": json.RawMessage(`"bar"`)}
+
+	eeJob := EEJob{
+		Name:      "edpm-deployment-compute-bootstrap",
+		Namespace: "test-namespace",
+	}
+	g.Expect(eeJob.BuildComposedAeeJobSpec(&aeeSpec, deployment, services, nodeSet)).To(Succeed())
+	g.Expect(eeJob.ExtraVars).To(Equal(map[string]json.RawMessage{
+		"foo":                 json.RawMessage(`"bar"`),
+		"edpm_override_hosts": json.RawMessage(`"compute"`),
+	}))
+	// The extra vars of the deployment are left untouched
+	g.Expect(aeeSpec.ExtraVars).To(HaveLen(1))
+
+	g.Expect(eeJob.ProgressConfigMap).To(Equal("compute-ansibleee-progress"))
+
+	job, err := eeJob.JobForOpenStackAnsibleEE(h)
+	g.Expect(err).ToNot(HaveOccurred())
+	container := job.Spec.Template.Spec.Containers[0]
+	g.Expect(container.Args[8:]).To(Equal([]string{
+		"ansible-runner", "run", "/runner", "-p", "playbook.yaml",
+		"-i", "edpm-deployment-compute-bootstrap",
+	}))
+	g.Expect(container.Env).To(ContainElement(And(
+		HaveField("Name", "RUNNER_PLAYBOOK"),
+		HaveField("Value", ContainSubstring("import_playbook: osp.edpm.configure_network")),
+	)))
+
+	// The jobs don't report their progress unless requested
+	deployment.Spec.ReportProgress = false
+	eeJob = EEJob{
+		Name:      "edpm-deployment-compute-bootstrap",
+		Namespace: "test-namespace",
+	}
+	g.Expect(eeJob.BuildComposedAeeJobSpec(&aeeSpec, deployment, services, nodeSet)).To(Succeed())
+	g.Expect(eeJob.ProgressConfigMap).To(BeEmpty())
+	job, err = eeJob.JobForOpenStackAnsibleEE(h)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(job.Spec.Template.Spec.Containers[0].Args).ToNot(ContainElement(progressReporterScript))
+	// but still report their task results
+	g.Expect(job.Spec.Template.Spec.Containers[0].Args[:4]).To(Equal(
+		[]string{"/bin/bash", "-c", taskResultsScript, "task-results"}))
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util //nolint:revive // util is an acceptable package name in this context
+
+import (
+	"strconv"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// MaxTaskFailures is the maximum number of failed hosts a job reports the
+// failed task of
+const MaxTaskFailures = 20
+
+// taskResultsScript runs ansible-runner with the arguments it is passed and
+// then adds the task results of the execution, read from its job_events
+// artifacts, to the execution summary in the termination message of the
+// container. The keys already written by the image are kept. The failed
+// tasks are dropped when the summary doesn't fit the 4096 bytes of a
+// termination message. The exit code of ansible-runner is preserved, failing
+// to report the task results doesn't fail the job. RUNNER_ARTIFACT_DIR
+// defaults to the artifacts directory of ansible-runner, RESULTS_FILE to the
+// termination message path.
+const taskResultsScript = `"$@"
+rc=$?
+python3 - <<'EOF' || echo "Could not report the task results"
+import glob, json, os
+
+path = os.environ.get("RESULTS_FILE", "/dev/termination-log")
+job_events = os.path.join(os.environ.get("RUNNER_ARTIFACT_DIR", "/runner/artifacts"),
+                          os.environ["RESULTS_IDENT"], "job_events")
+files = {}
+for f in glob.glob(job_events + "/*.json"):
+    idx = os.path.basename(f).split("-")[0]
+    if idx.isdigit() and not f.endswith("-partial.json"):
+        files[int(idx)] = f
+
+failures, stats = {}, None
+for idx in sorted(files):
+    try:
+        with open(files[idx]) as fd:
+            event = json.load(fd)
+    except (OSError, ValueError):
+        continue
+    data, name = event.get("event_data", {}), event.get("event", "")
+    if name == "playbook_on_stats":
+        stats = data
+    elif name in ("runner_on_failed", "runner_on_unreachable") and data.get("host"):
+        if name == "runner_on_failed" and data.get("ignore_errors"):
+            continue
+        res = data.get("res") if isinstance(data.get("res"), dict) else {}
+        if data["host"] not in failures and len(failures) < int(os.environ["RESULTS_MAX_FAILURES"]):
+            failures[data["host"]] = {"host": data["host"], "task": data.get("task", ""),
+                                      "role": data.get("role", ""), "message": str(res.get("msg", ""))[:256]}
+
+try:
+    with open(path) as fd:
+        summary = json.load(fd)
+except (OSError, ValueError):
+    summary = {}
+if not isinstance(summary, dict):
+    summary = {}
+
+if stats is not None:
+    stat = lambda key: stats.get(key) or {}
+    hosts = set()
+    for key in ("processed", "ok", "changed", "failures", "dark", "skipped"):
+        hosts.update(stat(key))
+    failed = sorted(h for h, count in stat("failures").items() if count)
+    unreachable = sorted(h for h, count in stat("dark").items() if count)
+    summary.setdefault("totalHosts", len(hosts))
+    summary.setdefault("failedHosts", len(failed))
+    summary.setdefault("unreachableHosts", len(unreachable))
+    summary.setdefault("failurePercent", len(set(failed) | set(unreachable)) * 100 // max(len(hosts), 1))
+    summary.setdefault("failedHostList", failed)
+    summary.setdefault("unreachableHostList", unreachable)
+    # the ok tasks of ansible include the changed ones
+    changed = sum(stat("changed").values())
+    summary.setdefault("okTasks", max(sum(stat("ok").values()) - changed, 0))
+    summary.setdefault("changedTasks", changed)
+    summary.setdefault("skippedTasks", sum(stat("skipped").values()))
+if failures:
+    summary.setdefault("taskFailures", list(failures.values()))
+while len(json.dumps(summary, separators=(",", ":"))) > 4096 and summary.get("taskFailures"):
+    summary["taskFailures"].pop()
+if summary.get("taskFailures") == []:
+    del summary["taskFailures"]
+
+if summary:
+    with open(path, "w") as fd:
+        json.dump(summary, fd, separators=(",", ":"))
+EOF
+exit $rc
+`
+
+// wrapArgsForTaskResults returns the arguments running args with the task
+// results script
+func wrapArgsForTaskResults(args []string) []string {
+	return append([]string{"/bin/bash", "-c", taskResultsScript, "task-results"}, args...)
+}
+
+// addTaskResults sets the environment the task results script needs to
+// report the task results of the job
+func addTaskResults(job *batchv1.Job, args []string) {
+	container := &job.Spec.Template.Spec.Containers[0]
+	container.Env = append(container.Env,
+		corev1.EnvVar{Name: "RESULTS_IDENT", Value: getRunnerIdentifier(args)},
+		corev1.EnvVar{Name: "RESULTS_MAX_FAILURES", Value: strconv.Itoa(MaxTaskFailures)},
+	)
+}
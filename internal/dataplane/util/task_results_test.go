@@ -0,0 +1,197 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util //nolint:revive // util is an acceptable package name in this context
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega" //revive:disable:dot-imports
+
+	dataplanev1 "github.com/openstack-k8s-operators/openstack-operator/api/dataplane/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+const testJobEvents = `{"event": "playbook_on_play_start", "event_data": {"play": "Deploy EDPM network"}}
+{"event": "runner_on_ok", "event_data": {"host": "host-a", "task": "Apply network configuration"}}
+{"event": "runner_on_failed", "event_data": {"host": "host-b", "task": "Check connectivity", "ignore_errors": true}}
+{"event": "runner_on_failed", "event_data": {"host": "host-b", "task": "Apply network configuration", "role": "edpm_network_config", "res": {"msg": "timeout"}}}
+{"event": "runner_on_unreachable", "event_data": {"host": "host-c", "task": "Gathering Facts", "res": {"msg": "ssh: connect to host host-c port 22: No route to host"}}}
+{"event": "playbook_on_stats", "event_data": {"ok": {"host-a": 10, "host-b": 4}, "changed": {"host-a": 3, "host-b": 1}, "skipped": {"host-a": 2}, "failures": {"host-b": 1}, "dark": {"host-c": 1}}}`
+
+// runTaskResultsScript runs the task results script in place of the job
+// container, with the job events of an execution written in a temporary
+// runner directory and the termination message in RESULTS_FILE, and returns
+// the summary in the termination message and the exit code
+func runTaskResultsScript(t *testing.T, message string, args ...string) (*dataplanev1.AnsibleExecutionSummary, int) {
+	t.Helper()
+	g := NewWithT(t)
+	if _, err := exec.LookPath("python3"); err != nil {
+		t.Skip("python3 is required to report the task results")
+	}
+
+	runnerDir := t.TempDir()
+	eventsDir := filepath.Join(runnerDir, "configure-network-edpm-deployment-compute", "job_events")
+	g.Expect(os.MkdirAll(eventsDir, 0o755)).To(Succeed())
+	for idx, event := range strings.Split(testJobEvents, "\n") {
+		name := filepath.Join(eventsDir, fmt.Sprintf("%d-event.json", idx+1))
+		g.Expect(os.WriteFile(name, []byte(event), 0o600)).To(Succeed())
+	}
+	// a partial event is not complete yet and ignored
+	g.Expect(os.WriteFile(filepath.Join(eventsDir, "7-event-partial.json"),
+		[]byte(`{"event": "runner_on_failed", "event_data": {"host": "host-a"}}`), 0o600)).To(Succeed())
+
+	resultsFile := filepath.Join(t.TempDir(), "termination-log")
+	g.Expect(os.WriteFile(resultsFile, []byte(message), 0o600)).To(Succeed())
+
+	cmd := exec.Command("/bin/bash", wrapArgsForTaskResults(args)[1:]...)
+	cmd.Env = []string{
+		"PATH=" + os.Getenv("PATH"),
+		"RUNNER_ARTIFACT_DIR=" + runnerDir,
+		"RESULTS_FILE=" + resultsFile,
+		"RESULTS_IDENT=configure-network-edpm-deployment-compute",
+		"RESULTS_MAX_FAILURES=20",
+	}
+	rc := 0
+	out, err := cmd.CombinedOutput()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		rc = exitErr.ExitCode()
+	} else {
+		g.Expect(err).ToNot(HaveOccurred())
+	}
+	g.Expect(string(out)).ToNot(ContainSubstring("Could not report the task results"))
+
+	data, err := os.ReadFile(resultsFile)
+	g.Expect(err).ToNot(HaveOccurred())
+	summary := &dataplanev1.AnsibleExecutionSummary{}
+	g.Expect(json.Unmarshal(data, summary)).To(Succeed())
+	return summary, rc
+}
+
+func TestJobForOpenStackAnsibleEEWithoutProgress(t *testing.T) {
+	g := NewWithT(t)
+	h := setupTestHelper(false)
+
+	// The task results are reported when the job doesn't report its progress
+	eeJob := EEJob{
+		Name:      "configure-network-edpm-deployment-compute",
+		Namespace: "test-namespace",
+		Playbook:  "osp.edpm.configure_network",
+	}
+	job, err := eeJob.JobForOpenStackAnsibleEE(h)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	container := job.Spec.Template.Spec.Containers[0]
+	g.Expect(container.Args[:4]).To(Equal([]string{"/bin/bash", "-c", taskResultsScript, "task-results"}))
+	g.Expect(container.Args[4:]).To(Equal([]string{
+		"ansible-runner", "run", "/runner", "-p", "osp.edpm.configure_network",
+		"-i", "configure-network-edpm-deployment-compute",
+	}))
+	g.Expect(container.Env).To(ContainElements(
+		corev1.EnvVar{Name: "RESULTS_IDENT", Value: "configure-network-edpm-deployment-compute"},
+		corev1.EnvVar{Name: "RESULTS_MAX_FAILURES", Value: "20"},
+	))
+	g.Expect(container.Env).ToNot(ContainElement(HaveField("Name", "PROGRESS_CONFIGMAP")))
+}
+
+func TestTaskResultsScript(t *testing.T) {
+	g := NewWithT(t)
+
+	// The summary written by the image is kept and the exit code of
+	// ansible-runner is preserved
+	summary, rc := runTaskResultsScript(t,
+		`{"totalHosts":3,"failedHosts":1,"unreachableHosts":1,"failurePercent":67,"failedHostList":["host-b"],"unreachableHostList":["host-c"]}`,
+		"/bin/sh", "-c", "exit 2")
+	g.Expect(rc).To(Equal(2))
+	g.Expect(summary).To(Equal(&dataplanev1.AnsibleExecutionSummary{
+		TotalHosts:          ptr.To(3),
+		FailedHosts:         ptr.To(1),
+		UnreachableHosts:    ptr.To(1),
+		FailurePercent:      ptr.To(67),
+		FailedHostList:      &[]string{"host-b"},
+		UnreachableHostList: &[]string{"host-c"},
+		OkTasks:             ptr.To(10),
+		ChangedTasks:        ptr.To(4),
+		SkippedTasks:        ptr.To(2),
+		TaskFailures: &[]dataplanev1.AnsibleTaskFailure{
+			{Host: "host-b", Task: "Apply network configuration", Role: "edpm_network_config", Message: "timeout"},
+			{Host: "host-c", Task: "Gathering Facts", Message: "ssh: connect to host host-c port 22: No route to host"},
+		},
+	}))
+
+	// Without a summary from the image, the host results are reported too
+	summary, rc = runTaskResultsScript(t, "", "/bin/true")
+	g.Expect(rc).To(Equal(0))
+	g.Expect(summary.TotalHosts).To(Equal(ptr.To(3)))
+	g.Expect(summary.FailurePercent).To(Equal(ptr.To(66)))
+	g.Expect(summary.FailedHostList).To(Equal(&[]string{"host-b"}))
+	g.Expect(summary.UnreachableHostList).To(Equal(&[]string{"host-c"}))
+	g.Expect(summary.OkTasks).To(Equal(ptr.To(10)))
+	g.Expect(summary.TaskFailures).ToNot(BeNil())
+
+	// The summary is read from the pod like the one of the image
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: "test-namespace"},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "runner",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{Message: mustMarshal(t, summary)},
+				},
+			}},
+		},
+	}
+	parsed, err := ParseAnsibleExecutionSummaryFromPod(pod)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(parsed).To(Equal(summary))
+}
+
+func TestTaskResultsScriptMessageSize(t *testing.T) {
+	g := NewWithT(t)
+
+	// The last failed tasks are dropped to fit the termination message
+	hosts := make([]string, 200)
+	for idx := range hosts {
+		hosts[idx] = fmt.Sprintf("edpm-compute-%d", idx)
+	}
+	message := mustMarshal(t, &dataplanev1.AnsibleExecutionSummary{
+		TotalHosts:     ptr.To(200),
+		FailedHosts:    ptr.To(200),
+		FailedHostList: &hosts,
+	})
+	summary, _ := runTaskResultsScript(t, message, "/bin/true")
+	g.Expect(summary.TotalHosts).To(Equal(ptr.To(200)))
+	g.Expect(summary.OkTasks).To(Equal(ptr.To(10)))
+	g.Expect(*summary.TaskFailures).To(HaveLen(1))
+	g.Expect((*summary.TaskFailures)[0].Host).To(Equal("host-b"))
+}
+
+func mustMarshal(t *testing.T, v interface{}) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	NewWithT(t).Expect(err).ToNot(HaveOccurred())
+	return string(data)
+}
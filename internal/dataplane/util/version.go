@@ -81,6 +81,7 @@ func GetContainerImages(version *openstackv1.OpenStackVersion) openstackv1.Conta
 		containerImages.NovaComputeImage = version.Status.ContainerImages.NovaComputeImage
 		containerImages.OvnControllerImage = version.Status.ContainerImages.OvnControllerImage
 		containerImages.OsContainerImage = version.Status.ContainerImages.OsContainerImage
+		containerImages.OrasImage = version.Status.ContainerImages.OrasImage
 		containerImages.AgentImage = version.Status.ContainerImages.AgentImage
 		containerImages.ApacheImage = version.Status.ContainerImages.ApacheImage
 		containerImages.SwiftAccountImage = version.Status.ContainerImages.SwiftAccountImage
@@ -109,6 +110,7 @@ func GetContainerImages(version *openstackv1.OpenStackVersion) openstackv1.Conta
 		containerImages.NovaComputeImage = dataplanev1.ContainerImages.NovaComputeImage
 		containerImages.OvnControllerImage = dataplanev1.ContainerImages.OvnControllerImage
 		containerImages.OsContainerImage = dataplanev1.ContainerImages.OsContainerImage
+		containerImages.OrasImage = dataplanev1.ContainerImages.OrasImage
 		containerImages.AgentImage = dataplanev1.ContainerImages.AgentImage
 		containerImages.ApacheImage = dataplanev1.ContainerImages.ApacheImage
 		containerImages.SwiftAccountImage = dataplanev1.ContainerImages.SwiftAccountImage
@@ -0,0 +1,214 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openstack
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+
+	corev1beta1 "github.com/openstack-k8s-operators/openstack-operator/api/core/v1beta1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	k8s_corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// requestsPerSecondMetric - the pods metric of the HTTP request rate of
+	// an API, provided by a custom metrics adapter
+	requestsPerSecondMetric = "http_requests_per_second"
+)
+
+// autoscaledDeployments - the Deployment of each autoscaled API, by API name.
+// The service operators name it after the service CR the OpenStackControlPlane
+// creates, suffixed with the API component for the services running several
+// components.
+var autoscaledDeployments = map[string]string{
+	"barbican":  barbicanName + "-api",
+	"designate": designateName + "-api",
+	"heat":      heatName + "-api",
+	"horizon":   horizonName,
+	"keystone":  keystoneName,
+	"neutron":   neutronName,
+	"octavia":   octaviaName + "-api",
+	"placement": placementName,
+	"swift":     swiftName + "-proxy",
+}
+
+// ReconcileAutoscaling - creates a HorizontalPodAutoscaler for the Deployment
+// of each autoscaled API and deletes the ones of the APIs not autoscaled
+// anymore, or scaled to zero replicas by their template. The replicas of the autoscaled templates are raised, in memory
+// only, to the replicas desired by their HorizontalPodAutoscaler so the
+// service operators don't scale the Deployments back to the template. An API
+// whose template, e.g. sized by the sizing profile, has more replicas than its
+// maxReplicas isn't autoscaled, the conflict is reported in the
+// OpenStackControlPlaneAutoscalingReady condition.
+func ReconcileAutoscaling(
+	ctx context.Context,
+	instance *corev1beta1.OpenStackControlPlane,
+	helper *helper.Helper,
+) (ctrl.Result, error) {
+	Log := GetLogger(ctx)
+
+	status := map[string]corev1beta1.AutoscalingStatus{}
+	conflicts := []string{}
+	for _, api := range instance.GetAutoscaledAPIs() {
+		deployment := autoscaledDeployments[api.Name]
+		hpa := &autoscalingv2.HorizontalPodAutoscaler{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      deployment,
+				Namespace: instance.Namespace,
+			},
+		}
+
+		// the replicas of the template are the floor, read them before
+		// raising them to the desired replicas below
+		var minReplicas, maxReplicas int32
+		if api.IsAutoscaled() {
+			minReplicas = api.GetMinReplicas()
+			maxReplicas = api.Autoscaling.MaxReplicas
+			if minReplicas > maxReplicas {
+				conflicts = append(conflicts, fmt.Sprintf("%s (maxReplicas %d, minimum replicas %d)",
+					api.Name, maxReplicas, minReplicas))
+			}
+		}
+
+		if !api.IsAutoscaled() || minReplicas > maxReplicas {
+			if err := deleteAutoscaler(ctx, instance, helper, hpa); err != nil {
+				return ctrl.Result{}, err
+			}
+			continue
+		}
+
+		Log.Info("Reconciling HorizontalPodAutoscaler", "HorizontalPodAutoscaler.Namespace", instance.Namespace, "HorizontalPodAutoscaler.Name", hpa.Name)
+		op, err := controllerutil.CreateOrPatch(ctx, helper.GetClient(), hpa, func() error {
+			hpa.Spec.ScaleTargetRef = autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       deployment,
+			}
+			hpa.Spec.MinReplicas = ptr.To(minReplicas)
+			hpa.Spec.MaxReplicas = maxReplicas
+			hpa.Spec.Metrics = autoscalingMetrics(api.Autoscaling)
+
+			return controllerutil.SetControllerReference(helper.GetBeforeObject(), hpa, helper.GetScheme())
+		})
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if op != controllerutil.OperationResultNone {
+			Log.Info("HorizontalPodAutoscaler reconciled", "HorizontalPodAutoscaler.Name", hpa.Name, "operation", string(op))
+		}
+
+		if hpa.Status.DesiredReplicas > api.GetTemplateReplicas() {
+			*api.Replicas = ptr.To(min(hpa.Status.DesiredReplicas, maxReplicas))
+		}
+
+		status[api.Name] = corev1beta1.AutoscalingStatus{
+			MinReplicas:     minReplicas,
+			MaxReplicas:     maxReplicas,
+			CurrentReplicas: hpa.Status.CurrentReplicas,
+			DesiredReplicas: hpa.Status.DesiredReplicas,
+		}
+	}
+
+	instance.Status.Autoscaling = nil
+	if len(status) > 0 {
+		instance.Status.Autoscaling = status
+	}
+
+	if len(conflicts) > 0 {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			corev1beta1.OpenStackControlPlaneAutoscalingReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityWarning,
+			corev1beta1.OpenStackControlPlaneAutoscalingReadyConflictMessage,
+			strings.Join(conflicts, ", ")))
+	} else {
+		instance.Status.Conditions.Remove(corev1beta1.OpenStackControlPlaneAutoscalingReadyCondition)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// autoscalingMetrics - returns the metrics of the targets of the autoscaling,
+// the default CPU utilization when none is set
+func autoscalingMetrics(autoscaling *corev1beta1.AutoscalingSection) []autoscalingv2.MetricSpec {
+	metrics := []autoscalingv2.MetricSpec{}
+
+	cpu := autoscaling.TargetCPUUtilizationPercentage
+	if cpu == nil && autoscaling.TargetRequestsPerSecond == nil {
+		cpu = ptr.To[int32](corev1beta1.DefaultAutoscalingTargetCPUUtilizationPercentage)
+	}
+	if cpu != nil {
+		metrics = append(metrics, autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name: k8s_corev1.ResourceCPU,
+				Target: autoscalingv2.MetricTarget{
+					Type:               autoscalingv2.UtilizationMetricType,
+					AverageUtilization: ptr.To(*cpu),
+				},
+			},
+		})
+	}
+	if autoscaling.TargetRequestsPerSecond != nil {
+		metrics = append(metrics, autoscalingv2.MetricSpec{
+			Type: autoscalingv2.PodsMetricSourceType,
+			Pods: &autoscalingv2.PodsMetricSource{
+				Metric: autoscalingv2.MetricIdentifier{
+					Name: requestsPerSecondMetric,
+				},
+				Target: autoscalingv2.MetricTarget{
+					Type:         autoscalingv2.AverageValueMetricType,
+					AverageValue: ptr.To(autoscaling.TargetRequestsPerSecond.DeepCopy()),
+				},
+			},
+		})
+	}
+
+	return metrics
+}
+
+// deleteAutoscaler - deletes the HorizontalPodAutoscaler if the
+// OpenStackControlPlane created it, leaving the ones created by users
+func deleteAutoscaler(
+	ctx context.Context,
+	instance *corev1beta1.OpenStackControlPlane,
+	helper *helper.Helper,
+	hpa *autoscalingv2.HorizontalPodAutoscaler,
+) error {
+	if err := helper.GetClient().Get(ctx, client.ObjectKeyFromObject(hpa), hpa); err != nil {
+		if k8s_errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if !metav1.IsControlledBy(hpa, instance) {
+		return nil
+	}
+	_, err := EnsureDeleted(ctx, helper, hpa)
+	return err
+}
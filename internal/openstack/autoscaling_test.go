@@ -0,0 +1,161 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openstack
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega" //revive:disable:dot-imports
+
+	keystonev1 "github.com/openstack-k8s-operators/keystone-operator/api/v1beta1"
+	corev1 "github.com/openstack-k8s-operators/openstack-operator/api/core/v1beta1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	k8s_corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestAutoscalingMetrics(t *testing.T) {
+	g := NewWithT(t)
+
+	// without a target the CPU utilization defaults
+	metrics := autoscalingMetrics(&corev1.AutoscalingSection{MaxReplicas: 3})
+	g.Expect(metrics).To(HaveLen(1))
+	g.Expect(metrics[0].Type).To(Equal(autoscalingv2.ResourceMetricSourceType))
+	g.Expect(metrics[0].Resource.Name).To(Equal(k8s_corev1.ResourceCPU))
+	g.Expect(*metrics[0].Resource.Target.AverageUtilization).To(
+		Equal(int32(corev1.DefaultAutoscalingTargetCPUUtilizationPercentage)))
+
+	// a request rate target alone doesn't add the default CPU utilization
+	rate := resource.MustParse("50")
+	metrics = autoscalingMetrics(&corev1.AutoscalingSection{
+		MaxReplicas:             3,
+		TargetRequestsPerSecond: &rate,
+	})
+	g.Expect(metrics).To(HaveLen(1))
+	g.Expect(metrics[0].Type).To(Equal(autoscalingv2.PodsMetricSourceType))
+	g.Expect(metrics[0].Pods.Metric.Name).To(Equal(requestsPerSecondMetric))
+	g.Expect(metrics[0].Pods.Target.AverageValue.String()).To(Equal("50"))
+
+	metrics = autoscalingMetrics(&corev1.AutoscalingSection{
+		MaxReplicas:                    3,
+		TargetCPUUtilizationPercentage: ptr.To[int32](60),
+		TargetRequestsPerSecond:        &rate,
+	})
+	g.Expect(metrics).To(HaveLen(2))
+	g.Expect(*metrics[0].Resource.Target.AverageUtilization).To(Equal(int32(60)))
+	g.Expect(metrics[1].Type).To(Equal(autoscalingv2.PodsMetricSourceType))
+}
+
+func TestReconcileAutoscalingScaledToZero(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	instance := &corev1.OpenStackControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-controlplane",
+			Namespace: "test-namespace",
+			UID:       "test-uid",
+		},
+	}
+	instance.Spec.Keystone.Enabled = true
+	instance.Spec.Keystone.Template = &keystonev1.KeystoneAPISpecCore{}
+	instance.Spec.Keystone.Template.Replicas = ptr.To[int32](0)
+	instance.Spec.Keystone.Autoscaling = &corev1.AutoscalingSection{MaxReplicas: 3}
+
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "keystone",
+			Namespace: instance.Namespace,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "core.openstack.org/v1beta1",
+				Kind:       "OpenStackControlPlane",
+				Name:       instance.Name,
+				UID:        instance.UID,
+				Controller: ptr.To(true),
+			}},
+		},
+	}
+	h := setupTestHelper(hpa)
+
+	// the API scaled to zero by its template isn't autoscaled anymore
+	_, err := ReconcileAutoscaling(ctx, instance, h)
+	g.Expect(err).ToNot(HaveOccurred())
+	err = h.GetClient().Get(ctx, client.ObjectKeyFromObject(hpa), &autoscalingv2.HorizontalPodAutoscaler{})
+	g.Expect(k8s_errors.IsNotFound(err)).To(BeTrue())
+	g.Expect(*instance.Spec.Keystone.Template.Replicas).To(Equal(int32(0)))
+	g.Expect(instance.Status.Autoscaling).To(BeNil())
+}
+
+func TestAutoscaledDeployments(t *testing.T) {
+	g := NewWithT(t)
+
+	// every autoscaled API targets the Deployment of its service CR
+	apis := (&corev1.OpenStackControlPlane{}).GetAutoscaledAPIs()
+	for _, api := range apis {
+		g.Expect(autoscaledDeployments).To(HaveKey(api.Name))
+	}
+	g.Expect(autoscaledDeployments).To(HaveLen(len(apis)))
+	g.Expect(autoscaledDeployments["barbican"]).To(Equal("barbican-api"))
+	g.Expect(autoscaledDeployments["keystone"]).To(Equal("keystone"))
+	g.Expect(autoscaledDeployments["swift"]).To(Equal("swift-proxy"))
+}
+
+func TestReconcileAutoscalingMaxReplicasConflict(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	instance := &corev1.OpenStackControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-controlplane",
+			Namespace: "test-namespace",
+			UID:       "test-uid",
+		},
+	}
+	instance.Spec.Keystone.Enabled = true
+	instance.Spec.Keystone.Template = &keystonev1.KeystoneAPISpecCore{}
+	// e.g. raised by the sizing profile above the maxReplicas
+	instance.Spec.Keystone.Template.Replicas = ptr.To[int32](4)
+	instance.Spec.Keystone.Autoscaling = &corev1.AutoscalingSection{MaxReplicas: 3}
+	h := setupTestHelper()
+
+	// the maxReplicas isn't raised, the API isn't autoscaled
+	_, err := ReconcileAutoscaling(ctx, instance, h)
+	g.Expect(err).ToNot(HaveOccurred())
+	err = h.GetClient().Get(ctx, client.ObjectKey{Name: "keystone", Namespace: instance.Namespace},
+		&autoscalingv2.HorizontalPodAutoscaler{})
+	g.Expect(k8s_errors.IsNotFound(err)).To(BeTrue())
+	g.Expect(*instance.Spec.Keystone.Template.Replicas).To(Equal(int32(4)))
+	g.Expect(instance.Status.Autoscaling).To(BeNil())
+	g.Expect(instance.Status.Conditions.IsFalse(corev1.OpenStackControlPlaneAutoscalingReadyCondition)).To(BeTrue())
+	g.Expect(instance.Status.Conditions.Get(corev1.OpenStackControlPlaneAutoscalingReadyCondition).Message).To(
+		ContainSubstring("keystone (maxReplicas 3, minimum replicas 4)"))
+
+	// the condition is removed once the conflict is solved
+	instance.Spec.Keystone.Autoscaling.MaxReplicas = 5
+	_, err = ReconcileAutoscaling(ctx, instance, h)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(instance.Status.Conditions.Get(corev1.OpenStackControlPlaneAutoscalingReadyCondition)).To(BeNil())
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+	g.Expect(h.GetClient().Get(ctx, client.ObjectKey{Name: "keystone", Namespace: instance.Namespace}, hpa)).To(Succeed())
+	g.Expect(*hpa.Spec.MinReplicas).To(Equal(int32(4)))
+	g.Expect(hpa.Spec.MaxReplicas).To(Equal(int32(5)))
+}
@@ -19,11 +19,15 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
+const (
+	barbicanName = "barbican"
+)
+
 // ReconcileBarbican -
 func ReconcileBarbican(ctx context.Context, instance *corev1beta1.OpenStackControlPlane, version *corev1beta1.OpenStackVersion, helper *helper.Helper) (ctrl.Result, error) {
 	barbican := &barbicanv1.Barbican{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "barbican",
+			Name:      barbicanName,
 			Namespace: instance.Namespace,
 		},
 	}
@@ -44,6 +48,14 @@ func ReconcileBarbican(ctx context.Context, instance *corev1beta1.OpenStackContr
 		return ctrl.Result{}, nil
 	}
 
+	if managed, err := EnsureServiceManaged(ctx, instance, helper, "Barbican", barbican, corev1beta1.OpenStackControlPlaneBarbicanReadyCondition); !managed || err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if !EnsureServiceDependencies(ctx, instance, "Barbican") {
+		return ctrl.Result{}, nil
+	}
+
 	if instance.Spec.Barbican.Template == nil {
 		instance.Spec.Barbican.Template = &barbicanv1.BarbicanSpecCore{}
 	}
@@ -62,7 +74,7 @@ func ReconcileBarbican(ctx context.Context, instance *corev1beta1.OpenStackContr
 	}
 
 	// When component services got created check if there is the need to create a route
-	if err := helper.GetClient().Get(ctx, types.NamespacedName{Name: "barbican", Namespace: instance.Namespace}, barbican); err != nil {
+	if err := helper.GetClient().Get(ctx, types.NamespacedName{Name: barbicanName, Namespace: instance.Namespace}, barbican); err != nil {
 		if !k8s_errors.IsNotFound(err) {
 			return ctrl.Result{}, err
 		}
@@ -151,7 +163,7 @@ func ReconcileBarbican(ctx context.Context, instance *corev1beta1.OpenStackContr
 	}
 
 	if instance.Spec.Barbican.Template.NodeSelector == nil {
-		instance.Spec.Barbican.Template.NodeSelector = &instance.Spec.NodeSelector
+		instance.Spec.Barbican.Template.NodeSelector = instance.GetSchedulingNodeSelector()
 	}
 
 	// When there's no Topology referenced in the Service Template, inject the
@@ -159,7 +171,7 @@ func ReconcileBarbican(ctx context.Context, instance *corev1beta1.OpenStackContr
 	// NOTE: This does not check the Service subCRs: by default the generated
 	// subCRs inherit the top-level TopologyRef unless an override is present
 	if instance.Spec.Barbican.Template.TopologyRef == nil {
-		instance.Spec.Barbican.Template.TopologyRef = instance.Spec.TopologyRef
+		instance.Spec.Barbican.Template.TopologyRef = instance.GetSchedulingTopologyRef()
 	}
 
 	// Propagate MessagingBus from top-level to template if not set
@@ -249,11 +261,11 @@ func ReconcileBarbican(ctx context.Context, instance *corev1beta1.OpenStackContr
 	return ctrl.Result{}, nil
 }
 
-// BarbicanImageMatch - return true if the Barbican images match on the ControlPlane and Version, or if Barbican is not enabled
+// BarbicanImageMatch - return true if the Barbican images match on the ControlPlane and Version, or if Barbican is not enabled or not managed
 func BarbicanImageMatch(ctx context.Context, controlPlane *corev1beta1.OpenStackControlPlane, version *corev1beta1.OpenStackVersion) bool {
 	Log := GetLogger(ctx)
 
-	if controlPlane.Spec.Barbican.Enabled {
+	if controlPlane.Spec.Barbican.Enabled && !controlPlane.IsServiceUnmanaged("Barbican") {
 		if !stringPointersEqual(controlPlane.Status.ContainerImages.BarbicanAPIImage, version.Status.ContainerImages.BarbicanAPIImage) ||
 			!stringPointersEqual(controlPlane.Status.ContainerImages.BarbicanWorkerImage, version.Status.ContainerImages.BarbicanWorkerImage) ||
 			!stringPointersEqual(controlPlane.Status.ContainerImages.BarbicanKeystoneListenerImage, version.Status.ContainerImages.BarbicanKeystoneListenerImage) {
@@ -67,6 +67,14 @@ func ReconcileCinder(ctx context.Context, instance *corev1beta1.OpenStackControl
 		return ctrl.Result{}, nil
 	}
 
+	if managed, err := EnsureServiceManaged(ctx, instance, helper, "Cinder", cinder, corev1beta1.OpenStackControlPlaneCinderReadyCondition); !managed || err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if !EnsureServiceDependencies(ctx, instance, "Cinder") {
+		return ctrl.Result{}, nil
+	}
+
 	if instance.Spec.Cinder.Template == nil {
 		instance.Spec.Cinder.Template = &cinderv1.CinderSpecCore{}
 	}
@@ -175,7 +183,7 @@ func ReconcileCinder(ctx context.Context, instance *corev1beta1.OpenStackControl
 	}
 
 	if instance.Spec.Cinder.Template.NodeSelector == nil {
-		instance.Spec.Cinder.Template.NodeSelector = &instance.Spec.NodeSelector
+		instance.Spec.Cinder.Template.NodeSelector = instance.GetSchedulingNodeSelector()
 	}
 
 	// When there's no Topology referenced in the Service Template, inject the
@@ -183,7 +191,7 @@ func ReconcileCinder(ctx context.Context, instance *corev1beta1.OpenStackControl
 	// NOTE: This does not check the Service subCRs: by default the generated
 	// subCRs inherit the top-level TopologyRef unless an override is present
 	if instance.Spec.Cinder.Template.TopologyRef == nil {
-		instance.Spec.Cinder.Template.TopologyRef = instance.Spec.TopologyRef
+		instance.Spec.Cinder.Template.TopologyRef = instance.GetSchedulingTopologyRef()
 	}
 
 	// Propagate MessagingBus from top-level to template if not set
@@ -308,10 +316,10 @@ func ReconcileCinder(ctx context.Context, instance *corev1beta1.OpenStackControl
 
 }
 
-// CinderImageMatch - return true if the Cinder images match on the ControlPlane and Version, or if Cinder is not enabled
+// CinderImageMatch - return true if the Cinder images match on the ControlPlane and Version, or if Cinder is not enabled or not managed
 func CinderImageMatch(ctx context.Context, controlPlane *corev1beta1.OpenStackControlPlane, version *corev1beta1.OpenStackVersion) bool {
 	Log := GetLogger(ctx)
-	if controlPlane.Spec.Cinder.Enabled {
+	if controlPlane.Spec.Cinder.Enabled && !controlPlane.IsServiceUnmanaged("Cinder") {
 		if !stringPointersEqual(controlPlane.Status.ContainerImages.CinderAPIImage, version.Status.ContainerImages.CinderAPIImage) ||
 			!stringPointersEqual(controlPlane.Status.ContainerImages.CinderSchedulerImage, version.Status.ContainerImages.CinderSchedulerImage) ||
 			!stringPointersEqual(controlPlane.Status.ContainerImages.CinderBackupImage, version.Status.ContainerImages.CinderBackupImage) {
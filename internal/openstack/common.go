@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -76,6 +77,122 @@ func GetLogger(ctx context.Context) logr.Logger {
 	return log.FromContext(ctx).WithName("Controllers").WithName("OpenstackControlPlane")
 }
 
+// EnsureServiceDependencies - returns false when a service required by the
+// service is disabled or, unless the WaitForDependenciesAnnotation opts out,
+// not ready yet. The Ready condition of the service then lists what it is
+// waiting for.
+func EnsureServiceDependencies(ctx context.Context, instance *corev1.OpenStackControlPlane, name string) bool {
+	notReady := instance.GetServiceDependenciesNotReady(name, instance.IsWaitingForDependencies())
+	if len(notReady) == 0 {
+		return true
+	}
+	GetLogger(ctx).Info("Waiting for the services required", "service", name, "waitingFor", notReady)
+	instance.Status.Conditions.Set(condition.FalseCondition(
+		corev1.GetServiceReadyCondition(name),
+		condition.RequestedReason,
+		condition.SeverityInfo,
+		corev1.OpenStackControlPlaneServiceDependenciesWaitingMessage,
+		name,
+		strings.Join(notReady, ", ")))
+	return false
+}
+
+// readyObject - a service CR reporting its readiness
+type readyObject interface {
+	client.Object
+	IsReady() bool
+}
+
+// EnsureServiceManaged - returns true if the openstack-operator manages the
+// service. For an unmanaged service it reads the service CR, without changing
+// it, and reports its readiness under the ready condition of the service.
+func EnsureServiceManaged(
+	ctx context.Context,
+	instance *corev1.OpenStackControlPlane,
+	helper *helper.Helper,
+	name string,
+	obj readyObject,
+	readyCondition condition.Type,
+) (bool, error) {
+	return EnsureServiceInstancesManaged(ctx, instance, helper, name, []readyObject{obj}, readyCondition)
+}
+
+// EnsureServiceInstancesManaged - EnsureServiceManaged for a service made of
+// several CRs, e.g. the Galera instances of the templates. An unmanaged
+// service is ready once all its CRs are.
+func EnsureServiceInstancesManaged(
+	ctx context.Context,
+	instance *corev1.OpenStackControlPlane,
+	helper *helper.Helper,
+	name string,
+	objs []readyObject,
+	readyCondition condition.Type,
+) (bool, error) {
+	if !instance.IsServiceUnmanaged(name) {
+		return true, nil
+	}
+	GetLogger(ctx).Info("Service not managed, skipping its reconcile", "service", name)
+
+	notFound := []string{}
+	ready := true
+	for _, obj := range objs {
+		err := helper.GetClient().Get(ctx, client.ObjectKeyFromObject(obj), obj)
+		switch {
+		case k8s_errors.IsNotFound(err):
+			notFound = append(notFound, obj.GetName())
+		case err != nil:
+			return false, err
+		case !obj.IsReady():
+			ready = false
+		}
+	}
+
+	switch {
+	case len(notFound) > 0:
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			readyCondition,
+			condition.RequestedReason,
+			condition.SeverityWarning,
+			corev1.OpenStackControlPlaneServiceUnmanagedNotFoundMessage,
+			name,
+			strings.Join(notFound, ", ")))
+	case ready:
+		instance.Status.Conditions.MarkTrue(
+			readyCondition,
+			corev1.OpenStackControlPlaneServiceUnmanagedReadyMessage,
+			name)
+	default:
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			readyCondition,
+			condition.RequestedReason,
+			condition.SeverityWarning,
+			corev1.OpenStackControlPlaneServiceUnmanagedNotReadyMessage,
+			name))
+	}
+	return false, nil
+}
+
+// templateInstances - returns the CRs of the instances of a service named
+// after its templates, sorted by name
+func templateInstances[T any](
+	instance *corev1.OpenStackControlPlane,
+	templates *map[string]T,
+	newObj func(metav1.ObjectMeta) readyObject,
+) []readyObject {
+	names := []string{}
+	if templates != nil {
+		for name := range *templates {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	objs := []readyObject{}
+	for _, name := range names {
+		objs = append(objs, newObj(metav1.ObjectMeta{Name: name, Namespace: instance.Namespace}))
+	}
+	return objs
+}
+
 // EnsureDeleted - Delete the object which in turn will clean the sub resources
 func EnsureDeleted(ctx context.Context, helper *helper.Helper, obj client.Object) (ctrl.Result, error) {
 	key := client.ObjectKeyFromObject(obj)
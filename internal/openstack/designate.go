@@ -18,11 +18,15 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
+const (
+	designateName = "designate"
+)
+
 // ReconcileDesignate -
 func ReconcileDesignate(ctx context.Context, instance *corev1beta1.OpenStackControlPlane, version *corev1beta1.OpenStackVersion, helper *helper.Helper) (ctrl.Result, error) {
 	designate := &designatev1.Designate{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "designate",
+			Name:      designateName,
 			Namespace: instance.Namespace,
 		},
 	}
@@ -48,6 +52,10 @@ func ReconcileDesignate(ctx context.Context, instance *corev1beta1.OpenStackCont
 		return ctrl.Result{}, nil
 	}
 
+	if managed, err := EnsureServiceManaged(ctx, instance, helper, "Designate", designate, corev1beta1.OpenStackControlPlaneDesignateReadyCondition); !managed || err != nil {
+		return ctrl.Result{}, err
+	}
+
 	if instance.Spec.Designate.Template == nil {
 		instance.Spec.Designate.Template = &designatev1.DesignateSpecCore{}
 	}
@@ -74,7 +82,7 @@ func ReconcileDesignate(ctx context.Context, instance *corev1beta1.OpenStackCont
 	instance.Spec.Designate.Template.DesignateAPI.TLS.CaBundleSecretName = instance.Status.TLS.CaBundleSecretName
 
 	// When component services got created check if there is the need to create a route
-	if err := helper.GetClient().Get(ctx, types.NamespacedName{Name: "designate", Namespace: instance.Namespace}, designate); err != nil {
+	if err := helper.GetClient().Get(ctx, types.NamespacedName{Name: designateName, Namespace: instance.Namespace}, designate); err != nil {
 		if !k8s_errors.IsNotFound(err) {
 			return ctrl.Result{}, err
 		}
@@ -158,7 +166,7 @@ func ReconcileDesignate(ctx context.Context, instance *corev1beta1.OpenStackCont
 	}
 
 	if instance.Spec.Designate.Template.NodeSelector == nil {
-		instance.Spec.Designate.Template.NodeSelector = &instance.Spec.NodeSelector
+		instance.Spec.Designate.Template.NodeSelector = instance.GetSchedulingNodeSelector()
 	}
 
 	// When there's no Topology referenced in the Service Template, inject the
@@ -166,7 +174,7 @@ func ReconcileDesignate(ctx context.Context, instance *corev1beta1.OpenStackCont
 	// NOTE: This does not check the Service subCRs: by default the generated
 	// subCRs inherit the top-level TopologyRef unless an override is present
 	if instance.Spec.Designate.Template.TopologyRef == nil {
-		instance.Spec.Designate.Template.TopologyRef = instance.Spec.TopologyRef
+		instance.Spec.Designate.Template.TopologyRef = instance.GetSchedulingTopologyRef()
 	}
 
 	// Propagate MessagingBus from top-level to template if not set
@@ -283,11 +291,11 @@ func ReconcileDesignate(ctx context.Context, instance *corev1beta1.OpenStackCont
 
 }
 
-// DesignateImageMatch - return true if the Designate images match on the ControlPlane and Version, or if Designate is not enabled
+// DesignateImageMatch - return true if the Designate images match on the ControlPlane and Version, or if Designate is not enabled or not managed
 func DesignateImageMatch(ctx context.Context, controlPlane *corev1beta1.OpenStackControlPlane, version *corev1beta1.OpenStackVersion) bool {
 	Log := GetLogger(ctx)
 
-	if controlPlane.Spec.Designate.Enabled {
+	if controlPlane.Spec.Designate.Enabled && !controlPlane.IsServiceUnmanaged("Designate") {
 		if !stringPointersEqual(controlPlane.Status.ContainerImages.DesignateAPIImage, version.Status.ContainerImages.DesignateAPIImage) ||
 			!stringPointersEqual(controlPlane.Status.ContainerImages.DesignateCentralImage, version.Status.ContainerImages.DesignateCentralImage) ||
 			!stringPointersEqual(controlPlane.Status.ContainerImages.DesignateMdnsImage, version.Status.ContainerImages.DesignateMdnsImage) ||
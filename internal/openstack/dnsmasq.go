@@ -34,6 +34,10 @@ func ReconcileDNSMasqs(ctx context.Context, instance *corev1beta1.OpenStackContr
 		return ctrl.Result{}, nil
 	}
 
+	if managed, err := EnsureServiceManaged(ctx, instance, helper, "DNS", dnsmasq, corev1beta1.OpenStackControlPlaneDNSReadyCondition); !managed || err != nil {
+		return ctrl.Result{}, err
+	}
+
 	Log := GetLogger(ctx)
 
 	if instance.Spec.DNS.Template == nil {
@@ -41,7 +45,7 @@ func ReconcileDNSMasqs(ctx context.Context, instance *corev1beta1.OpenStackContr
 	}
 
 	if instance.Spec.DNS.Template.NodeSelector == nil {
-		instance.Spec.DNS.Template.NodeSelector = &instance.Spec.NodeSelector
+		instance.Spec.DNS.Template.NodeSelector = instance.GetSchedulingNodeSelector()
 	}
 
 	// When there's no Topology referenced in the Service Template, inject the
@@ -49,7 +53,7 @@ func ReconcileDNSMasqs(ctx context.Context, instance *corev1beta1.OpenStackContr
 	// NOTE: This does not check the Service subCRs: by default the generated
 	// subCRs inherit the top-level TopologyRef unless an override is present
 	if instance.Spec.DNS.Template.TopologyRef == nil {
-		instance.Spec.DNS.Template.TopologyRef = instance.Spec.TopologyRef
+		instance.Spec.DNS.Template.TopologyRef = instance.GetSchedulingTopologyRef()
 	}
 
 	Log.Info("Reconciling DNSMasq", "DNSMasq.Namespace", instance.Namespace, "DNSMasq.Name", "dnsmasq")
@@ -104,10 +108,10 @@ func ReconcileDNSMasqs(ctx context.Context, instance *corev1beta1.OpenStackContr
 
 }
 
-// DnsmasqImageMatch - return true if the Dnsmasq images match on the ControlPlane and Version, or if Dnsmasq is not enabled
+// DnsmasqImageMatch - return true if the Dnsmasq images match on the ControlPlane and Version, or if Dnsmasq is not enabled or not managed
 func DnsmasqImageMatch(ctx context.Context, controlPlane *corev1beta1.OpenStackControlPlane, version *corev1beta1.OpenStackVersion) bool {
 	Log := GetLogger(ctx)
-	if controlPlane.Spec.DNS.Enabled {
+	if controlPlane.Spec.DNS.Enabled && !controlPlane.IsServiceUnmanaged("DNS") {
 		if !stringPointersEqual(controlPlane.Status.ContainerImages.InfraDnsmasqImage, version.Status.ContainerImages.InfraDnsmasqImage) {
 			Log.Info("Dnsmasq images do not match", "controlPlane.Status.ContainerImages.InfraDnsmasqImage", controlPlane.Status.ContainerImages.InfraDnsmasqImage, "version.Status.ContainerImages.InfraDnsmasqImage", version.Status.ContainerImages.InfraDnsmasqImage)
 			return false
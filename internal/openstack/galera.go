@@ -54,11 +54,27 @@ func deleteUndefinedGaleras(
 		return ctrl.Result{}, fmt.Errorf("could not get galeras %w", err)
 	}
 
+	refs, err := instance.GetGaleraReferences()
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("could not get the references to the galeras %w", err)
+	}
+
 	var delErrs []error
 	for _, galeraObj := range galeraList.Items {
 		// if it is not defined in the OpenStackControlPlane then delete it from k8s.
 		if _, exists := (*instance.Spec.Galera.Templates)[galeraObj.Name]; !exists {
 			if object.CheckOwnerRefExist(instance.GetUID(), galeraObj.OwnerReferences) {
+				// the webhook refuses these deletions, don't rely on it
+				if paths, referenced := refs[galeraObj.Name]; referenced {
+					log.Info("Not deleting Galera, still referenced", "", galeraObj.Name, "references", paths)
+					continue
+				}
+				if !instance.IsDeletionConfirmed(galeraObj.Name) {
+					log.Info("Not deleting Galera, deletion not confirmed", "", galeraObj.Name,
+						"annotation", corev1beta1.ConfirmDeleteAnnotation)
+					continue
+				}
+
 				log.Info("Deleting Galera", "", galeraObj.Name)
 
 				certName := galeraCertName(galeraObj.Name)
@@ -93,9 +109,17 @@ func ReconcileGaleras(
 ) (ctrl.Result, error) {
 	log := GetLogger(ctx)
 	if !instance.Spec.Galera.Enabled {
+		removeStaleScalingConditions(instance, "Galera", nil)
 		return ctrl.Result{}, nil
 	}
 
+	galeras := templateInstances(instance, instance.Spec.Galera.Templates, func(meta metav1.ObjectMeta) readyObject {
+		return &mariadbv1.Galera{ObjectMeta: meta}
+	})
+	if managed, err := EnsureServiceInstancesManaged(ctx, instance, helper, "Galera", galeras, corev1beta1.OpenStackControlPlaneMariaDBReadyCondition); !managed || err != nil {
+		return ctrl.Result{}, err
+	}
+
 	var failures = []string{}
 	var inprogress = []string{}
 	clusterDomain := clusterdns.GetDNSClusterDomain()
@@ -226,6 +250,8 @@ func ReconcileGaleras(
 		)
 	}
 
+	removeStaleScalingConditions(instance, "Galera", templateNames)
+
 	_, errs := deleteUndefinedGaleras(ctx, instance, helper)
 	if errs != nil {
 		instance.Status.Conditions.Set(condition.FalseCondition(
@@ -267,7 +293,7 @@ func reconcileGalera(
 	}
 
 	if spec.NodeSelector == nil {
-		spec.NodeSelector = &instance.Spec.NodeSelector
+		spec.NodeSelector = instance.GetSchedulingNodeSelector()
 	}
 
 	// When there's no Topology referenced in the Service Template, inject the
@@ -275,12 +301,18 @@ func reconcileGalera(
 	// NOTE: This does not check the Service subCRs: by default the generated
 	// subCRs inherit the top-level TopologyRef unless an override is present
 	if spec.TopologyRef == nil {
-		spec.TopologyRef = instance.Spec.TopologyRef
+		spec.TopologyRef = instance.GetSchedulingTopologyRef()
 	}
 
 	log.Info("Reconciling Galera", "Galera.Namespace", instance.Namespace, "Galera.Name", name)
 	op, err := controllerutil.CreateOrPatch(ctx, helper.GetClient(), galera, func() error {
+		// scale an existing Galera one step at a time, only while it's healthy
+		current := galera.Spec.Replicas
+		healthy := galera.Status.ObservedGeneration == galera.Generation && galera.IsReady()
 		spec.DeepCopyInto(&galera.Spec.GaleraSpecCore)
+		if !galera.CreationTimestamp.IsZero() && current != nil && spec.Replicas != nil {
+			galera.Spec.Replicas = ptr.To(scalingStep(*current, *spec.Replicas, galeraScalingStep, healthy))
+		}
 		galera.Spec.ContainerImage = *version.Status.ContainerImages.MariadbImage
 		err := controllerutil.SetControllerReference(helper.GetBeforeObject(), galera, helper.GetScheme())
 		if err != nil {
@@ -297,6 +329,15 @@ func reconcileGalera(
 		log.Info(fmt.Sprintf("Galera %s - %s", galera.Name, op))
 	}
 
+	if galera.Spec.Replicas != nil && spec.Replicas != nil {
+		setScalingCondition(instance, "Galera", name, *galera.Spec.Replicas, *spec.Replicas)
+		if *galera.Spec.Replicas != *spec.Replicas {
+			log.Info("Galera scaling in progress", "Galera.Name", name,
+				"replicas", *galera.Spec.Replicas, "desired", *spec.Replicas)
+			return galeraCreating, galera, nil
+		}
+	}
+
 	if galera.Status.ObservedGeneration == galera.Generation && galera.IsReady() {
 		instance.Status.ContainerImages.MariadbImage = version.Status.ContainerImages.MariadbImage
 		return galeraReady, galera, nil
@@ -305,10 +346,10 @@ func reconcileGalera(
 	return galeraCreating, galera, nil
 }
 
-// GaleraImageMatch - return true if the Galera images match on the ControlPlane and Version, or if Galera is not enabled
+// GaleraImageMatch - return true if the Galera images match on the ControlPlane and Version, or if Galera is not enabled or not managed
 func GaleraImageMatch(ctx context.Context, controlPlane *corev1beta1.OpenStackControlPlane, version *corev1beta1.OpenStackVersion) bool {
 	log := GetLogger(ctx)
-	if controlPlane.Spec.Galera.Enabled {
+	if controlPlane.Spec.Galera.Enabled && !controlPlane.IsServiceUnmanaged("Galera") {
 		if !stringPointersEqual(controlPlane.Status.ContainerImages.MariadbImage, version.Status.ContainerImages.MariadbImage) {
 			log.Info("Galera images do not match", "controlPlane.Status.ContainerImages.MariadbImage", controlPlane.Status.ContainerImages.MariadbImage, "version.Status.ContainerImages.MariadbImage", version.Status.ContainerImages.MariadbImage)
 			return false
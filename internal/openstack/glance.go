@@ -72,6 +72,14 @@ func ReconcileGlance(ctx context.Context, instance *corev1beta1.OpenStackControl
 		return ctrl.Result{}, nil
 	}
 
+	if managed, err := EnsureServiceManaged(ctx, instance, helper, "Glance", glance, corev1beta1.OpenStackControlPlaneGlanceReadyCondition); !managed || err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if !EnsureServiceDependencies(ctx, instance, "Glance") {
+		return ctrl.Result{}, nil
+	}
+
 	if instance.Spec.Glance.Template == nil {
 		instance.Spec.Glance.Template = &glancev1.GlanceSpecCore{}
 	}
@@ -96,7 +104,7 @@ func ReconcileGlance(ctx context.Context, instance *corev1beta1.OpenStackControl
 	}
 
 	if instance.Spec.Glance.Template.NodeSelector == nil {
-		instance.Spec.Glance.Template.NodeSelector = &instance.Spec.NodeSelector
+		instance.Spec.Glance.Template.NodeSelector = instance.GetSchedulingNodeSelector()
 	}
 
 	// When there's no Topology referenced in the Service Template, inject the
@@ -104,7 +112,7 @@ func ReconcileGlance(ctx context.Context, instance *corev1beta1.OpenStackControl
 	// NOTE: This does not check the Service subCRs: by default the generated
 	// subCRs inherit the top-level TopologyRef unless an override is present
 	if instance.Spec.Glance.Template.TopologyRef == nil {
-		instance.Spec.Glance.Template.TopologyRef = instance.Spec.TopologyRef
+		instance.Spec.Glance.Template.TopologyRef = instance.GetSchedulingTopologyRef()
 	}
 
 	// When component services got created check if there is the need to create a route
@@ -343,10 +351,10 @@ func getGlanceAPILabelMap(name string, apiName string, apiType string) map[strin
 	}
 }
 
-// GlanceImageMatch - return true if the glance images match on the ControlPlane and Version, or if Glance is not enabled
+// GlanceImageMatch - return true if the glance images match on the ControlPlane and Version, or if Glance is not enabled or not managed
 func GlanceImageMatch(ctx context.Context, controlPlane *corev1beta1.OpenStackControlPlane, version *corev1beta1.OpenStackVersion) bool {
 	Log := GetLogger(ctx)
-	if controlPlane.Spec.Glance.Enabled {
+	if controlPlane.Spec.Glance.Enabled && !controlPlane.IsServiceUnmanaged("Glance") {
 		if !stringPointersEqual(controlPlane.Status.ContainerImages.GlanceAPIImage, version.Status.ContainerImages.GlanceAPIImage) {
 			Log.Info("Glance API image mismatch", "controlPlane.Status.ContainerImages.GlanceAPIImage", controlPlane.Status.ContainerImages.GlanceAPIImage, "version.Status.ContainerImages.GlanceAPIImage", version.Status.ContainerImages.GlanceAPIImage)
 			return false
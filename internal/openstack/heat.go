@@ -47,6 +47,14 @@ func ReconcileHeat(ctx context.Context, instance *corev1beta1.OpenStackControlPl
 		return ctrl.Result{}, nil
 	}
 
+	if managed, err := EnsureServiceManaged(ctx, instance, helper, "Heat", heat, corev1beta1.OpenStackControlPlaneHeatReadyCondition); !managed || err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if !EnsureServiceDependencies(ctx, instance, "Heat") {
+		return ctrl.Result{}, nil
+	}
+
 	if instance.Spec.Heat.Template == nil {
 		instance.Spec.Heat.Template = &heatv1.HeatSpecCore{}
 	}
@@ -55,7 +63,7 @@ func ReconcileHeat(ctx context.Context, instance *corev1beta1.OpenStackControlPl
 	// via annotation-based triggers. No direct spec mutation here to avoid GitOps conflicts.
 
 	if instance.Spec.Heat.Template.NodeSelector == nil {
-		instance.Spec.Heat.Template.NodeSelector = &instance.Spec.NodeSelector
+		instance.Spec.Heat.Template.NodeSelector = instance.GetSchedulingNodeSelector()
 	}
 
 	// When there's no Topology referenced in the Service Template, inject the
@@ -63,7 +71,7 @@ func ReconcileHeat(ctx context.Context, instance *corev1beta1.OpenStackControlPl
 	// NOTE: This does not check the Service subCRs: by default the generated
 	// subCRs inherit the top-level TopologyRef unless an override is present
 	if instance.Spec.Heat.Template.TopologyRef == nil {
-		instance.Spec.Heat.Template.TopologyRef = instance.Spec.TopologyRef
+		instance.Spec.Heat.Template.TopologyRef = instance.GetSchedulingTopologyRef()
 	}
 
 	// Propagate MessagingBus from top-level to template if not set
@@ -298,10 +306,10 @@ func ReconcileHeat(ctx context.Context, instance *corev1beta1.OpenStackControlPl
 	return ctrl.Result{}, nil
 }
 
-// HeatImageMatch - return true if the heat images match on the ControlPlane and Version, or if Heat is not enabled
+// HeatImageMatch - return true if the heat images match on the ControlPlane and Version, or if Heat is not enabled or not managed
 func HeatImageMatch(ctx context.Context, controlPlane *corev1beta1.OpenStackControlPlane, version *corev1beta1.OpenStackVersion) bool {
 	Log := GetLogger(ctx)
-	if controlPlane.Spec.Heat.Enabled {
+	if controlPlane.Spec.Heat.Enabled && !controlPlane.IsServiceUnmanaged("Heat") {
 		if !stringPointersEqual(controlPlane.Status.ContainerImages.HeatAPIImage, version.Status.ContainerImages.HeatAPIImage) ||
 			!stringPointersEqual(controlPlane.Status.ContainerImages.HeatCfnapiImage, version.Status.ContainerImages.HeatCfnapiImage) ||
 			!stringPointersEqual(controlPlane.Status.ContainerImages.HeatEngineImage, version.Status.ContainerImages.HeatEngineImage) {
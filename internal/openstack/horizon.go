@@ -21,6 +21,10 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
+const (
+	horizonName = "horizon"
+)
+
 // ReconcileHorizon -
 func ReconcileHorizon(ctx context.Context, instance *corev1beta1.OpenStackControlPlane, version *corev1beta1.OpenStackVersion, helper *helper.Helper) (ctrl.Result, error) {
 	const (
@@ -30,7 +34,7 @@ func ReconcileHorizon(ctx context.Context, instance *corev1beta1.OpenStackContro
 
 	horizon := &horizonv1.Horizon{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "horizon",
+			Name:      horizonName,
 			Namespace: instance.Namespace,
 		},
 	}
@@ -46,12 +50,20 @@ func ReconcileHorizon(ctx context.Context, instance *corev1beta1.OpenStackContro
 		return ctrl.Result{}, nil
 	}
 
+	if managed, err := EnsureServiceManaged(ctx, instance, helper, "Horizon", horizon, corev1beta1.OpenStackControlPlaneHorizonReadyCondition); !managed || err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if !EnsureServiceDependencies(ctx, instance, "Horizon") {
+		return ctrl.Result{}, nil
+	}
+
 	if instance.Spec.Horizon.Template == nil {
 		instance.Spec.Horizon.Template = &horizonv1.HorizonSpecCore{}
 	}
 
 	if instance.Spec.Horizon.Template.NodeSelector == nil {
-		instance.Spec.Horizon.Template.NodeSelector = &instance.Spec.NodeSelector
+		instance.Spec.Horizon.Template.NodeSelector = instance.GetSchedulingNodeSelector()
 	}
 
 	// When there's no Topology referenced in the Service Template, inject the
@@ -59,7 +71,7 @@ func ReconcileHorizon(ctx context.Context, instance *corev1beta1.OpenStackContro
 	// NOTE: This does not check the Service subCRs: by default the generated
 	// subCRs inherit the top-level TopologyRef unless an override is present
 	if instance.Spec.Horizon.Template.TopologyRef == nil {
-		instance.Spec.Horizon.Template.TopologyRef = instance.Spec.TopologyRef
+		instance.Spec.Horizon.Template.TopologyRef = instance.GetSchedulingTopologyRef()
 	}
 
 	// add selector to service overrides
@@ -74,7 +86,7 @@ func ReconcileHorizon(ctx context.Context, instance *corev1beta1.OpenStackContro
 		horizon.Name)
 
 	// When component services got created check if there is the need to create a route
-	if err := helper.GetClient().Get(ctx, types.NamespacedName{Name: "horizon", Namespace: instance.Namespace}, horizon); err != nil {
+	if err := helper.GetClient().Get(ctx, types.NamespacedName{Name: horizonName, Namespace: instance.Namespace}, horizon); err != nil {
 		if !k8s_errors.IsNotFound(err) {
 			return ctrl.Result{}, err
 		}
@@ -212,10 +224,10 @@ func ReconcileHorizon(ctx context.Context, instance *corev1beta1.OpenStackContro
 	return ctrl.Result{}, nil
 }
 
-// HorizonImageMatch - return true if horizon images match on the ControlPlane and Version, or if Horizon is not enabled
+// HorizonImageMatch - return true if horizon images match on the ControlPlane and Version, or if Horizon is not enabled or not managed
 func HorizonImageMatch(ctx context.Context, controlPlane *corev1beta1.OpenStackControlPlane, version *corev1beta1.OpenStackVersion) bool {
 	Log := GetLogger(ctx)
-	if controlPlane.Spec.Horizon.Enabled {
+	if controlPlane.Spec.Horizon.Enabled && !controlPlane.IsServiceUnmanaged("Horizon") {
 		if !stringPointersEqual(controlPlane.Status.ContainerImages.HorizonImage, version.Status.ContainerImages.HorizonImage) {
 			Log.Info("Horizon images do not match", "ControlPlane.Status.ContainerImages.HorizonImage", controlPlane.Status.ContainerImages.HorizonImage, "Version.Status.ContainerImages.HorizonImage", version.Status.ContainerImages.HorizonImage)
 			return false
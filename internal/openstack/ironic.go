@@ -50,6 +50,10 @@ func ReconcileIronic(ctx context.Context, instance *corev1beta1.OpenStackControl
 		return ctrl.Result{}, nil
 	}
 
+	if managed, err := EnsureServiceManaged(ctx, instance, helper, "Ironic", ironic, corev1beta1.OpenStackControlPlaneIronicReadyCondition); !managed || err != nil {
+		return ctrl.Result{}, err
+	}
+
 	if instance.Spec.Ironic.Template == nil {
 		instance.Spec.Ironic.Template = &ironicv1.IronicSpecCore{}
 	}
@@ -59,7 +63,7 @@ func ReconcileIronic(ctx context.Context, instance *corev1beta1.OpenStackControl
 	// This applies to both Ironic main template and IronicNeutronAgent.
 
 	if instance.Spec.Ironic.Template.NodeSelector == nil {
-		instance.Spec.Ironic.Template.NodeSelector = &instance.Spec.NodeSelector
+		instance.Spec.Ironic.Template.NodeSelector = instance.GetSchedulingNodeSelector()
 	}
 
 	// When there's no Topology referenced in the Service Template, inject the
@@ -67,7 +71,7 @@ func ReconcileIronic(ctx context.Context, instance *corev1beta1.OpenStackControl
 	// NOTE: This does not check the Service subCRs: by default the generated
 	// subCRs inherit the top-level TopologyRef unless an override is present
 	if instance.Spec.Ironic.Template.TopologyRef == nil {
-		instance.Spec.Ironic.Template.TopologyRef = instance.Spec.TopologyRef
+		instance.Spec.Ironic.Template.TopologyRef = instance.GetSchedulingTopologyRef()
 	}
 
 	// Propagate MessagingBus from top-level to template if not set
@@ -336,7 +340,7 @@ func ReconcileIronic(ctx context.Context, instance *corev1beta1.OpenStackControl
 // IronicImageMatch returns true if the ironic images match on the ControlPlane and Version, or if Ironic is not enabled
 func IronicImageMatch(ctx context.Context, controlPlane *corev1beta1.OpenStackControlPlane, version *corev1beta1.OpenStackVersion) bool {
 	Log := GetLogger(ctx)
-	if controlPlane.Spec.Ironic.Enabled {
+	if controlPlane.Spec.Ironic.Enabled && !controlPlane.IsServiceUnmanaged("Ironic") {
 		if !stringPointersEqual(controlPlane.Status.ContainerImages.IronicAPIImage, version.Status.ContainerImages.IronicAPIImage) ||
 			!stringPointersEqual(controlPlane.Status.ContainerImages.IronicConductorImage, version.Status.ContainerImages.IronicConductorImage) ||
 			!stringPointersEqual(controlPlane.Status.ContainerImages.IronicInspectorImage, version.Status.ContainerImages.IronicInspectorImage) ||
@@ -18,11 +18,15 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
+const (
+	keystoneName = "keystone"
+)
+
 // ReconcileKeystoneAPI -
 func ReconcileKeystoneAPI(ctx context.Context, instance *corev1beta1.OpenStackControlPlane, version *corev1beta1.OpenStackVersion, helper *helper.Helper) (ctrl.Result, error) {
 	keystoneAPI := &keystonev1.KeystoneAPI{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "keystone", //FIXME (keystone doesn't seem to work unless named "keystone")
+			Name:      keystoneName, //FIXME (keystone doesn't seem to work unless named "keystone")
 			Namespace: instance.Namespace,
 		},
 	}
@@ -39,6 +43,14 @@ func ReconcileKeystoneAPI(ctx context.Context, instance *corev1beta1.OpenStackCo
 		return ctrl.Result{}, nil
 	}
 
+	if managed, err := EnsureServiceManaged(ctx, instance, helper, "Keystone", keystoneAPI, corev1beta1.OpenStackControlPlaneKeystoneAPIReadyCondition); !managed || err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if !EnsureServiceDependencies(ctx, instance, "Keystone") {
+		return ctrl.Result{}, nil
+	}
+
 	if instance.Spec.Keystone.Template == nil {
 		instance.Spec.Keystone.Template = &keystonev1.KeystoneAPISpecCore{}
 	}
@@ -58,7 +70,7 @@ func ReconcileKeystoneAPI(ctx context.Context, instance *corev1beta1.OpenStackCo
 	}
 
 	// When component services got created check if there is the need to create a route
-	if err := helper.GetClient().Get(ctx, types.NamespacedName{Name: "keystone", Namespace: instance.Namespace}, keystoneAPI); err != nil {
+	if err := helper.GetClient().Get(ctx, types.NamespacedName{Name: keystoneName, Namespace: instance.Namespace}, keystoneAPI); err != nil {
 		if !k8s_errors.IsNotFound(err) {
 			return ctrl.Result{}, err
 		}
@@ -107,7 +119,7 @@ func ReconcileKeystoneAPI(ctx context.Context, instance *corev1beta1.OpenStackCo
 	}
 
 	if instance.Spec.Keystone.Template.NodeSelector == nil {
-		instance.Spec.Keystone.Template.NodeSelector = &instance.Spec.NodeSelector
+		instance.Spec.Keystone.Template.NodeSelector = instance.GetSchedulingNodeSelector()
 	}
 
 	// When there's no Topology referenced in the Service Template, inject the
@@ -115,7 +127,7 @@ func ReconcileKeystoneAPI(ctx context.Context, instance *corev1beta1.OpenStackCo
 	// NOTE: This does not check the Service subCRs: by default the generated
 	// subCRs inherit the top-level TopologyRef unless an override is present
 	if instance.Spec.Keystone.Template.TopologyRef == nil {
-		instance.Spec.Keystone.Template.TopologyRef = instance.Spec.TopologyRef
+		instance.Spec.Keystone.Template.TopologyRef = instance.GetSchedulingTopologyRef()
 	}
 
 	// Propagate NotificationsBus from top-level to template if not set
@@ -200,10 +212,10 @@ func ReconcileKeystoneAPI(ctx context.Context, instance *corev1beta1.OpenStackCo
 	return ctrl.Result{}, nil
 }
 
-// KeystoneImageMatch - return true if the keystone images match on the ControlPlane and Version, or if Keystone is not enabled
+// KeystoneImageMatch - return true if the keystone images match on the ControlPlane and Version, or if Keystone is not enabled or not managed
 func KeystoneImageMatch(ctx context.Context, controlPlane *corev1beta1.OpenStackControlPlane, version *corev1beta1.OpenStackVersion) bool {
 	Log := GetLogger(ctx)
-	if controlPlane.Spec.Keystone.Enabled {
+	if controlPlane.Spec.Keystone.Enabled && !controlPlane.IsServiceUnmanaged("Keystone") {
 		if !stringPointersEqual(controlPlane.Status.ContainerImages.KeystoneAPIImage, version.Status.ContainerImages.KeystoneAPIImage) {
 			Log.Info("Keystone API image mismatch", "controlPlane.Status.ContainerImages.KeystoneAPIImage", controlPlane.Status.ContainerImages.KeystoneAPIImage, "version.Status.ContainerImages.KeystoneAPIImage", version.Status.ContainerImages.KeystoneAPIImage)
 			return false
@@ -45,6 +45,10 @@ func ReconcileManila(ctx context.Context, instance *corev1beta1.OpenStackControl
 		return ctrl.Result{}, nil
 	}
 
+	if managed, err := EnsureServiceManaged(ctx, instance, helper, "Manila", manila, corev1beta1.OpenStackControlPlaneManilaReadyCondition); !managed || err != nil {
+		return ctrl.Result{}, err
+	}
+
 	if instance.Spec.Manila.Template == nil {
 		instance.Spec.Manila.Template = &manilav1.ManilaSpecCore{}
 	}
@@ -154,7 +158,7 @@ func ReconcileManila(ctx context.Context, instance *corev1beta1.OpenStackControl
 	}
 
 	if instance.Spec.Manila.Template.NodeSelector == nil {
-		instance.Spec.Manila.Template.NodeSelector = &instance.Spec.NodeSelector
+		instance.Spec.Manila.Template.NodeSelector = instance.GetSchedulingNodeSelector()
 	}
 
 	// There's no Topology referenced in Manila Template, inject the top-level
@@ -162,7 +166,7 @@ func ReconcileManila(ctx context.Context, instance *corev1beta1.OpenStackControl
 	// NOTE: This does not check the Service subCRs: by default the generated
 	// subCRs inherit the top-level TopologyRef unless an override is present
 	if instance.Spec.Manila.Template.TopologyRef == nil {
-		instance.Spec.Manila.Template.TopologyRef = instance.Spec.TopologyRef
+		instance.Spec.Manila.Template.TopologyRef = instance.GetSchedulingTopologyRef()
 	}
 
 	// Propagate MessagingBus from top-level to template if not set
@@ -282,10 +286,10 @@ func ReconcileManila(ctx context.Context, instance *corev1beta1.OpenStackControl
 	return ctrl.Result{}, nil
 }
 
-// ManilaImageMatch - return true if the Manila images match on the ControlPlane and Version, or if Manila is not enabled
+// ManilaImageMatch - return true if the Manila images match on the ControlPlane and Version, or if Manila is not enabled or not managed
 func ManilaImageMatch(ctx context.Context, controlPlane *corev1beta1.OpenStackControlPlane, version *corev1beta1.OpenStackVersion) bool {
 	Log := GetLogger(ctx)
-	if controlPlane.Spec.Manila.Enabled {
+	if controlPlane.Spec.Manila.Enabled && !controlPlane.IsServiceUnmanaged("Manila") {
 		if !stringPointersEqual(controlPlane.Status.ContainerImages.ManilaAPIImage, version.Status.ContainerImages.ManilaAPIImage) ||
 			!stringPointersEqual(controlPlane.Status.ContainerImages.ManilaSchedulerImage, version.Status.ContainerImages.ManilaSchedulerImage) {
 			Log.Info("Manila images do not match")
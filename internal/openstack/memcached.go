@@ -43,6 +43,13 @@ func ReconcileMemcacheds(
 	var inprogress = []string{}
 	var conditions = condition.Conditions{}
 
+	memcachedInstances := templateInstances(instance, instance.Spec.Memcached.Templates, func(meta metav1.ObjectMeta) readyObject {
+		return &memcachedv1.Memcached{ObjectMeta: meta}
+	})
+	if managed, err := EnsureServiceInstancesManaged(ctx, instance, helper, "Memcached", memcachedInstances, corev1beta1.OpenStackControlPlaneMemcachedReadyCondition); !managed || err != nil {
+		return ctrl.Result{}, err
+	}
+
 	// We first remove memcacheds no longer owned
 	memcacheds := &memcachedv1.MemcachedList{}
 	listOpts := []client.ListOption{
@@ -274,7 +281,7 @@ func reconcileMemcached(
 	}
 
 	if spec.NodeSelector == nil {
-		spec.NodeSelector = &instance.Spec.NodeSelector
+		spec.NodeSelector = instance.GetSchedulingNodeSelector()
 	}
 
 	// When there's no Topology referenced in the Service Template, inject the
@@ -282,7 +289,7 @@ func reconcileMemcached(
 	// NOTE: This does not check the Service subCRs: by default the generated
 	// subCRs inherit the top-level TopologyRef unless an override is present
 	if spec.TopologyRef == nil {
-		spec.TopologyRef = instance.Spec.TopologyRef
+		spec.TopologyRef = instance.GetSchedulingTopologyRef()
 	}
 
 	op, err := controllerutil.CreateOrPatch(ctx, helper.GetClient(), memcached, func() error {
@@ -319,10 +326,10 @@ func reconcileMemcached(
 	return memcachedCreating, memcached, ctrl.Result{}, nil
 }
 
-// MemcachedImageMatch - return true if the memcached images match on the ControlPlane and Version, or if Memcached is not enabled
+// MemcachedImageMatch - return true if the memcached images match on the ControlPlane and Version, or if Memcached is not enabled or not managed
 func MemcachedImageMatch(ctx context.Context, controlPlane *corev1beta1.OpenStackControlPlane, version *corev1beta1.OpenStackVersion) bool {
 	Log := GetLogger(ctx)
-	if controlPlane.Spec.Memcached.Enabled {
+	if controlPlane.Spec.Memcached.Enabled && !controlPlane.IsServiceUnmanaged("Memcached") {
 		if !stringPointersEqual(controlPlane.Status.ContainerImages.InfraMemcachedImage, version.Status.ContainerImages.InfraMemcachedImage) {
 			Log.Info("Memcached images do not match", "controlPlane.Status.ContainerImages.InfraMemcachedImage", controlPlane.Status.ContainerImages.InfraMemcachedImage, "version.Status.ContainerImages.InfraMemcachedImage", version.Status.ContainerImages.InfraMemcachedImage)
 			return false
@@ -22,11 +22,15 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
+const (
+	neutronName = "neutron"
+)
+
 // ReconcileNeutron -
 func ReconcileNeutron(ctx context.Context, instance *corev1beta1.OpenStackControlPlane, version *corev1beta1.OpenStackVersion, helper *helper.Helper) (ctrl.Result, error) {
 	neutronAPI := &neutronv1.NeutronAPI{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "neutron",
+			Name:      neutronName,
 			Namespace: instance.Namespace,
 		},
 	}
@@ -46,6 +50,14 @@ func ReconcileNeutron(ctx context.Context, instance *corev1beta1.OpenStackContro
 		return ctrl.Result{}, nil
 	}
 
+	if managed, err := EnsureServiceManaged(ctx, instance, helper, "Neutron", neutronAPI, corev1beta1.OpenStackControlPlaneNeutronReadyCondition); !managed || err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if !EnsureServiceDependencies(ctx, instance, "Neutron") {
+		return ctrl.Result{}, nil
+	}
+
 	if instance.Spec.Neutron.Template == nil {
 		instance.Spec.Neutron.Template = &neutronv1.NeutronAPISpecCore{}
 	}
@@ -65,7 +77,7 @@ func ReconcileNeutron(ctx context.Context, instance *corev1beta1.OpenStackContro
 	}
 
 	// When component services got created check if there is the need to create a route
-	if err := helper.GetClient().Get(ctx, types.NamespacedName{Name: "neutron", Namespace: instance.Namespace}, neutronAPI); err != nil {
+	if err := helper.GetClient().Get(ctx, types.NamespacedName{Name: neutronName, Namespace: instance.Namespace}, neutronAPI); err != nil {
 		if !k8s_errors.IsNotFound(err) {
 			return ctrl.Result{}, err
 		}
@@ -191,7 +203,7 @@ func ReconcileNeutron(ctx context.Context, instance *corev1beta1.OpenStackContro
 	}
 
 	if instance.Spec.Neutron.Template.NodeSelector == nil {
-		instance.Spec.Neutron.Template.NodeSelector = &instance.Spec.NodeSelector
+		instance.Spec.Neutron.Template.NodeSelector = instance.GetSchedulingNodeSelector()
 	}
 
 	// When there's no Topology referenced in the Service Template, inject the
@@ -199,7 +211,7 @@ func ReconcileNeutron(ctx context.Context, instance *corev1beta1.OpenStackContro
 	// NOTE: This does not check the Service subCRs: by default the generated
 	// subCRs inherit the top-level TopologyRef unless an override is present
 	if instance.Spec.Neutron.Template.TopologyRef == nil {
-		instance.Spec.Neutron.Template.TopologyRef = instance.Spec.TopologyRef
+		instance.Spec.Neutron.Template.TopologyRef = instance.GetSchedulingTopologyRef()
 	}
 
 	// Propagate MessagingBus from top-level to template if not set
@@ -285,10 +297,10 @@ func ReconcileNeutron(ctx context.Context, instance *corev1beta1.OpenStackContro
 
 }
 
-// NeutronImageMatch - return true if the neutron images match on the ControlPlane and Version, or if Neutron is not enabled
+// NeutronImageMatch - return true if the neutron images match on the ControlPlane and Version, or if Neutron is not enabled or not managed
 func NeutronImageMatch(ctx context.Context, controlPlane *corev1beta1.OpenStackControlPlane, version *corev1beta1.OpenStackVersion) bool {
 	Log := GetLogger(ctx)
-	if controlPlane.Spec.Neutron.Enabled {
+	if controlPlane.Spec.Neutron.Enabled && !controlPlane.IsServiceUnmanaged("Neutron") {
 		if !stringPointersEqual(controlPlane.Status.ContainerImages.NeutronAPIImage, version.Status.ContainerImages.NeutronAPIImage) {
 			Log.Info("Neutron API image mismatch", "controlPlane.Status.ContainerImages.NeutronAPIImage", controlPlane.Status.ContainerImages.NeutronAPIImage, "version.Status.ContainerImages.NeutronAPIImage", version.Status.ContainerImages.NeutronAPIImage)
 			return false
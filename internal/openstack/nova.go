@@ -68,6 +68,14 @@ func ReconcileNova(ctx context.Context, instance *corev1beta1.OpenStackControlPl
 		return ctrl.Result{}, nil
 	}
 
+	if managed, err := EnsureServiceManaged(ctx, instance, helper, "Nova", nova, corev1beta1.OpenStackControlPlaneNovaReadyCondition); !managed || err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if !EnsureServiceDependencies(ctx, instance, "Nova") {
+		return ctrl.Result{}, nil
+	}
+
 	if instance.Spec.Nova.Template == nil {
 		instance.Spec.Nova.Template = &novav1.NovaSpecCore{}
 	}
@@ -76,7 +84,7 @@ func ReconcileNova(ctx context.Context, instance *corev1beta1.OpenStackControlPl
 	// is handled by the webhook via annotation-based triggers. No direct spec mutation here to avoid GitOps conflicts.
 
 	if instance.Spec.Nova.Template.NodeSelector == nil {
-		instance.Spec.Nova.Template.NodeSelector = &instance.Spec.NodeSelector
+		instance.Spec.Nova.Template.NodeSelector = instance.GetSchedulingNodeSelector()
 	}
 
 	// When no NotificationsBus is referenced in the subCR (override)
@@ -114,7 +122,7 @@ func ReconcileNova(ctx context.Context, instance *corev1beta1.OpenStackControlPl
 	// NOTE: This does not check the Service subCRs: by default the generated
 	// subCRs inherit the top-level TopologyRef unless an override is present
 	if instance.Spec.Nova.Template.TopologyRef == nil {
-		instance.Spec.Nova.Template.TopologyRef = instance.Spec.TopologyRef
+		instance.Spec.Nova.Template.TopologyRef = instance.GetSchedulingTopologyRef()
 	}
 
 	// When component services got created check if there is the need to create routes and certificates
@@ -519,10 +527,10 @@ func noVNCProxyEnabled(vncproxy novav1.NovaNoVNCProxyTemplate) bool {
 	return vncproxy.Enabled != nil && *vncproxy.Enabled
 }
 
-// NovaImageMatch - return true if the nova images match on the ControlPlane and Version, or if Nova is not enabled
+// NovaImageMatch - return true if the nova images match on the ControlPlane and Version, or if Nova is not enabled or not managed
 func NovaImageMatch(ctx context.Context, controlPlane *corev1beta1.OpenStackControlPlane, version *corev1beta1.OpenStackVersion) bool {
 	Log := GetLogger(ctx)
-	if controlPlane.Spec.Nova.Enabled {
+	if controlPlane.Spec.Nova.Enabled && !controlPlane.IsServiceUnmanaged("Nova") {
 		if !stringPointersEqual(controlPlane.Status.ContainerImages.NovaAPIImage, version.Status.ContainerImages.NovaAPIImage) ||
 			!stringPointersEqual(controlPlane.Status.ContainerImages.NovaComputeImage, version.Status.ContainerImages.NovaComputeImage) ||
 			!stringPointersEqual(controlPlane.Status.ContainerImages.NovaConductorImage, version.Status.ContainerImages.NovaConductorImage) ||
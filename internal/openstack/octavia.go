@@ -38,11 +38,15 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
+const (
+	octaviaName = "octavia"
+)
+
 // ReconcileOctavia -
 func ReconcileOctavia(ctx context.Context, instance *corev1beta1.OpenStackControlPlane, version *corev1beta1.OpenStackVersion, helper *helper.Helper) (ctrl.Result, error) {
 	octavia := &octaviav1.Octavia{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "octavia",
+			Name:      octaviaName,
 			Namespace: instance.Namespace,
 		},
 	}
@@ -66,6 +70,14 @@ func ReconcileOctavia(ctx context.Context, instance *corev1beta1.OpenStackContro
 		return ctrl.Result{}, nil
 	}
 
+	if managed, err := EnsureServiceManaged(ctx, instance, helper, "Octavia", octavia, corev1beta1.OpenStackControlPlaneOctaviaReadyCondition); !managed || err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if !EnsureServiceDependencies(ctx, instance, "Octavia") {
+		return ctrl.Result{}, nil
+	}
+
 	if instance.Spec.Octavia.Template == nil {
 		instance.Spec.Octavia.Template = &octaviav1.OctaviaSpecCore{}
 	}
@@ -74,7 +86,7 @@ func ReconcileOctavia(ctx context.Context, instance *corev1beta1.OpenStackContro
 	// via annotation-based triggers. No direct spec mutation here to avoid GitOps conflicts.
 
 	if instance.Spec.Octavia.Template.NodeSelector == nil {
-		instance.Spec.Octavia.Template.NodeSelector = &instance.Spec.NodeSelector
+		instance.Spec.Octavia.Template.NodeSelector = instance.GetSchedulingNodeSelector()
 	}
 
 	// When there's no Topology referenced in the Service Template, inject the
@@ -82,7 +94,7 @@ func ReconcileOctavia(ctx context.Context, instance *corev1beta1.OpenStackContro
 	// NOTE: This does not check the Service subCRs: by default the generated
 	// subCRs inherit the top-level TopologyRef unless an override is present
 	if instance.Spec.Octavia.Template.TopologyRef == nil {
-		instance.Spec.Octavia.Template.TopologyRef = instance.Spec.TopologyRef
+		instance.Spec.Octavia.Template.TopologyRef = instance.GetSchedulingTopologyRef()
 	}
 
 	// Propagate MessagingBus from top-level to template if not set
@@ -156,7 +168,7 @@ func ReconcileOctavia(ctx context.Context, instance *corev1beta1.OpenStackContro
 	instance.Spec.Octavia.Template.OctaviaAPI.TLS.CaBundleSecretName = instance.Status.TLS.CaBundleSecretName
 
 	// When component services got created check if there is the need to create a route
-	if err := helper.GetClient().Get(ctx, types.NamespacedName{Name: "octavia", Namespace: instance.Namespace}, octavia); err != nil {
+	if err := helper.GetClient().Get(ctx, types.NamespacedName{Name: octaviaName, Namespace: instance.Namespace}, octavia); err != nil {
 		if !k8s_errors.IsNotFound(err) {
 			return ctrl.Result{}, err
 		}
@@ -321,10 +333,10 @@ func ReconcileOctavia(ctx context.Context, instance *corev1beta1.OpenStackContro
 	return ctrl.Result{}, nil
 }
 
-// OctaviaImageMatch - return true if the octavia images match on the ControlPlane and Version, or if Octavia is not enabled
+// OctaviaImageMatch - return true if the octavia images match on the ControlPlane and Version, or if Octavia is not enabled or not managed
 func OctaviaImageMatch(ctx context.Context, controlPlane *corev1beta1.OpenStackControlPlane, version *corev1beta1.OpenStackVersion) bool {
 	Log := GetLogger(ctx)
-	if controlPlane.Spec.Octavia.Enabled {
+	if controlPlane.Spec.Octavia.Enabled && !controlPlane.IsServiceUnmanaged("Octavia") {
 		if !stringPointersEqual(controlPlane.Status.ContainerImages.OctaviaAPIImage, version.Status.ContainerImages.OctaviaAPIImage) ||
 			!stringPointersEqual(controlPlane.Status.ContainerImages.OctaviaWorkerImage, version.Status.ContainerImages.OctaviaWorkerImage) ||
 			!stringPointersEqual(controlPlane.Status.ContainerImages.OctaviaHealthmanagerImage, version.Status.ContainerImages.OctaviaHealthmanagerImage) ||
@@ -48,7 +48,7 @@ func ReconcileOpenStackClient(ctx context.Context, instance *corev1.OpenStackCon
 	}
 
 	if instance.Spec.OpenStackClient.Template.NodeSelector == nil {
-		instance.Spec.OpenStackClient.Template.NodeSelector = &instance.Spec.NodeSelector
+		instance.Spec.OpenStackClient.Template.NodeSelector = instance.GetSchedulingNodeSelector()
 	}
 
 	Log.Info("Reconciling OpenStackClient", "OpenStackClient.Namespace", instance.Namespace, "OpenStackClient.Name", openstackclient.Name)
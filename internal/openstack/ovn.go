@@ -37,6 +37,10 @@ func ReconcileOVN(ctx context.Context, instance *corev1beta1.OpenStackControlPla
 		instance.Spec.Ovn.Template = &corev1beta1.OvnResources{}
 	}
 
+	if managed, err := EnsureOVNManaged(ctx, instance, helper); !managed || err != nil {
+		return ctrl.Result{}, err
+	}
+
 	// Create TLS certificate for OVN metrics services when TLS is enabled
 	var ovnMetricsCertName string
 	if instance.Spec.Ovn.Enabled && instance.Spec.TLS.PodLevel.Enabled {
@@ -122,6 +126,23 @@ func ReconcileOVN(ctx context.Context, instance *corev1beta1.OpenStackControlPla
 	return ctrl.Result{}, nil
 }
 
+// EnsureOVNManaged - returns true if the openstack-operator manages the OVN
+// services, otherwise reports the readiness of the OVN CRs
+func EnsureOVNManaged(ctx context.Context, instance *corev1beta1.OpenStackControlPlane, helper *helper.Helper) (bool, error) {
+	if instance.Spec.Ovn.Template == nil {
+		instance.Spec.Ovn.Template = &corev1beta1.OvnResources{}
+	}
+	ovns := templateInstances(instance, &instance.Spec.Ovn.Template.OVNDBCluster, func(meta metav1.ObjectMeta) readyObject {
+		return &ovnv1.OVNDBCluster{ObjectMeta: meta}
+	})
+	ovns = append(ovns, &ovnv1.OVNNorthd{ObjectMeta: metav1.ObjectMeta{Name: "ovnnorthd", Namespace: instance.Namespace}})
+	// ovn-controller only runs with nicMappings
+	if len(instance.Spec.Ovn.Template.OVNController.NicMappings) > 0 {
+		ovns = append(ovns, &ovnv1.OVNController{ObjectMeta: metav1.ObjectMeta{Name: "ovncontroller", Namespace: instance.Namespace}})
+	}
+	return EnsureServiceInstancesManaged(ctx, instance, helper, "OVN", ovns, corev1beta1.OpenStackControlPlaneOVNReadyCondition)
+}
+
 // ReconcileOVNDbClusters reconciles the OVN database clusters for the OpenStack control plane
 func ReconcileOVNDbClusters(ctx context.Context, instance *corev1beta1.OpenStackControlPlane, version *corev1beta1.OpenStackVersion, helper *helper.Helper, ovnMetricsCertName string) (bool, condition.Conditions, error) {
 	Log := GetLogger(ctx)
@@ -209,7 +230,7 @@ func ReconcileOVNDbClusters(ctx context.Context, instance *corev1beta1.OpenStack
 		}
 
 		if dbcluster.NodeSelector == nil {
-			dbcluster.NodeSelector = &instance.Spec.NodeSelector
+			dbcluster.NodeSelector = instance.GetSchedulingNodeSelector()
 		}
 
 		// When there's no Topology referenced in the Service Template, inject the
@@ -217,7 +238,7 @@ func ReconcileOVNDbClusters(ctx context.Context, instance *corev1beta1.OpenStack
 		// NOTE: This does not check the Service subCRs: by default the generated
 		// subCRs inherit the top-level TopologyRef unless an override is present
 		if dbcluster.TopologyRef == nil {
-			dbcluster.TopologyRef = instance.Spec.TopologyRef
+			dbcluster.TopologyRef = instance.GetSchedulingTopologyRef()
 		}
 
 		Log.Info("Reconciling OVNDBCluster", "OVNDBCluster.Namespace", instance.Namespace, "OVNDBCluster.Name", name)
@@ -350,7 +371,7 @@ func ReconcileOVNNorthd(ctx context.Context, instance *corev1beta1.OpenStackCont
 	}
 
 	if ovnNorthdSpec.NodeSelector == nil {
-		ovnNorthdSpec.NodeSelector = &instance.Spec.NodeSelector
+		ovnNorthdSpec.NodeSelector = instance.GetSchedulingNodeSelector()
 	}
 
 	// When there's no Topology referenced in the Service Template, inject the
@@ -358,7 +379,7 @@ func ReconcileOVNNorthd(ctx context.Context, instance *corev1beta1.OpenStackCont
 	// NOTE: This does not check the Service subCRs: by default the generated
 	// subCRs inherit the top-level TopologyRef unless an override is present
 	if ovnNorthdSpec.TopologyRef == nil {
-		ovnNorthdSpec.TopologyRef = instance.Spec.TopologyRef
+		ovnNorthdSpec.TopologyRef = instance.GetSchedulingTopologyRef()
 	}
 
 	Log.Info("Reconciling OVNNorthd", "OVNNorthd.Namespace", instance.Namespace, "OVNNorthd.Name", "ovnnorthd")
@@ -494,7 +515,7 @@ func ReconcileOVNController(ctx context.Context, instance *corev1beta1.OpenStack
 	}
 
 	if ovnControllerSpec.NodeSelector == nil {
-		ovnControllerSpec.NodeSelector = &instance.Spec.NodeSelector
+		ovnControllerSpec.NodeSelector = instance.GetSchedulingNodeSelector()
 	}
 
 	// When there's no Topology referenced in the Service Template, inject the
@@ -502,7 +523,7 @@ func ReconcileOVNController(ctx context.Context, instance *corev1beta1.OpenStack
 	// NOTE: This does not check the Service subCRs: by default the generated
 	// subCRs inherit the top-level TopologyRef unless an override is present
 	if ovnControllerSpec.TopologyRef == nil {
-		ovnControllerSpec.TopologyRef = instance.Spec.TopologyRef
+		ovnControllerSpec.TopologyRef = instance.GetSchedulingTopologyRef()
 	}
 
 	Log.Info("Reconciling OVNController", "OVNController.Namespace", instance.Namespace, "OVNController.Name", "ovncontroller")
@@ -545,11 +566,11 @@ func ReconcileOVNController(ctx context.Context, instance *corev1beta1.OpenStack
 	return false, conditions, nil
 }
 
-// OVNControllerImageMatch - return true if the OVN Controller images match on the ControlPlane and Version, or if OVN is not enabled
+// OVNControllerImageMatch - return true if the OVN Controller images match on the ControlPlane and Version, or if OVN is not enabled or not managed
 func OVNControllerImageMatch(ctx context.Context, controlPlane *corev1beta1.OpenStackControlPlane, version *corev1beta1.OpenStackVersion) bool {
 	Log := GetLogger(ctx)
 
-	if controlPlane.Spec.Ovn.Enabled {
+	if controlPlane.Spec.Ovn.Enabled && !controlPlane.IsServiceUnmanaged("OVN") {
 		if !stringPointersEqual(controlPlane.Status.ContainerImages.OvnControllerImage, version.Status.ContainerImages.OvnControllerImage) ||
 			!stringPointersEqual(controlPlane.Status.ContainerImages.OvnControllerOvsImage, version.Status.ContainerImages.OvnControllerOvsImage) {
 			Log.Info("OVN Controller images do not match")
@@ -559,11 +580,11 @@ func OVNControllerImageMatch(ctx context.Context, controlPlane *corev1beta1.Open
 	return true
 }
 
-// OVNDbClusterImageMatch - return true if the OVN DbCluster images match on the ControlPlane and Version, or if OVN is not enabled
+// OVNDbClusterImageMatch - return true if the OVN DbCluster images match on the ControlPlane and Version, or if OVN is not enabled or not managed
 func OVNDbClusterImageMatch(ctx context.Context, controlPlane *corev1beta1.OpenStackControlPlane, version *corev1beta1.OpenStackVersion) bool {
 	Log := GetLogger(ctx)
 
-	if controlPlane.Spec.Ovn.Enabled {
+	if controlPlane.Spec.Ovn.Enabled && !controlPlane.IsServiceUnmanaged("OVN") {
 		if !stringPointersEqual(controlPlane.Status.ContainerImages.OvnNbDbclusterImage, version.Status.ContainerImages.OvnNbDbclusterImage) ||
 			!stringPointersEqual(controlPlane.Status.ContainerImages.OvnSbDbclusterImage, version.Status.ContainerImages.OvnSbDbclusterImage) {
 			Log.Info("OVN Cluster images do not match")
@@ -573,11 +594,11 @@ func OVNDbClusterImageMatch(ctx context.Context, controlPlane *corev1beta1.OpenS
 	return true
 }
 
-// OVNNorthImageMatch - return true if the OVN North images match on the ControlPlane and Version, or if OVN is not enabled
+// OVNNorthImageMatch - return true if the OVN North images match on the ControlPlane and Version, or if OVN is not enabled or not managed
 func OVNNorthImageMatch(ctx context.Context, controlPlane *corev1beta1.OpenStackControlPlane, version *corev1beta1.OpenStackVersion) bool {
 	Log := GetLogger(ctx)
 
-	if controlPlane.Spec.Ovn.Enabled {
+	if controlPlane.Spec.Ovn.Enabled && !controlPlane.IsServiceUnmanaged("OVN") {
 		if !stringPointersEqual(controlPlane.Status.ContainerImages.OvnNorthdImage, version.Status.ContainerImages.OvnNorthdImage) {
 			Log.Info("OVN North images do not match", "controlPlane.Status.ContainerImages.OvnNorthdImage", controlPlane.Status.ContainerImages.OvnNorthdImage, "version.Status.ContainerImages.OvnNorthdImage", version.Status.ContainerImages.OvnNorthdImage)
 			return false
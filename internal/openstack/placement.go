@@ -18,11 +18,15 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
+const (
+	placementName = "placement"
+)
+
 // ReconcilePlacementAPI -
 func ReconcilePlacementAPI(ctx context.Context, instance *corev1beta1.OpenStackControlPlane, version *corev1beta1.OpenStackVersion, helper *helper.Helper) (ctrl.Result, error) {
 	placementAPI := &placementv1.PlacementAPI{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "placement",
+			Name:      placementName,
 			Namespace: instance.Namespace,
 		},
 	}
@@ -41,12 +45,20 @@ func ReconcilePlacementAPI(ctx context.Context, instance *corev1beta1.OpenStackC
 		return ctrl.Result{}, nil
 	}
 
+	if managed, err := EnsureServiceManaged(ctx, instance, helper, "Placement", placementAPI, corev1beta1.OpenStackControlPlanePlacementAPIReadyCondition); !managed || err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if !EnsureServiceDependencies(ctx, instance, "Placement") {
+		return ctrl.Result{}, nil
+	}
+
 	if instance.Spec.Placement.Template == nil {
 		instance.Spec.Placement.Template = &placementv1.PlacementAPISpecCore{}
 	}
 
 	if instance.Spec.Placement.Template.NodeSelector == nil {
-		instance.Spec.Placement.Template.NodeSelector = &instance.Spec.NodeSelector
+		instance.Spec.Placement.Template.NodeSelector = instance.GetSchedulingNodeSelector()
 	}
 
 	// When there's no Topology referenced in the Service Template, inject the
@@ -54,7 +66,7 @@ func ReconcilePlacementAPI(ctx context.Context, instance *corev1beta1.OpenStackC
 	// NOTE: This does not check the Service subCRs: by default the generated
 	// subCRs inherit the top-level TopologyRef unless an override is present
 	if instance.Spec.Placement.Template.TopologyRef == nil {
-		instance.Spec.Placement.Template.TopologyRef = instance.Spec.TopologyRef
+		instance.Spec.Placement.Template.TopologyRef = instance.GetSchedulingTopologyRef()
 	}
 
 	// add selector to service overrides
@@ -68,7 +80,7 @@ func ReconcilePlacementAPI(ctx context.Context, instance *corev1beta1.OpenStackC
 	}
 
 	// When component services got created check if there is the need to create a route
-	if err := helper.GetClient().Get(ctx, types.NamespacedName{Name: "placement", Namespace: instance.Namespace}, placementAPI); err != nil {
+	if err := helper.GetClient().Get(ctx, types.NamespacedName{Name: placementName, Namespace: instance.Namespace}, placementAPI); err != nil {
 		if !k8s_errors.IsNotFound(err) {
 			return ctrl.Result{}, err
 		}
@@ -215,10 +227,10 @@ func ReconcilePlacementAPI(ctx context.Context, instance *corev1beta1.OpenStackC
 
 }
 
-// PlacementImageMatch - return true if the placement images match on the ControlPlane and Version, or if Placement is not enabled
+// PlacementImageMatch - return true if the placement images match on the ControlPlane and Version, or if Placement is not enabled or not managed
 func PlacementImageMatch(ctx context.Context, controlPlane *corev1beta1.OpenStackControlPlane, version *corev1beta1.OpenStackVersion) bool {
 	Log := GetLogger(ctx)
-	if controlPlane.Spec.Placement.Enabled {
+	if controlPlane.Spec.Placement.Enabled && !controlPlane.IsServiceUnmanaged("Placement") {
 		if !stringPointersEqual(controlPlane.Status.ContainerImages.PlacementAPIImage, version.Status.ContainerImages.PlacementAPIImage) {
 			Log.Info("Placement API image mismatch", "controlPlane.Status.ContainerImages.PlacementAPIImage", controlPlane.Status.ContainerImages.PlacementAPIImage, "version.Status.ContainerImages.PlacementAPIImage", version.Status.ContainerImages.PlacementAPIImage)
 			return false
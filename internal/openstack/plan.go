@@ -0,0 +1,350 @@
+package openstack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	corev1 "github.com/openstack-k8s-operators/openstack-operator/api/core/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/client-go/kubernetes"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// ApplyControlPlanePlanPatch returns a copy of the OpenStackControlPlane with
+// the specPatch of an OpenStackControlPlanePlan merged into its spec
+func ApplyControlPlanePlanPatch(instance *corev1.OpenStackControlPlane,
+	specPatch map[string]json.RawMessage,
+) (*corev1.OpenStackControlPlane, error) {
+	proposed := instance.DeepCopy()
+	if len(specPatch) == 0 {
+		return proposed, nil
+	}
+
+	patchJSON, err := json.Marshal(specPatch)
+	if err != nil {
+		return nil, fmt.Errorf("invalid specPatch: %w", err)
+	}
+	specJSON, err := json.Marshal(instance.Spec)
+	if err != nil {
+		return nil, err
+	}
+	proposedJSON, err := strategicpatch.StrategicMergePatch(specJSON, patchJSON,
+		corev1.OpenStackControlPlaneSpec{})
+	if err != nil {
+		return nil, fmt.Errorf("could not apply the specPatch: %w", err)
+	}
+	proposed.Spec = corev1.OpenStackControlPlaneSpec{}
+	if err := json.Unmarshal(proposedJSON, &proposed.Spec); err != nil {
+		return nil, fmt.Errorf("could not apply the specPatch: %w", err)
+	}
+	return proposed, nil
+}
+
+// PlanControlPlane runs the reconcile steps of the OpenStackControlPlane for
+// the proposed instance with a client recording the writes instead of sending
+// them. It returns the recorded changes and the steps which stopped early,
+// either returning an error or waiting for a resource to get ready.
+func PlanControlPlane(
+	ctx context.Context,
+	proposed *corev1.OpenStackControlPlane,
+	version *corev1.OpenStackVersion,
+	c client.Client,
+	kclient kubernetes.Interface,
+	scheme *runtime.Scheme,
+	log logr.Logger,
+) ([]corev1.OpenStackControlPlanePlanChange, []string, error) {
+	// The steps update the status of the instance and the version, work on
+	// copies to not leak these into the caller's objects
+	instance := proposed.DeepCopy()
+	version = version.DeepCopy()
+
+	recorder := &planClient{Client: c}
+	planHelper, err := helper.NewHelper(instance, recorder, kclient, scheme, log)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The stages run in the order of the OpenStackControlPlane reconcile,
+	// which prepares the instance after the setup steps
+	stages := []ControlPlaneStage{ControlPlaneStageSetup, ControlPlaneStageInfrastructure}
+	if instance.Annotations[corev1.DeploymentStageAnnotation] != corev1.DeploymentStageInfrastructureOnly {
+		stages = append(stages, ControlPlaneStageServices)
+	}
+
+	incompleteSteps := []string{}
+	for _, stage := range stages {
+		if stage == ControlPlaneStageInfrastructure {
+			PrepareControlPlane(instance)
+		}
+		for _, step := range GetControlPlaneSteps(stage) {
+			ctrlResult, err := step.Reconcile(ctx, instance, version, planHelper)
+			if err != nil {
+				incompleteSteps = append(incompleteSteps, fmt.Sprintf("%s: %s", step.Name, err.Error()))
+			} else if (ctrlResult != ctrl.Result{}) {
+				incompleteSteps = append(incompleteSteps, step.Name)
+			}
+		}
+	}
+
+	return recorder.changes, incompleteSteps, nil
+}
+
+// planClient - a client reading from the cluster and recording the writes
+// instead of sending them
+type planClient struct {
+	client.Client
+	changes []corev1.OpenStackControlPlanePlanChange
+}
+
+// Create - records the creation of the object
+func (c *planClient) Create(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+	data, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return err
+	}
+	images := []string{}
+	collectImages(stripPlanObject(data), "", &images)
+	return c.record(corev1.PlannedActionCreate, obj, nil, images)
+}
+
+// Update - records the fields the update changes
+func (c *planClient) Update(ctx context.Context, obj client.Object, _ ...client.UpdateOption) error {
+	return c.recordUpdate(ctx, obj)
+}
+
+// Patch - records the fields the patch changes, CreateOrPatch applies the
+// mutation to obj before calling Patch so obj is the patched object
+func (c *planClient) Patch(ctx context.Context, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
+	return c.recordUpdate(ctx, obj)
+}
+
+// Delete - records the deletion of the object
+func (c *planClient) Delete(ctx context.Context, obj client.Object, _ ...client.DeleteOption) error {
+	current, err := c.newObject(obj)
+	if err != nil {
+		return err
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), current); err != nil {
+		return err
+	}
+	return c.record(corev1.PlannedActionDelete, obj, nil, nil)
+}
+
+// DeleteAllOf - not recorded, only used to clean up resources of a service
+func (c *planClient) DeleteAllOf(_ context.Context, _ client.Object, _ ...client.DeleteAllOfOption) error {
+	return nil
+}
+
+// Status - status writes are not part of the plan
+func (c *planClient) Status() client.SubResourceWriter {
+	return planSubResourceClient{}
+}
+
+// SubResource - subresource writes are not part of the plan
+func (c *planClient) SubResource(subResource string) client.SubResourceClient {
+	return planSubResourceClient{SubResourceClient: c.Client.SubResource(subResource)}
+}
+
+func (c *planClient) recordUpdate(ctx context.Context, obj client.Object) error {
+	current, err := c.newObject(obj)
+	if err != nil {
+		return err
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), current); err != nil {
+		return err
+	}
+	before, err := runtime.DefaultUnstructuredConverter.ToUnstructured(current)
+	if err != nil {
+		return err
+	}
+	after, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return err
+	}
+
+	fields, images := diffPlanObjects(stripPlanObject(before), stripPlanObject(after))
+	if len(fields) == 0 {
+		return nil
+	}
+	return c.record(corev1.PlannedActionUpdate, obj, fields, images)
+}
+
+// record adds the change, merging it with an earlier change of the same object
+func (c *planClient) record(action corev1.PlannedAction, obj client.Object, fields []string, images []string) error {
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		return err
+	}
+	for i := range c.changes {
+		change := &c.changes[i]
+		if change.Action == action && change.Kind == gvk.Kind && change.Name == obj.GetName() {
+			change.Fields = mergeSorted(change.Fields, fields)
+			change.Images = mergeSorted(change.Images, images)
+			return nil
+		}
+	}
+	c.changes = append(c.changes, corev1.OpenStackControlPlanePlanChange{
+		Action: action,
+		Kind:   gvk.Kind,
+		Name:   obj.GetName(),
+		Fields: mergeSorted(nil, fields),
+		Images: mergeSorted(nil, images),
+	})
+	return nil
+}
+
+// newObject returns an empty object of the same kind to read the current state into
+func (c *planClient) newObject(obj client.Object) (client.Object, error) {
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		current := &unstructured.Unstructured{}
+		current.SetGroupVersionKind(u.GroupVersionKind())
+		return current, nil
+	}
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		return nil, err
+	}
+	newObj, err := c.Scheme().New(gvk)
+	if err != nil {
+		return nil, err
+	}
+	current, ok := newObj.(client.Object)
+	if !ok {
+		return nil, fmt.Errorf("%T is not a client.Object", newObj)
+	}
+	return current, nil
+}
+
+// planSubResourceClient - reads subresources from the cluster and drops the writes
+type planSubResourceClient struct {
+	client.SubResourceClient
+}
+
+func (planSubResourceClient) Create(_ context.Context, _ client.Object, _ client.Object, _ ...client.SubResourceCreateOption) error {
+	return nil
+}
+
+func (planSubResourceClient) Update(_ context.Context, _ client.Object, _ ...client.SubResourceUpdateOption) error {
+	return nil
+}
+
+func (planSubResourceClient) Patch(_ context.Context, _ client.Object, _ client.Patch, _ ...client.SubResourcePatchOption) error {
+	return nil
+}
+
+// stripPlanObject drops the status and the metadata set by the API server,
+// keeping the metadata the reconcile manages
+func stripPlanObject(data map[string]interface{}) map[string]interface{} {
+	stripped := map[string]interface{}{}
+	for key, value := range data {
+		switch key {
+		case "status", "apiVersion", "kind":
+		case "metadata":
+			metadata, _ := value.(map[string]interface{})
+			kept := map[string]interface{}{}
+			for _, metaKey := range []string{"labels", "annotations", "ownerReferences", "finalizers"} {
+				if metaValue, ok := metadata[metaKey]; ok {
+					kept[metaKey] = metaValue
+				}
+			}
+			if len(kept) > 0 {
+				stripped[key] = kept
+			}
+		default:
+			stripped[key] = value
+		}
+	}
+	return stripped
+}
+
+// diffPlanObjects returns the paths of the fields which differ, lists are
+// compared as a whole, and the images of the changed fields
+func diffPlanObjects(before, after map[string]interface{}) ([]string, []string) {
+	fields := []string{}
+	images := []string{}
+	diffPlanValues(before, after, "", &fields, &images)
+	sort.Strings(fields)
+	sort.Strings(images)
+	return fields, images
+}
+
+func diffPlanValues(before, after interface{}, path string, fields *[]string, images *[]string) {
+	beforeMap, beforeIsMap := before.(map[string]interface{})
+	afterMap, afterIsMap := after.(map[string]interface{})
+	if beforeIsMap && afterIsMap {
+		keys := map[string]struct{}{}
+		for key := range beforeMap {
+			keys[key] = struct{}{}
+		}
+		for key := range afterMap {
+			keys[key] = struct{}{}
+		}
+		for key := range keys {
+			diffPlanValues(beforeMap[key], afterMap[key], joinPlanPath(path, key), fields, images)
+		}
+		return
+	}
+	if reflect.DeepEqual(before, after) {
+		return
+	}
+	*fields = append(*fields, path)
+	collectImages(after, path, images)
+}
+
+// collectImages adds <path>=<image> for the string values of the fields whose
+// name contains image
+func collectImages(value interface{}, path string, images *[]string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			collectImages(child, joinPlanPath(path, key), images)
+		}
+	case []interface{}:
+		for i, child := range v {
+			collectImages(child, fmt.Sprintf("%s[%d]", path, i), images)
+		}
+	case string:
+		name := path[strings.LastIndex(path, ".")+1:]
+		if v != "" && strings.Contains(strings.ToLower(name), "image") {
+			*images = append(*images, fmt.Sprintf("%s=%s", path, v))
+		}
+	}
+}
+
+func joinPlanPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func mergeSorted(values []string, more []string) []string {
+	merged := append([]string{}, values...)
+	for _, value := range more {
+		found := false
+		for _, existing := range merged {
+			if existing == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged = append(merged, value)
+		}
+	}
+	if len(merged) == 0 {
+		return nil
+	}
+	sort.Strings(merged)
+	return merged
+}
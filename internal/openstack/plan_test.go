@@ -0,0 +1,200 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openstack
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/gomega" //revive:disable:dot-imports
+
+	corev1 "github.com/openstack-k8s-operators/openstack-operator/api/core/v1beta1"
+	k8s_corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestApplyControlPlanePlanPatch(t *testing.T) {
+	g := NewWithT(t)
+
+	instance := &corev1.OpenStackControlPlane{
+		ObjectMeta: metav1.ObjectMeta{Name: "openstack", Namespace: "test-namespace"},
+		Spec: corev1.OpenStackControlPlaneSpec{
+			Secret:       "osp-secret",
+			StorageClass: "local-storage",
+			NodeSelector: map[string]string{"a": "1", "b": "2"},
+			ExtraMounts:  []corev1.OpenStackExtraVolMounts{{Name: "one"}, {Name: "two"}},
+		},
+	}
+	instance.Spec.Glance.Enabled = true
+
+	proposed, err := ApplyControlPlanePlanPatch(instance, map[string]json.RawMessage{
+		"storageClass": json.RawMessage(`"ceph"`),
+		"nodeSelector": json.RawMessage(`{"b": null, "c": "3"}`),
+		"extraMounts":  json.RawMessage(`[{"name": "three"}]`),
+		"glance":       json.RawMessage(`{"enabled": false}`),
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(proposed.Spec.Secret).To(Equal("osp-secret"))
+	g.Expect(proposed.Spec.StorageClass).To(Equal("ceph"))
+	g.Expect(proposed.Spec.NodeSelector).To(Equal(map[string]string{"a": "1", "c": "3"}))
+	g.Expect(proposed.Spec.ExtraMounts).To(HaveLen(1))
+	g.Expect(proposed.Spec.ExtraMounts[0].Name).To(Equal("three"))
+	g.Expect(proposed.Spec.Glance.Enabled).To(BeFalse())
+
+	// the instance itself is left unchanged
+	g.Expect(instance.Spec.StorageClass).To(Equal("local-storage"))
+	g.Expect(instance.Spec.Glance.Enabled).To(BeTrue())
+
+	// an empty patch proposes the current spec
+	proposed, err = ApplyControlPlanePlanPatch(instance, nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(proposed.Spec).To(Equal(instance.Spec))
+
+	_, err = ApplyControlPlanePlanPatch(instance, map[string]json.RawMessage{
+		"storageClass": json.RawMessage(`{"not": "a string"}`),
+	})
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestPlanClient(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	existing := &k8s_corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "existing", Namespace: "test-namespace"},
+		Spec: k8s_corev1.PodSpec{
+			Containers: []k8s_corev1.Container{{Name: "api", Image: "quay.io/api:old"}},
+		},
+	}
+	unchanged := &k8s_corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "unchanged", Namespace: "test-namespace"},
+		Data:       map[string]string{"key": "value"},
+	}
+	fakeClient := fakeclient.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(existing, unchanged).
+		Build()
+	c := &planClient{Client: fakeClient}
+
+	// creating an object records it with its images
+	created := &k8s_corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "created", Namespace: "test-namespace"},
+		Spec: k8s_corev1.PodSpec{
+			Containers: []k8s_corev1.Container{{Name: "api", Image: "quay.io/api:new"}},
+		},
+	}
+	g.Expect(c.Create(ctx, created)).To(Succeed())
+
+	// updating an object records the changed fields and images
+	updated := existing.DeepCopy()
+	updated.Spec.Containers[0].Image = "quay.io/api:new"
+	updated.Spec.TerminationGracePeriodSeconds = ptr.To[int64](10)
+	updated.Status.Phase = k8s_corev1.PodRunning
+	g.Expect(c.Patch(ctx, updated, client.MergeFrom(existing))).To(Succeed())
+
+	// an update without changes is not recorded
+	g.Expect(c.Update(ctx, unchanged.DeepCopy())).To(Succeed())
+
+	// deleting an object records it, deleting a missing object fails as the
+	// real client does
+	g.Expect(c.Delete(ctx, unchanged.DeepCopy())).To(Succeed())
+	err := c.Delete(ctx, &k8s_corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "missing", Namespace: "test-namespace"},
+	})
+	g.Expect(k8s_errors.IsNotFound(err)).To(BeTrue())
+
+	// status writes are dropped
+	g.Expect(c.Status().Update(ctx, updated)).To(Succeed())
+
+	g.Expect(c.changes).To(Equal([]corev1.OpenStackControlPlanePlanChange{
+		{
+			Action: corev1.PlannedActionCreate,
+			Kind:   "Pod",
+			Name:   "created",
+			Images: []string{"spec.containers[0].image=quay.io/api:new"},
+		},
+		{
+			Action: corev1.PlannedActionUpdate,
+			Kind:   "Pod",
+			Name:   "existing",
+			Fields: []string{"spec.containers", "spec.terminationGracePeriodSeconds"},
+			Images: []string{"spec.containers[0].image=quay.io/api:new"},
+		},
+		{
+			Action: corev1.PlannedActionDelete,
+			Kind:   "ConfigMap",
+			Name:   "unchanged",
+		},
+	}))
+
+	// nothing was written
+	current := &k8s_corev1.Pod{}
+	g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(existing), current)).To(Succeed())
+	g.Expect(current.Spec.Containers[0].Image).To(Equal("quay.io/api:old"))
+	g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(created), &k8s_corev1.Pod{})).ToNot(Succeed())
+	g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(unchanged), &k8s_corev1.ConfigMap{})).To(Succeed())
+}
+
+func TestDiffPlanObjects(t *testing.T) {
+	g := NewWithT(t)
+
+	before := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": map[string]interface{}{"app": "glance"},
+		},
+		"spec": map[string]interface{}{
+			"replicas":            int64(1),
+			"containerImage":      "quay.io/glance:old",
+			"customServiceConfig": "[DEFAULT]\n",
+		},
+	}
+	after := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": map[string]interface{}{"app": "glance", "tier": "api"},
+		},
+		"spec": map[string]interface{}{
+			"replicas":            int64(3),
+			"containerImage":      "quay.io/glance:new",
+			"customServiceConfig": "[DEFAULT]\n",
+			"glanceAPIs": map[string]interface{}{
+				"default": map[string]interface{}{"apiImage": "quay.io/glance-api:new"},
+			},
+		},
+	}
+
+	fields, images := diffPlanObjects(before, after)
+	g.Expect(fields).To(Equal([]string{
+		"metadata.labels.tier",
+		"spec.containerImage",
+		"spec.glanceAPIs",
+		"spec.replicas",
+	}))
+	g.Expect(images).To(Equal([]string{
+		"spec.containerImage=quay.io/glance:new",
+		"spec.glanceAPIs.default.apiImage=quay.io/glance-api:new",
+	}))
+
+	fields, images = diffPlanObjects(before, before)
+	g.Expect(fields).To(BeEmpty())
+	g.Expect(images).To(BeEmpty())
+}
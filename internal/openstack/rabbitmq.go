@@ -61,11 +61,27 @@ func deleteUndefinedRabbitMQs(
 		return ctrl.Result{}, fmt.Errorf("could not list rabbitmqs %w", err)
 	}
 
+	refs, err := instance.GetRabbitMqReferences()
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("could not get the references to the rabbitmqs %w", err)
+	}
+
 	var delErrs []error
 	for _, rabbitObj := range rabbitList.Items {
 		// if it is not defined in the OpenStackControlPlane then delete it from k8s.
 		if _, exists := (*instance.Spec.Rabbitmq.Templates)[rabbitObj.Name]; !exists {
 			if object.CheckOwnerRefExist(instance.GetUID(), rabbitObj.OwnerReferences) {
+				// the webhook refuses these deletions, don't rely on it
+				if paths, referenced := refs[rabbitObj.Name]; referenced {
+					log.Info("Not deleting Rabbitmq, still referenced", "", rabbitObj.Name, "references", paths)
+					continue
+				}
+				if !instance.IsDeletionConfirmed(rabbitObj.Name) {
+					log.Info("Not deleting Rabbitmq, deletion not confirmed", "", rabbitObj.Name,
+						"annotation", corev1beta1.ConfirmDeleteAnnotation)
+					continue
+				}
+
 				log.Info("Deleting Rabbitmq", "", rabbitObj.Name)
 
 				certName := fmt.Sprintf("%s-svc", rabbitObj.Name)
@@ -103,6 +119,13 @@ func ReconcileRabbitMQs(
 	var err error
 	var status mqStatus
 
+	rabbitmqs := templateInstances(instance, instance.Spec.Rabbitmq.Templates, func(meta metav1.ObjectMeta) readyObject {
+		return &rabbitmqv1.RabbitMq{ObjectMeta: meta}
+	})
+	if managed, err := EnsureServiceInstancesManaged(ctx, instance, helper, "RabbitMQ", rabbitmqs, corev1beta1.OpenStackControlPlaneRabbitMQReadyCondition); !managed || err != nil {
+		return ctrl.Result{}, err
+	}
+
 	if instance.Spec.Rabbitmq.Templates == nil {
 		instance.Spec.Rabbitmq.Templates = ptr.To(map[string]rabbitmqv1.RabbitMqSpecCore{})
 	}
@@ -154,6 +177,8 @@ func ReconcileRabbitMQs(
 		)
 	}
 
+	removeStaleScalingConditions(instance, "RabbitMQ", templateNames)
+
 	_, errs := deleteUndefinedRabbitMQs(ctx, instance, helper)
 	if errs != nil {
 		instance.Status.Conditions.Set(condition.FalseCondition(
@@ -190,6 +215,7 @@ func reconcileRabbitMQ(
 			return mqFailed, ctrl.Result{}, err
 		}
 		instance.Status.Conditions.Remove(corev1beta1.OpenStackControlPlaneRabbitMQReadyCondition)
+		instance.Status.Conditions.Remove(scalingConditionType("RabbitMQ", name))
 		instance.Status.ContainerImages.RabbitmqImage = nil
 		return mqReady, ctrl.Result{}, nil
 	}
@@ -247,13 +273,25 @@ func reconcileRabbitMQ(
 	}
 
 	if spec.NodeSelector == nil {
-		spec.NodeSelector = &instance.Spec.NodeSelector
+		spec.NodeSelector = instance.GetSchedulingNodeSelector()
 	}
 
 	// When there's no Topology referenced in the Service Template, inject the
 	// top-level one
 	if spec.TopologyRef == nil {
-		spec.TopologyRef = instance.Spec.TopologyRef
+		spec.TopologyRef = instance.GetSchedulingTopologyRef()
+	}
+
+	if len(spec.Tolerations) == 0 {
+		spec.Tolerations = instance.GetSchedulingTolerations()
+	}
+
+	// Spread the pods of the RabbitmqCluster, labelled with its name, with
+	// the anti-affinity preset unless the template sets its own affinity
+	if spec.Affinity == nil {
+		spec.Affinity = corev1beta1.GetPodAntiAffinity(
+			instance.GetSchedulingPodAntiAffinity(),
+			map[string]string{"app.kubernetes.io/name": name})
 	}
 
 	// infra operator is now the controller
@@ -268,7 +306,13 @@ func reconcileRabbitMQ(
 	}
 
 	op, err := controllerutil.CreateOrPatch(ctx, helper.GetClient(), rabbitmq, func() error {
+		// scale an existing RabbitMQ one step at a time, only while it's healthy
+		current := rabbitmq.Spec.Replicas
+		healthy := rabbitmq.Status.ObservedGeneration == rabbitmq.Generation && rabbitmq.IsReady()
 		spec.DeepCopyInto(&rabbitmq.Spec.RabbitMqSpecCore)
+		if !rabbitmq.CreationTimestamp.IsZero() && current != nil && spec.Replicas != nil {
+			rabbitmq.Spec.Replicas = ptr.To(scalingStep(*current, *spec.Replicas, rabbitmqScalingStep, healthy))
+		}
 
 		if rabbitmq.Spec.Persistence.StorageClassName == nil {
 			log.Info(fmt.Sprintf("Setting StorageClassName: %s", instance.Spec.StorageClass))
@@ -303,6 +347,15 @@ func reconcileRabbitMQ(
 		log.Info(fmt.Sprintf("RabbitMQ %s - %s", rabbitmq.Name, op))
 	}
 
+	if rabbitmq.Spec.Replicas != nil && spec.Replicas != nil {
+		setScalingCondition(instance, "RabbitMQ", name, *rabbitmq.Spec.Replicas, *spec.Replicas)
+		if *rabbitmq.Spec.Replicas != *spec.Replicas {
+			log.Info("RabbitMQ scaling in progress", "RabbitMQ.Name", name,
+				"replicas", *rabbitmq.Spec.Replicas, "desired", *spec.Replicas)
+			return mqCreating, ctrl.Result{}, nil
+		}
+	}
+
 	if rabbitmq.Status.ObservedGeneration == rabbitmq.Generation && rabbitmq.IsReady() {
 		instance.Status.ContainerImages.RabbitmqImage = version.Status.ContainerImages.RabbitmqImage
 		return mqReady, ctrl.Result{}, nil
@@ -370,10 +423,10 @@ func removeConfigMapControllerReference(
 	return nil
 }
 
-// RabbitmqImageMatch - return true if the rabbitmq images match on the ControlPlane and Version, or if Rabbitmq is not enabled
+// RabbitmqImageMatch - return true if the rabbitmq images match on the ControlPlane and Version, or if Rabbitmq is not enabled or not managed
 func RabbitmqImageMatch(ctx context.Context, controlPlane *corev1beta1.OpenStackControlPlane, version *corev1beta1.OpenStackVersion) bool {
 	log := GetLogger(ctx)
-	if controlPlane.Spec.Rabbitmq.Enabled {
+	if controlPlane.Spec.Rabbitmq.Enabled && !controlPlane.IsServiceUnmanaged("RabbitMQ") {
 		if !stringPointersEqual(controlPlane.Status.ContainerImages.RabbitmqImage, version.Status.ContainerImages.RabbitmqImage) {
 			log.Info("RabbitMQ image mismatch", "controlPlane.Status.ContainerImages.RabbitmqImage", controlPlane.Status.ContainerImages.RabbitmqImage, "version.Status.ContainerImages.RabbitmqImage", version.Status.ContainerImages.RabbitmqImage)
 			return false
@@ -57,6 +57,13 @@ func ReconcileRedis(
 	// truly remain invisible it's best not to provide any example
 	// templates info while the service is disabled.
 
+	redisInstances := templateInstances(instance, instance.Spec.Redis.Templates, func(meta metav1.ObjectMeta) readyObject {
+		return &redisv1.Redis{ObjectMeta: meta}
+	})
+	if managed, err := EnsureServiceInstancesManaged(ctx, instance, helper, "Redis", redisInstances, corev1beta1.OpenStackControlPlaneRedisReadyCondition); !managed || err != nil {
+		return ctrl.Result{}, err
+	}
+
 	// We first remove redises no longer owned
 	redises := &redisv1.RedisList{}
 	listOpts := []client.ListOption{
@@ -256,7 +263,7 @@ func reconcileRedis(
 	}
 
 	if spec.NodeSelector == nil {
-		spec.NodeSelector = &instance.Spec.NodeSelector
+		spec.NodeSelector = instance.GetSchedulingNodeSelector()
 	}
 
 	// When there's no Topology referenced in the Service Template, inject the
@@ -264,7 +271,7 @@ func reconcileRedis(
 	// NOTE: This does not check the Service subCRs: by default the generated
 	// subCRs inherit the top-level TopologyRef unless an override is present
 	if spec.TopologyRef == nil {
-		spec.TopologyRef = instance.Spec.TopologyRef
+		spec.TopologyRef = instance.GetSchedulingTopologyRef()
 	}
 
 	op, err := controllerutil.CreateOrPatch(ctx, helper.GetClient(), redis, func() error {
@@ -299,10 +306,10 @@ func reconcileRedis(
 	return redisCreating, redis, ctrl.Result{}, nil
 }
 
-// RedisImageMatch - return true if the redis images match on the ControlPlane and Version, or if Redis is not enabled
+// RedisImageMatch - return true if the redis images match on the ControlPlane and Version, or if Redis is not enabled or not managed
 func RedisImageMatch(ctx context.Context, controlPlane *corev1beta1.OpenStackControlPlane, version *corev1beta1.OpenStackVersion) bool {
 	Log := GetLogger(ctx)
-	if controlPlane.Spec.Redis.Enabled {
+	if controlPlane.Spec.Redis.Enabled && !controlPlane.IsServiceUnmanaged("Redis") {
 		if !stringPointersEqual(controlPlane.Status.ContainerImages.InfraRedisImage, version.Status.ContainerImages.InfraRedisImage) {
 			Log.Info("Redis images do not match", "controlPlane.Status.ContainerImages.InfraRedisImage", controlPlane.Status.ContainerImages.InfraRedisImage, "version.Status.ContainerImages.InfraRedisImage", version.Status.ContainerImages.InfraRedisImage)
 			return false
@@ -0,0 +1,100 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openstack
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	corev1beta1 "github.com/openstack-k8s-operators/openstack-operator/api/core/v1beta1"
+)
+
+const (
+	// galeraScalingStep - Galera runs an odd number of replicas, it is scaled
+	// two replicas at a time
+	galeraScalingStep = 2
+	// rabbitmqScalingStep - RabbitMQ is scaled one replica at a time
+	rabbitmqScalingStep = 1
+
+	scalingConditionSuffix = "ScalingReady"
+)
+
+// scalingStep - returns the replicas to set on an instance running the
+// current replicas to move it towards the desired ones. The replicas change
+// one step at a time and only while the cluster is healthy, so a degraded
+// cluster is never scaled down below its quorum. Starting or stopping the
+// instance, from or to zero replicas, is not a scaling and isn't held back.
+func scalingStep(current int32, desired int32, step int32, healthy bool) int32 {
+	if current == desired || current == 0 || desired == 0 {
+		return desired
+	}
+	if !healthy {
+		return current
+	}
+	if desired > current {
+		return min(current+step, desired)
+	}
+	return max(current-step, desired)
+}
+
+// scalingConditionType - returns the condition of the OpenStackControlPlane
+// reporting the scaling of an instance, e.g. GaleraOpenstackCell1ScalingReady
+func scalingConditionType(kind string, name string) condition.Type {
+	return condition.Type(fmt.Sprintf("%s%s%s", kind, strcase.ToCamel(name), scalingConditionSuffix))
+}
+
+// setScalingCondition - reports the scaling of an instance running replicas
+// out of the desired ones, and removes the condition once it's done
+func setScalingCondition(instance *corev1beta1.OpenStackControlPlane, kind string, name string, replicas int32, desired int32) {
+	conditionType := scalingConditionType(kind, name)
+	if replicas == desired {
+		instance.Status.Conditions.Remove(conditionType)
+		return
+	}
+	instance.Status.Conditions.Set(condition.FalseCondition(
+		conditionType,
+		condition.RequestedReason,
+		condition.SeverityInfo,
+		corev1beta1.OpenStackControlPlaneScalingInProgressMessage,
+		kind,
+		name,
+		desired,
+		replicas))
+}
+
+// removeStaleScalingConditions - removes the scaling conditions of the
+// instances of the kind not in names anymore
+func removeStaleScalingConditions(instance *corev1beta1.OpenStackControlPlane, kind string, names []string) {
+	current := map[condition.Type]struct{}{}
+	for _, name := range names {
+		current[scalingConditionType(kind, name)] = struct{}{}
+	}
+	stale := []condition.Type{}
+	for _, c := range instance.Status.Conditions {
+		if !strings.HasPrefix(string(c.Type), kind) || !strings.HasSuffix(string(c.Type), scalingConditionSuffix) {
+			continue
+		}
+		if _, exists := current[c.Type]; !exists {
+			stale = append(stale, c.Type)
+		}
+	}
+	for _, conditionType := range stale {
+		instance.Status.Conditions.Remove(conditionType)
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openstack
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega" //revive:disable:dot-imports
+
+	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	corev1 "github.com/openstack-k8s-operators/openstack-operator/api/core/v1beta1"
+)
+
+func TestScalingStep(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  int32
+		desired  int32
+		step     int32
+		healthy  bool
+		expected int32
+	}{
+		{name: "unchanged", current: 3, desired: 3, step: 1, healthy: false, expected: 3},
+		{name: "galera scale up", current: 1, desired: 3, step: 2, healthy: true, expected: 3},
+		{name: "galera scale down", current: 3, desired: 1, step: 2, healthy: true, expected: 1},
+		{name: "rabbitmq scale up one step", current: 1, desired: 3, step: 1, healthy: true, expected: 2},
+		{name: "rabbitmq scale down one step", current: 5, desired: 3, step: 1, healthy: true, expected: 4},
+		{name: "scale up held while degraded", current: 1, desired: 3, step: 1, healthy: false, expected: 1},
+		{name: "scale down held while degraded", current: 3, desired: 1, step: 2, healthy: false, expected: 3},
+		{name: "start", current: 0, desired: 3, step: 1, healthy: false, expected: 3},
+		{name: "stop", current: 3, desired: 0, step: 1, healthy: false, expected: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(scalingStep(tt.current, tt.desired, tt.step, tt.healthy)).To(Equal(tt.expected))
+		})
+	}
+}
+
+func TestScalingConditions(t *testing.T) {
+	g := NewWithT(t)
+
+	instance := &corev1.OpenStackControlPlane{}
+	instance.Status.Conditions = condition.Conditions{}
+
+	g.Expect(scalingConditionType("Galera", "openstack-cell1")).To(
+		Equal(condition.Type("GaleraOpenstackCell1ScalingReady")))
+
+	setScalingCondition(instance, "Galera", "openstack", 1, 3)
+	setScalingCondition(instance, "Galera", "openstack-cell1", 1, 3)
+	setScalingCondition(instance, "RabbitMQ", "rabbitmq", 2, 3)
+	scaling := instance.Status.Conditions.Get(scalingConditionType("Galera", "openstack"))
+	g.Expect(scaling).ToNot(BeNil())
+	g.Expect(scaling.Status).To(BeEquivalentTo("False"))
+	g.Expect(scaling.Message).To(Equal(
+		"OpenStackControlPlane Galera openstack scaling to 3 replicas, at 1 replicas waiting for the cluster to be healthy"))
+
+	// the condition is removed once the instance runs the desired replicas
+	setScalingCondition(instance, "Galera", "openstack", 3, 3)
+	g.Expect(instance.Status.Conditions.Get(scalingConditionType("Galera", "openstack"))).To(BeNil())
+
+	// the conditions of removed instances are removed, the other kinds kept
+	removeStaleScalingConditions(instance, "Galera", []string{"openstack"})
+	g.Expect(instance.Status.Conditions.Get(scalingConditionType("Galera", "openstack-cell1"))).To(BeNil())
+	g.Expect(instance.Status.Conditions.Get(scalingConditionType("RabbitMQ", "rabbitmq"))).ToNot(BeNil())
+}
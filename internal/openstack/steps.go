@@ -0,0 +1,174 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openstack
+
+import (
+	"context"
+	"strings"
+
+	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	corev1beta1 "github.com/openstack-k8s-operators/openstack-operator/api/core/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// ControlPlaneStage - the stage of the OpenStackControlPlane reconcile a step
+// runs in
+type ControlPlaneStage int
+
+const (
+	// ControlPlaneStageSetup - steps run before both the normal and the minor
+	// update reconcile
+	ControlPlaneStageSetup ControlPlaneStage = iota
+	// ControlPlaneStageInfrastructure - steps of the infrastructure, run
+	// in the infrastructure-only deployment stage too
+	ControlPlaneStageInfrastructure
+	// ControlPlaneStageServices - steps of the OpenStack services
+	ControlPlaneStageServices
+)
+
+// ControlPlaneStep - a step of the OpenStackControlPlane reconcile
+type ControlPlaneStep struct {
+	Name      string
+	Stage     ControlPlaneStage
+	Reconcile func(context.Context, *corev1beta1.OpenStackControlPlane, *corev1beta1.OpenStackVersion, *helper.Helper) (ctrl.Result, error)
+}
+
+// ControlPlaneSteps - the ordered steps of the OpenStackControlPlane
+// reconcile, shared by the OpenStackControlPlane controller and the
+// OpenStackControlPlanePlan so the plan runs the same steps in the same order
+var ControlPlaneSteps = []ControlPlaneStep{
+	{Name: "Sizing", Stage: ControlPlaneStageSetup, Reconcile: reconcileSizingStep},
+	{Name: "Autoscaling", Stage: ControlPlaneStageSetup, Reconcile: reconcileAutoscalingStep},
+	{Name: "CAs", Stage: ControlPlaneStageInfrastructure, Reconcile: reconcileCAsStep},
+	{Name: "DNSMasqs", Stage: ControlPlaneStageInfrastructure, Reconcile: ReconcileDNSMasqs},
+	{Name: "RabbitMQs", Stage: ControlPlaneStageInfrastructure, Reconcile: ReconcileRabbitMQs},
+	{Name: "Galeras", Stage: ControlPlaneStageInfrastructure, Reconcile: ReconcileGaleras},
+	{Name: "Memcacheds", Stage: ControlPlaneStageInfrastructure, Reconcile: ReconcileMemcacheds},
+	{Name: "OVN", Stage: ControlPlaneStageInfrastructure, Reconcile: ReconcileOVN},
+	{Name: "KeystoneAPI", Stage: ControlPlaneStageServices, Reconcile: ReconcileKeystoneAPI},
+	{Name: "PlacementAPI", Stage: ControlPlaneStageServices, Reconcile: ReconcilePlacementAPI},
+	{Name: "Glance", Stage: ControlPlaneStageServices, Reconcile: ReconcileGlance},
+	{Name: "Cinder", Stage: ControlPlaneStageServices, Reconcile: ReconcileCinder},
+	{Name: "Neutron", Stage: ControlPlaneStageServices, Reconcile: ReconcileNeutron},
+	{Name: "Nova", Stage: ControlPlaneStageServices, Reconcile: ReconcileNova},
+	{Name: "Heat", Stage: ControlPlaneStageServices, Reconcile: ReconcileHeat},
+	{Name: "Ironic", Stage: ControlPlaneStageServices, Reconcile: ReconcileIronic},
+	{Name: "OpenStackClient", Stage: ControlPlaneStageServices, Reconcile: ReconcileOpenStackClient},
+	{Name: "Manila", Stage: ControlPlaneStageServices, Reconcile: ReconcileManila},
+	{Name: "Horizon", Stage: ControlPlaneStageServices, Reconcile: ReconcileHorizon},
+	{Name: "Telemetry", Stage: ControlPlaneStageServices, Reconcile: ReconcileTelemetry},
+	{Name: "Barbican", Stage: ControlPlaneStageServices, Reconcile: ReconcileBarbican},
+	{Name: "Redis", Stage: ControlPlaneStageServices, Reconcile: ReconcileRedis},
+	{Name: "Octavia", Stage: ControlPlaneStageServices, Reconcile: ReconcileOctavia},
+	{Name: "Designate", Stage: ControlPlaneStageServices, Reconcile: ReconcileDesignate},
+	{Name: "Swift", Stage: ControlPlaneStageServices, Reconcile: ReconcileSwift},
+	{Name: "Test", Stage: ControlPlaneStageServices, Reconcile: ReconcileTest},
+	{Name: "InstanceHa", Stage: ControlPlaneStageServices, Reconcile: ReconcileInstanceHa},
+	{Name: "Watcher", Stage: ControlPlaneStageServices, Reconcile: ReconcileWatcher},
+	{Name: "CertsAndRoutesCleanup", Stage: ControlPlaneStageServices, Reconcile: reconcileCertsAndRoutesCleanupStep},
+}
+
+// GetControlPlaneSteps - returns the steps of a stage, in order
+func GetControlPlaneSteps(stage ControlPlaneStage) []ControlPlaneStep {
+	steps := []ControlPlaneStep{}
+	for _, step := range ControlPlaneSteps {
+		if step.Stage == stage {
+			steps = append(steps, step)
+		}
+	}
+	return steps
+}
+
+// PrepareControlPlane - applies the in-memory changes the steps of the
+// normal reconcile expect on the instance, and reports the state they are
+// derived from in its status
+func PrepareControlPlane(instance *corev1beta1.OpenStackControlPlane) {
+	// Propagate the buses of the messaging topology into the service
+	// templates, in memory only to not conflict with GitOps managed specs
+	instance.ApplyMessagingTopology()
+
+	// Warn about the services the openstack-operator doesn't change, they
+	// keep the OpenStackControlPlane from being Ready until managed again
+	if unmanaged := instance.GetUnmanagedServices(); len(unmanaged) > 0 {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			corev1beta1.OpenStackControlPlaneServicesManagedCondition,
+			condition.RequestedReason,
+			condition.SeverityWarning,
+			corev1beta1.OpenStackControlPlaneServicesUnmanagedMessage,
+			strings.Join(unmanaged, ", ")))
+	} else {
+		instance.Status.Conditions.Remove(corev1beta1.OpenStackControlPlaneServicesManagedCondition)
+	}
+
+	// Report the effective scheduling of the pods of the services, the
+	// templates inherit spec.scheduling in the Reconcile* functions
+	instance.Status.Scheduling = instance.GetSchedulingStatus()
+}
+
+// reconcileSizingStep - applies the sizing profile to the templates, in memory
+// only so a change of profile applies to every template, and reports the
+// effective sizing. It runs before the autoscaling, which keeps the replicas of
+// the templates as the minimum.
+func reconcileSizingStep(ctx context.Context, instance *corev1beta1.OpenStackControlPlane, _ *corev1beta1.OpenStackVersion, helper *helper.Helper) (ctrl.Result, error) {
+	profile, err := instance.GetSizingProfile(ctx, helper.GetClient())
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			corev1beta1.OpenStackControlPlaneSizingReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			corev1beta1.OpenStackControlPlaneSizingReadyErrorMessage,
+			err))
+		return ctrl.Result{}, err
+	}
+	instance.Status.Conditions.Remove(corev1beta1.OpenStackControlPlaneSizingReadyCondition)
+	instance.Status.Sizing = nil
+	if profile != nil {
+		instance.Status.Sizing = instance.ApplySizing(profile)
+	}
+	return ctrl.Result{}, nil
+}
+
+// reconcileAutoscalingStep - autoscales the APIs, both the normal and the
+// minor update reconcile propagate the replicas desired by the autoscalers.
+// Waiting for the autoscalers doesn't hold back the reconcile.
+func reconcileAutoscalingStep(ctx context.Context, instance *corev1beta1.OpenStackControlPlane, _ *corev1beta1.OpenStackVersion, helper *helper.Helper) (ctrl.Result, error) {
+	_, err := ReconcileAutoscaling(ctx, instance, helper)
+	return ctrl.Result{}, err
+}
+
+func reconcileCAsStep(ctx context.Context, instance *corev1beta1.OpenStackControlPlane, _ *corev1beta1.OpenStackVersion, helper *helper.Helper) (ctrl.Result, error) {
+	return ReconcileCAs(ctx, instance, helper)
+}
+
+// reconcileCertsAndRoutesCleanupStep - deletes the certificates and routes of
+// the services which don't need them anymore, and reports the failures in the
+// OpenStackControlPlaneCertCleanupReady condition
+func reconcileCertsAndRoutesCleanupStep(ctx context.Context, instance *corev1beta1.OpenStackControlPlane, _ *corev1beta1.OpenStackVersion, helper *helper.Helper) (ctrl.Result, error) {
+	ctrlResult, err := DeleteCertsAndRoutes(ctx, instance, helper)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			corev1beta1.OpenStackControlPlaneCertCleanupReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			corev1beta1.OpenStackControlPlaneCertCleanupReadyErrorMessage,
+			err))
+		return ctrlResult, err
+	}
+	instance.Status.Conditions.Remove(corev1beta1.OpenStackControlPlaneCertCleanupReadyCondition)
+	return ctrl.Result{}, nil
+}
@@ -19,11 +19,15 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
+const (
+	swiftName = "swift"
+)
+
 // ReconcileSwift -
 func ReconcileSwift(ctx context.Context, instance *corev1beta1.OpenStackControlPlane, version *corev1beta1.OpenStackVersion, helper *helper.Helper) (ctrl.Result, error) {
 	swift := &swiftv1.Swift{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "swift",
+			Name:      swiftName,
 			Namespace: instance.Namespace,
 		},
 	}
@@ -47,6 +51,14 @@ func ReconcileSwift(ctx context.Context, instance *corev1beta1.OpenStackControlP
 		return ctrl.Result{}, nil
 	}
 
+	if managed, err := EnsureServiceManaged(ctx, instance, helper, "Swift", swift, corev1beta1.OpenStackControlPlaneSwiftReadyCondition); !managed || err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if !EnsureServiceDependencies(ctx, instance, "Swift") {
+		return ctrl.Result{}, nil
+	}
+
 	if instance.Spec.Swift.Template == nil {
 		instance.Spec.Swift.Template = &swiftv1.SwiftSpecCore{}
 	}
@@ -67,7 +79,7 @@ func ReconcileSwift(ctx context.Context, instance *corev1beta1.OpenStackControlP
 	}
 
 	if instance.Spec.Swift.Template.NodeSelector == nil {
-		instance.Spec.Swift.Template.NodeSelector = &instance.Spec.NodeSelector
+		instance.Spec.Swift.Template.NodeSelector = instance.GetSchedulingNodeSelector()
 	}
 
 	// When there's no Topology referenced in the Service Template, inject the
@@ -75,7 +87,7 @@ func ReconcileSwift(ctx context.Context, instance *corev1beta1.OpenStackControlP
 	// NOTE: This does not check the Service subCRs: by default the generated
 	// subCRs inherit the top-level TopologyRef unless an override is present
 	if instance.Spec.Swift.Template.TopologyRef == nil {
-		instance.Spec.Swift.Template.TopologyRef = instance.Spec.TopologyRef
+		instance.Spec.Swift.Template.TopologyRef = instance.GetSchedulingTopologyRef()
 	}
 
 	// Propagate NotificationsBus from top-level to SwiftProxy template if not set
@@ -98,7 +110,7 @@ func ReconcileSwift(ctx context.Context, instance *corev1beta1.OpenStackControlP
 	}
 
 	// When component services got created check if there is the need to create a route
-	if err := helper.GetClient().Get(ctx, types.NamespacedName{Name: "swift", Namespace: instance.Namespace}, swift); err != nil {
+	if err := helper.GetClient().Get(ctx, types.NamespacedName{Name: swiftName, Namespace: instance.Namespace}, swift); err != nil {
 		if !k8s_errors.IsNotFound(err) {
 			return ctrl.Result{}, err
 		}
@@ -254,10 +266,10 @@ func ReconcileSwift(ctx context.Context, instance *corev1beta1.OpenStackControlP
 	return ctrl.Result{}, nil
 }
 
-// SwiftImageMatch - return true if the swift images match on the ControlPlane and Version, or if Swift is not enabled
+// SwiftImageMatch - return true if the swift images match on the ControlPlane and Version, or if Swift is not enabled or not managed
 func SwiftImageMatch(ctx context.Context, controlPlane *corev1beta1.OpenStackControlPlane, version *corev1beta1.OpenStackVersion) bool {
 	Log := GetLogger(ctx)
-	if controlPlane.Spec.Swift.Enabled {
+	if controlPlane.Spec.Swift.Enabled && !controlPlane.IsServiceUnmanaged("Swift") {
 		if !stringPointersEqual(controlPlane.Status.ContainerImages.SwiftAccountImage, version.Status.ContainerImages.SwiftAccountImage) ||
 			!stringPointersEqual(controlPlane.Status.ContainerImages.SwiftContainerImage, version.Status.ContainerImages.SwiftContainerImage) ||
 			!stringPointersEqual(controlPlane.Status.ContainerImages.SwiftObjectImage, version.Status.ContainerImages.SwiftObjectImage) ||
@@ -67,6 +67,14 @@ func ReconcileTelemetry(ctx context.Context, instance *corev1beta1.OpenStackCont
 		return ctrl.Result{}, nil
 	}
 
+	if managed, err := EnsureServiceManaged(ctx, instance, helper, "Telemetry", telemetry, corev1beta1.OpenStackControlPlaneTelemetryReadyCondition); !managed || err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if !EnsureServiceDependencies(ctx, instance, "Telemetry") {
+		return ctrl.Result{}, nil
+	}
+
 	if instance.Spec.Telemetry.Template == nil {
 		instance.Spec.Telemetry.Template = &telemetryv1.TelemetrySpecCore{}
 	}
@@ -76,7 +84,7 @@ func ReconcileTelemetry(ctx context.Context, instance *corev1beta1.OpenStackCont
 	// No direct spec mutation here to avoid GitOps conflicts.
 
 	if instance.Spec.Telemetry.Template.NodeSelector == nil {
-		instance.Spec.Telemetry.Template.NodeSelector = &instance.Spec.NodeSelector
+		instance.Spec.Telemetry.Template.NodeSelector = instance.GetSchedulingNodeSelector()
 	}
 
 	// When there's no Topology referenced in the Service Template, inject the
@@ -84,7 +92,7 @@ func ReconcileTelemetry(ctx context.Context, instance *corev1beta1.OpenStackCont
 	// NOTE: This does not check the Service subCRs: by default the generated
 	// subCRs inherit the top-level TopologyRef unless an override is present
 	if instance.Spec.Telemetry.Template.TopologyRef == nil {
-		instance.Spec.Telemetry.Template.TopologyRef = instance.Spec.TopologyRef
+		instance.Spec.Telemetry.Template.TopologyRef = instance.GetSchedulingTopologyRef()
 	}
 
 	// Propagate MessagingBus from top-level to CloudKitty if not set
@@ -728,10 +736,10 @@ func ReconcileTelemetry(ctx context.Context, instance *corev1beta1.OpenStackCont
 	return ctrl.Result{}, nil
 }
 
-// TelemetryImageMatch - return true if the telemetry images match on the ControlPlane and Version, or if Telemetry is not enabled
+// TelemetryImageMatch - return true if the telemetry images match on the ControlPlane and Version, or if Telemetry is not enabled or not managed
 func TelemetryImageMatch(ctx context.Context, controlPlane *corev1beta1.OpenStackControlPlane, version *corev1beta1.OpenStackVersion) bool {
 	Log := GetLogger(ctx)
-	if controlPlane.Spec.Telemetry.Enabled {
+	if controlPlane.Spec.Telemetry.Enabled && !controlPlane.IsServiceUnmanaged("Telemetry") {
 		if !stringPointersEqual(controlPlane.Status.ContainerImages.CeilometerCentralImage, version.Status.ContainerImages.CeilometerCentralImage) ||
 			!stringPointersEqual(controlPlane.Status.ContainerImages.CeilometerComputeImage, version.Status.ContainerImages.CeilometerComputeImage) ||
 			!stringPointersEqual(controlPlane.Status.ContainerImages.CeilometerIpmiImage, version.Status.ContainerImages.CeilometerIpmiImage) ||
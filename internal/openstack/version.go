@@ -189,6 +189,7 @@ func GetContainerImages(defaults *corev1beta1.ContainerDefaults, instance corev1
 			OctaviaWorkerImage:            getImg(instance.Spec.CustomContainerImages.OctaviaWorkerImage, defaults.OctaviaWorkerImage),
 			OctaviaRsyslogImage:           getImg(instance.Spec.CustomContainerImages.OctaviaRsyslogImage, defaults.OctaviaRsyslogImage),
 			OpenstackClientImage:          getImg(instance.Spec.CustomContainerImages.OpenstackClientImage, defaults.OpenstackClientImage),
+			OrasImage:                     getImg(instance.Spec.CustomContainerImages.OrasImage, defaults.OrasImage),
 			OsContainerImage:              getImg(instance.Spec.CustomContainerImages.OsContainerImage, defaults.OsContainerImage),
 			OvnControllerImage:            getImg(instance.Spec.CustomContainerImages.OvnControllerImage, defaults.OvnControllerImage),
 			OvnControllerOvsImage:         getImg(instance.Spec.CustomContainerImages.OvnControllerOvsImage, defaults.OvnControllerOvsImage),
@@ -304,7 +305,7 @@ func stringPointersEqual(a, b *string) bool {
 }
 
 // ControlplaneContainerImageMatch - function to compare the ContainerImages on the controlPlane to the OpenStackVersion
-// only enabled services are checked
+// only enabled and managed services are checked, the unmanaged ones are left out of minor updates
 func ControlplaneContainerImageMatch(ctx context.Context, controlPlane *corev1beta1.OpenStackControlPlane, version *corev1beta1.OpenStackVersion) (bool, []string) {
 	failedMatches := []string{}
 	if !BarbicanImageMatch(ctx, controlPlane, version) {
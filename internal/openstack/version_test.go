@@ -0,0 +1,44 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openstack
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega" //revive:disable:dot-imports
+
+	corev1 "github.com/openstack-k8s-operators/openstack-operator/api/core/v1beta1"
+	"k8s.io/utils/ptr"
+)
+
+func TestImageMatchUnmanaged(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	controlPlane := &corev1.OpenStackControlPlane{}
+	controlPlane.Spec.Keystone.Enabled = true
+	controlPlane.Status.ContainerImages.KeystoneAPIImage = ptr.To("keystone:1")
+	version := &corev1.OpenStackVersion{}
+	version.Status.ContainerImages.KeystoneAPIImage = ptr.To("keystone:2")
+
+	g.Expect(KeystoneImageMatch(ctx, controlPlane, version)).To(BeFalse())
+
+	// an unmanaged service is left out of minor updates
+	controlPlane.Spec.Keystone.ManagementState = corev1.ManagementStateUnmanaged
+	g.Expect(KeystoneImageMatch(ctx, controlPlane, version)).To(BeTrue())
+}
@@ -43,6 +43,14 @@ func ReconcileWatcher(ctx context.Context, instance *corev1beta1.OpenStackContro
 		return ctrl.Result{}, nil
 	}
 
+	if managed, err := EnsureServiceManaged(ctx, instance, helper, "Watcher", watcher, corev1beta1.OpenStackControlPlaneWatcherReadyCondition); !managed || err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if !EnsureServiceDependencies(ctx, instance, "Watcher") {
+		return ctrl.Result{}, nil
+	}
+
 	if instance.Spec.Watcher.Template == nil {
 		instance.Spec.Watcher.Template = &watcherv1.WatcherSpecCore{}
 	}
@@ -166,7 +174,7 @@ func ReconcileWatcher(ctx context.Context, instance *corev1beta1.OpenStackContro
 	}
 
 	if instance.Spec.Watcher.Template.NodeSelector == nil {
-		instance.Spec.Watcher.Template.NodeSelector = &instance.Spec.NodeSelector
+		instance.Spec.Watcher.Template.NodeSelector = instance.GetSchedulingNodeSelector()
 	}
 
 	// When there's no Topology referenced in the Service Template, inject the
@@ -174,7 +182,7 @@ func ReconcileWatcher(ctx context.Context, instance *corev1beta1.OpenStackContro
 	// NOTE: This does not check the Service subCRs: by default the generated
 	// subCRs inherit the top-level TopologyRef unless an override is present
 	if instance.Spec.Watcher.Template.TopologyRef == nil {
-		instance.Spec.Watcher.Template.TopologyRef = instance.Spec.TopologyRef
+		instance.Spec.Watcher.Template.TopologyRef = instance.GetSchedulingTopologyRef()
 	}
 
 	// Propagate MessagingBus from top-level to template if not set
@@ -262,11 +270,11 @@ func ReconcileWatcher(ctx context.Context, instance *corev1beta1.OpenStackContro
 	return ctrl.Result{}, nil
 }
 
-// WatcherImageMatch - return true if the Watcher images match on the ControlPlane and Version, or if Watcher is not enabled
+// WatcherImageMatch - return true if the Watcher images match on the ControlPlane and Version, or if Watcher is not enabled or not managed
 func WatcherImageMatch(ctx context.Context, controlPlane *corev1beta1.OpenStackControlPlane, version *corev1beta1.OpenStackVersion) bool {
 	Log := GetLogger(ctx)
 
-	if controlPlane.Spec.Watcher.Enabled {
+	if controlPlane.Spec.Watcher.Enabled && !controlPlane.IsServiceUnmanaged("Watcher") {
 		if !stringPointersEqual(controlPlane.Status.ContainerImages.WatcherAPIImage, version.Status.ContainerImages.WatcherAPIImage) ||
 			!stringPointersEqual(controlPlane.Status.ContainerImages.WatcherApplierImage, version.Status.ContainerImages.WatcherApplierImage) ||
 			!stringPointersEqual(controlPlane.Status.ContainerImages.WatcherDecisionEngineImage, version.Status.ContainerImages.WatcherDecisionEngineImage) {
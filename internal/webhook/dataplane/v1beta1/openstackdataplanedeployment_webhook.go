@@ -21,7 +21,9 @@ import (
 	"context"
 	"fmt"
 
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -36,6 +38,10 @@ var openstackdataplanedeploymentlog = logf.Log.WithName("openstackdataplanedeplo
 
 // SetupOpenStackDataPlaneDeploymentWebhookWithManager registers the webhook for OpenStackDataPlaneDeployment in the manager.
 func SetupOpenStackDataPlaneDeploymentWebhookWithManager(mgr ctrl.Manager) error {
+	if webhookClient == nil {
+		webhookClient = mgr.GetClient()
+	}
+
 	return ctrl.NewWebhookManagedBy(mgr).For(&dataplanev1beta1.OpenStackDataPlaneDeployment{}).
 		WithValidator(&OpenStackDataPlaneDeploymentCustomValidator{}).
 		WithDefaulter(&OpenStackDataPlaneDeploymentCustomDefaulter{}).
@@ -86,7 +92,7 @@ type OpenStackDataPlaneDeploymentCustomValidator struct {
 var _ webhook.CustomValidator = &OpenStackDataPlaneDeploymentCustomValidator{}
 
 // ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type OpenStackDataPlaneDeployment.
-func (v *OpenStackDataPlaneDeploymentCustomValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+func (v *OpenStackDataPlaneDeploymentCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
 	openstackdataplanedeployment, ok := obj.(*dataplanev1beta1.OpenStackDataPlaneDeployment)
 	if !ok {
 		return nil, fmt.Errorf("expected a OpenStackDataPlaneDeployment object but got %T", obj)
@@ -95,7 +101,33 @@ func (v *OpenStackDataPlaneDeploymentCustomValidator) ValidateCreate(_ context.C
 		openstackdataplanedeployment.GetName())
 
 	// Call the ValidateCreate method on the OpenStackDataPlaneDeployment type
-	return openstackdataplanedeployment.ValidateCreate()
+	warnings, err := openstackdataplanedeployment.ValidateCreate()
+	if err != nil {
+		return warnings, err
+	}
+
+	// The NodeSets are validated against the services the deployment runs,
+	// the errors point at the NodeSet setting the offending variables
+	for _, nodeSetName := range openstackdataplanedeployment.Spec.NodeSets {
+		nodeSet := &dataplanev1beta1.OpenStackDataPlaneNodeSet{}
+		err = webhookClient.Get(ctx, types.NamespacedName{
+			Name: nodeSetName, Namespace: openstackdataplanedeployment.Namespace}, nodeSet)
+		if err != nil {
+			if k8s_errors.IsNotFound(err) {
+				continue
+			}
+			return warnings, err
+		}
+		services := nodeSet.Spec.Services
+		if len(openstackdataplanedeployment.Spec.ServicesOverride) > 0 {
+			services = openstackdataplanedeployment.Spec.ServicesOverride
+		}
+		warnings, err = validateAnsibleVarsSchemas(ctx, nodeSet, services, warnings)
+		if err != nil {
+			return warnings, err
+		}
+	}
+	return warnings, nil
 }
 
 // ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type OpenStackDataPlaneDeployment.
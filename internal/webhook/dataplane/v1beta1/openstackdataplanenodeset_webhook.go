@@ -20,14 +20,19 @@ import (
 	"context"
 	"fmt"
 
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
 	dataplanev1beta1 "github.com/openstack-k8s-operators/openstack-operator/api/dataplane/v1beta1"
+	deployment "github.com/openstack-k8s-operators/openstack-operator/internal/dataplane"
 )
 
 // nolint:unused
@@ -101,7 +106,17 @@ func (v *OpenStackDataPlaneNodeSetCustomValidator) ValidateCreate(ctx context.Co
 	openstackdataplanenodesetlog.Info("Validation for OpenStackDataPlaneNodeSet upon creation", "name", openstackdataplanenodeset.GetName())
 
 	// Call the ValidateCreate method on the OpenStackDataPlaneNodeSet type
-	return openstackdataplanenodeset.ValidateCreate(ctx, webhookClient)
+	warnings, err := openstackdataplanenodeset.ValidateCreate(ctx, webhookClient)
+	if err != nil {
+		return warnings, err
+	}
+
+	warnings, err = validateNetworkConfigTemplates(ctx, openstackdataplanenodeset, warnings)
+	if err != nil {
+		return warnings, err
+	}
+
+	return validateAnsibleVarsSchemas(ctx, openstackdataplanenodeset, openstackdataplanenodeset.Spec.Services, warnings)
 }
 
 // ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type OpenStackDataPlaneNodeSet.
@@ -113,7 +128,21 @@ func (v *OpenStackDataPlaneNodeSetCustomValidator) ValidateUpdate(ctx context.Co
 	openstackdataplanenodesetlog.Info("Validation for OpenStackDataPlaneNodeSet upon update", "name", openstackdataplanenodeset.GetName())
 
 	// Call the ValidateUpdate method on the OpenStackDataPlaneNodeSet type
-	return openstackdataplanenodeset.ValidateUpdate(ctx, oldObj, webhookClient)
+	warnings, err := openstackdataplanenodeset.ValidateUpdate(ctx, oldObj, webhookClient)
+	if err != nil {
+		return warnings, err
+	}
+
+	if !isSpecUpdated(oldObj, openstackdataplanenodeset) {
+		return warnings, nil
+	}
+
+	warnings, err = validateNetworkConfigTemplates(ctx, openstackdataplanenodeset, warnings)
+	if err != nil {
+		return warnings, err
+	}
+
+	return validateAnsibleVarsSchemas(ctx, openstackdataplanenodeset, openstackdataplanenodeset.Spec.Services, warnings)
 }
 
 // ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type OpenStackDataPlaneNodeSet.
@@ -127,3 +156,72 @@ func (v *OpenStackDataPlaneNodeSetCustomValidator) ValidateDelete(ctx context.Co
 	// Call the ValidateDelete method on the OpenStackDataPlaneNodeSet type
 	return openstackdataplanenodeset.ValidateDelete(ctx, webhookClient)
 }
+
+// isSpecUpdated returns true if the update changes the spec of a NodeSet which
+// isn't being deleted. The metadata and status updates, like the finalizer
+// removal, must go through even when the NodeSet doesn't validate anymore
+// against the current NetConfig or services.
+func isSpecUpdated(oldObj runtime.Object, instance *dataplanev1beta1.OpenStackDataPlaneNodeSet) bool {
+	if !instance.DeletionTimestamp.IsZero() {
+		return false
+	}
+	oldNodeSet, ok := oldObj.(*dataplanev1beta1.OpenStackDataPlaneNodeSet)
+	if !ok {
+		return true
+	}
+	return !equality.Semantic.DeepEqual(oldNodeSet.Spec, instance.Spec)
+}
+
+// validateNetworkConfigTemplates checks the networks of the nodes and the
+// network variable names used by their network config template against their
+// inventory before any deployment
+func validateNetworkConfigTemplates(
+	ctx context.Context,
+	instance *dataplanev1beta1.OpenStackDataPlaneNodeSet,
+	warnings admission.Warnings,
+) (admission.Warnings, error) {
+	h, err := helper.NewHelper(instance, webhookClient, nil, webhookClient.Scheme(), openstackdataplanenodesetlog)
+	if err != nil {
+		return warnings, err
+	}
+
+	templateWarnings, errs, err := deployment.ValidateNetworkConfigTemplates(ctx, h, instance)
+	warnings = append(warnings, templateWarnings...)
+	if err != nil {
+		return warnings, err
+	}
+	if len(errs) > 0 {
+		return warnings, apierrors.NewInvalid(
+			schema.GroupKind{Group: "dataplane.openstack.org", Kind: "OpenStackDataPlaneNodeSet"},
+			instance.Name,
+			errs)
+	}
+	return warnings, nil
+}
+
+// validateAnsibleVarsSchemas checks the group and host vars of the nodes
+// against the ansibleVarsSchema of the services deployed on the NodeSet
+func validateAnsibleVarsSchemas(
+	ctx context.Context,
+	instance *dataplanev1beta1.OpenStackDataPlaneNodeSet,
+	services []string,
+	warnings admission.Warnings,
+) (admission.Warnings, error) {
+	h, err := helper.NewHelper(instance, webhookClient, nil, webhookClient.Scheme(), openstackdataplanenodesetlog)
+	if err != nil {
+		return warnings, err
+	}
+
+	schemaWarnings, errs, err := deployment.ValidateAnsibleVarsSchemas(ctx, h, instance, services)
+	warnings = append(warnings, schemaWarnings...)
+	if err != nil {
+		return warnings, err
+	}
+	if len(errs) > 0 {
+		return warnings, apierrors.NewInvalid(
+			schema.GroupKind{Group: "dataplane.openstack.org", Kind: "OpenStackDataPlaneNodeSet"},
+			instance.Name,
+			errs)
+	}
+	return warnings, nil
+}
@@ -20,13 +20,16 @@ import (
 	"context"
 	"fmt"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	dataplanev1beta1 "github.com/openstack-k8s-operators/openstack-operator/api/dataplane/v1beta1"
+	deployment "github.com/openstack-k8s-operators/openstack-operator/internal/dataplane"
 )
 
 // nolint:unused
@@ -96,7 +99,12 @@ func (v *OpenStackDataPlaneServiceCustomValidator) ValidateCreate(_ context.Cont
 	openstackdataplaneservicelog.Info("Validation for OpenStackDataPlaneService upon creation", "name", openstackdataplaneservice.GetName())
 
 	// Call the ValidateCreate method on the OpenStackDataPlaneService type
-	return openstackdataplaneservice.ValidateCreate()
+	warnings, err := openstackdataplaneservice.ValidateCreate()
+	if err != nil {
+		return warnings, err
+	}
+
+	return warnings, validateAnsibleVarsSchema(openstackdataplaneservice)
 }
 
 // ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type OpenStackDataPlaneService.
@@ -108,7 +116,12 @@ func (v *OpenStackDataPlaneServiceCustomValidator) ValidateUpdate(_ context.Cont
 	openstackdataplaneservicelog.Info("Validation for OpenStackDataPlaneService upon update", "name", openstackdataplaneservice.GetName())
 
 	// Call the ValidateUpdate method on the OpenStackDataPlaneService type
-	return openstackdataplaneservice.ValidateUpdate(oldObj)
+	warnings, err := openstackdataplaneservice.ValidateUpdate(oldObj)
+	if err != nil {
+		return warnings, err
+	}
+
+	return warnings, validateAnsibleVarsSchema(openstackdataplaneservice)
 }
 
 // ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type OpenStackDataPlaneService.
@@ -122,3 +135,16 @@ func (v *OpenStackDataPlaneServiceCustomValidator) ValidateDelete(_ context.Cont
 	// Call the ValidateDelete method on the OpenStackDataPlaneService type
 	return openstackdataplaneservice.ValidateDelete()
 }
+
+// validateAnsibleVarsSchema checks the ansibleVarsSchema of the service can
+// be used to validate the ansible variables of the NodeSets
+func validateAnsibleVarsSchema(instance *dataplanev1beta1.OpenStackDataPlaneService) error {
+	errs := deployment.ValidateAnsibleVarsSchema(instance)
+	if len(errs) > 0 {
+		return apierrors.NewInvalid(
+			schema.GroupKind{Group: "dataplane.openstack.org", Kind: "OpenStackDataPlaneService"},
+			instance.Name,
+			errs)
+	}
+	return nil
+}
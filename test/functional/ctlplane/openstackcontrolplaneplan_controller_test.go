@@ -0,0 +1,176 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package functional_test
+
+import (
+	"fmt"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2" //revive:disable:dot-imports
+	. "github.com/onsi/gomega"    //revive:disable:dot-imports
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	//revive:disable-next-line:dot-imports
+	. "github.com/openstack-k8s-operators/lib-common/modules/common/test/helpers"
+
+	corev1 "github.com/openstack-k8s-operators/openstack-operator/api/core/v1beta1"
+	k8s_corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+)
+
+var _ = Describe("OpenStackControlPlanePlan controller", func() {
+	var planName types.NamespacedName
+
+	BeforeEach(func() {
+		// lib-common uses OPERATOR_TEMPLATES env var to locate the "templates"
+		// directory of the operator. We need to set them othervise lib-common
+		// will fail to generate the ConfigMap as it does not find common.sh
+		err := os.Setenv("OPERATOR_TEMPLATES", "../../templates")
+		Expect(err).NotTo(HaveOccurred())
+
+		// create cluster config map which is used to validate if cluster supports fips
+		DeferCleanup(k8sClient.Delete, ctx, CreateClusterConfigCM())
+
+		// create root CA secrets as there is no certmanager running.
+		DeferCleanup(k8sClient.Delete, ctx, CreateCertSecret(names.RootCAPublicName))
+		DeferCleanup(k8sClient.Delete, ctx, CreateCertSecret(names.RootCAInternalName))
+		DeferCleanup(k8sClient.Delete, ctx, CreateCertSecret(names.RootCAOvnName))
+		DeferCleanup(k8sClient.Delete, ctx, CreateCertSecret(names.RootCALibvirtName))
+		// create cert secrets for galera instances
+		DeferCleanup(k8sClient.Delete, ctx, th.CreateCertSecret(names.DBCertName))
+		DeferCleanup(k8sClient.Delete, ctx, th.CreateCertSecret(names.DBCell1CertName))
+
+		planName = types.NamespacedName{
+			Namespace: names.Namespace,
+			Name:      "openstack-plan",
+		}
+	})
+
+	When("An OpenStackControlPlanePlan is created for a missing OpenStackControlPlane", func() {
+		BeforeEach(func() {
+			DeferCleanup(
+				th.DeleteInstance,
+				CreateOpenStackControlPlanePlan(planName, map[string]interface{}{
+					"controlPlane": names.OpenStackControlplaneName.Name,
+				}),
+			)
+		})
+
+		It("should wait for the OpenStackControlPlane", func() {
+			th.ExpectConditionWithDetails(
+				planName,
+				ConditionGetterFunc(OpenStackControlPlanePlanConditionGetter),
+				condition.ReadyCondition,
+				k8s_corev1.ConditionFalse,
+				condition.RequestedReason,
+				fmt.Sprintf(corev1.OpenStackControlPlanePlanReadyWaitingMessage,
+					names.OpenStackControlplaneName.Name),
+			)
+		})
+	})
+
+	When("An OpenStackControlPlanePlan is created for an OpenStackControlPlane", func() {
+		BeforeEach(func() {
+			DeferCleanup(
+				th.DeleteInstance,
+				CreateOpenStackControlPlane(names.OpenStackControlplaneName, GetDefaultOpenStackControlPlaneSpec()),
+			)
+
+			// memcached exists
+			Eventually(func(g Gomega) {
+				memcached := infra.GetMemcached(names.MemcachedName)
+				g.Expect(memcached).Should(Not(BeNil()))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("should list the changes of the proposed spec without applying them", func() {
+			DeferCleanup(
+				th.DeleteInstance,
+				CreateOpenStackControlPlanePlan(planName, map[string]interface{}{
+					"controlPlane": names.OpenStackControlplaneName.Name,
+					"specPatch": map[string]interface{}{
+						"memcached": map[string]interface{}{
+							"templates": map[string]interface{}{
+								names.MemcachedName.Name: map[string]interface{}{
+									"replicas": 3,
+								},
+							},
+						},
+					},
+				}),
+			)
+
+			th.ExpectCondition(
+				planName,
+				ConditionGetterFunc(OpenStackControlPlanePlanConditionGetter),
+				condition.ReadyCondition,
+				k8s_corev1.ConditionTrue,
+			)
+
+			plan := GetOpenStackControlPlanePlan(planName)
+			Expect(plan.Status.ControlPlaneGeneration).Should(
+				Equal(GetOpenStackControlPlane(names.OpenStackControlplaneName).Generation))
+
+			var memcachedChange *corev1.OpenStackControlPlanePlanChange
+			for i, change := range plan.Status.Changes {
+				if change.Kind == "Memcached" && change.Name == names.MemcachedName.Name {
+					memcachedChange = &plan.Status.Changes[i]
+				}
+			}
+			Expect(memcachedChange).ShouldNot(BeNil())
+			Expect(memcachedChange.Action).Should(Equal(corev1.PlannedActionUpdate))
+			Expect(memcachedChange.Fields).Should(ContainElement("spec.replicas"))
+
+			// neither the controlplane nor the memcached changed
+			OSCtlplane := GetOpenStackControlPlane(names.OpenStackControlplaneName)
+			Expect((*OSCtlplane.Spec.Memcached.Templates)[names.MemcachedName.Name].Replicas).Should(
+				Equal(ptr.To[int32](1)))
+			memcached := infra.GetMemcached(names.MemcachedName)
+			Expect(memcached.Spec.Replicas).Should(Equal(ptr.To[int32](1)))
+		})
+
+		It("should report a proposed spec the webhook rejects", func() {
+			DeferCleanup(
+				th.DeleteInstance,
+				CreateOpenStackControlPlanePlan(planName, map[string]interface{}{
+					"controlPlane": names.OpenStackControlplaneName.Name,
+					"specPatch": map[string]interface{}{
+						"galera": map[string]interface{}{
+							"templates": map[string]interface{}{
+								// still referenced by keystone
+								names.DBName.Name: nil,
+							},
+						},
+					},
+				}),
+			)
+
+			Eventually(func(g Gomega) {
+				conditions := OpenStackControlPlanePlanConditionGetter(planName)
+				ready := conditions.Get(condition.ReadyCondition)
+				g.Expect(ready).ShouldNot(BeNil())
+				g.Expect(ready.Status).Should(Equal(k8s_corev1.ConditionFalse))
+				g.Expect(ready.Reason).Should(Equal(condition.ErrorReason))
+				g.Expect(ready.Message).Should(ContainSubstring("is still referenced by"))
+			}, timeout, interval).Should(Succeed())
+
+			plan := GetOpenStackControlPlanePlan(planName)
+			Expect(plan.Status.Changes).Should(BeEmpty())
+		})
+	})
+})
@@ -29,6 +29,7 @@ import (
 	. "github.com/openstack-k8s-operators/lib-common/modules/common/test/helpers"
 	mariadbv1 "github.com/openstack-k8s-operators/mariadb-operator/api/v1beta1"
 
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	k8s_corev1 "k8s.io/api/core/v1"
 	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -43,6 +44,7 @@ import (
 	cinderv1 "github.com/openstack-k8s-operators/cinder-operator/api/v1beta1"
 	rabbitmqv1 "github.com/openstack-k8s-operators/infra-operator/apis/rabbitmq/v1beta1"
 	topologyv1 "github.com/openstack-k8s-operators/infra-operator/apis/topology/v1beta1"
+	keystonev1 "github.com/openstack-k8s-operators/keystone-operator/api/v1beta1"
 
 	"github.com/openstack-k8s-operators/lib-common/modules/certmanager"
 	common_annotations "github.com/openstack-k8s-operators/lib-common/modules/common/annotations"
@@ -669,6 +671,8 @@ var _ = Describe("OpenStackOperator controller", func() {
 				th.DeleteInstance,
 				CreateOpenStackControlPlane(names.OpenStackControlplaneName, spec),
 			)
+			// keystone waits for galera, memcached and rabbitmq to be ready
+			SimulateInfraReady()
 		})
 
 		It("should have the Spec fields defaulted", func() {
@@ -943,6 +947,8 @@ var _ = Describe("OpenStackOperator controller", func() {
 				th.DeleteInstance,
 				CreateOpenStackControlPlane(names.OpenStackControlplaneName, GetDefaultOpenStackControlPlaneSpec()),
 			)
+			// keystone waits for galera, memcached and rabbitmq to be ready
+			SimulateInfraReady()
 		})
 
 		It("should have the Spec fields defaulted", func() {
@@ -1415,6 +1421,8 @@ var _ = Describe("OpenStackOperator controller", func() {
 				th.DeleteInstance,
 				CreateOpenStackControlPlane(names.OpenStackControlplaneName, spec),
 			)
+			// keystone waits for galera, memcached and rabbitmq to be ready
+			SimulateInfraReady()
 		})
 
 		It("should have the Spec fields defaulted", func() {
@@ -1687,6 +1695,9 @@ var _ = Describe("OpenStackOperator controller", func() {
 				th.DeleteInstance,
 				CreateOpenStackControlPlane(names.OpenStackControlplaneName, spec),
 			)
+			// cinder waits for galera, memcached, rabbitmq and keystone to be ready
+			SimulateInfraReady()
+			keystone.SimulateKeystoneAPIReady(names.KeystoneAPIName)
 		})
 
 		It("should have Cinder enabled", func() {
@@ -2003,7 +2014,9 @@ var _ = Describe("OpenStackOperator controller", func() {
 			}
 
 			Eventually(func(g Gomega) {
-				g.Expect(CreateOpenStackControlPlane(names.OpenStackControlplaneName, spec)).Should(Not(BeNil()))
+				// nova waits for glance, neutron and placement to be ready,
+				// their readiness is not simulated
+				g.Expect(CreateOpenStackControlPlaneWithoutDependencyWait(names.OpenStackControlplaneName, spec)).Should(Not(BeNil()))
 				keystoneAPI := keystone.GetKeystoneAPI(names.KeystoneAPIName)
 				g.Expect(keystoneAPI).Should(Not(BeNil()))
 				SimulateControlplaneReady()
@@ -2093,9 +2106,11 @@ var _ = Describe("OpenStackOperator controller", func() {
 			DeferCleanup(k8sClient.Delete, ctx, th.CreateCertSecret(names.WatcherCertPublicSvcName))
 			DeferCleanup(k8sClient.Delete, ctx, th.CreateCertSecret(names.WatcherCertInternalName))
 
+			// watcher waits for telemetry to be ready, its readiness is not
+			// simulated
 			DeferCleanup(
 				th.DeleteInstance,
-				CreateOpenStackControlPlane(names.OpenStackControlplaneName, spec),
+				CreateOpenStackControlPlaneWithoutDependencyWait(names.OpenStackControlplaneName, spec),
 			)
 
 		})
@@ -2283,9 +2298,11 @@ var _ = Describe("OpenStackOperator controller", func() {
 			DeferCleanup(k8sClient.Delete, ctx, th.CreateCertSecret(names.WatcherCertPublicSvcName))
 			DeferCleanup(k8sClient.Delete, ctx, th.CreateCertSecret(names.WatcherCertInternalName))
 
+			// watcher waits for telemetry to be ready, its readiness is not
+			// simulated
 			DeferCleanup(
 				th.DeleteInstance,
-				CreateOpenStackControlPlane(names.OpenStackControlplaneName, spec),
+				CreateOpenStackControlPlaneWithoutDependencyWait(names.OpenStackControlplaneName, spec),
 			)
 		})
 
@@ -2502,6 +2519,8 @@ var _ = Describe("OpenStackOperator controller", func() {
 				th.DeleteInstance,
 				CreateOpenStackControlPlane(names.OpenStackControlplaneName, spec),
 			)
+			// keystone waits for galera, memcached and rabbitmq to be ready
+			SimulateInfraReady()
 
 			Eventually(func(g Gomega) {
 				keystoneAPI := keystone.GetKeystoneAPI(names.KeystoneAPIName)
@@ -2758,9 +2777,11 @@ var _ = Describe("OpenStackOperator controller", func() {
 			DeferCleanup(k8sClient.Delete, ctx, th.CreateCertSecret(names.InstanceHAMetricsCertName))
 			DeferCleanup(k8sClient.Delete, ctx, th.CreateCertSecret(names.NeutronOVNCertName))
 
+			// watcher waits for telemetry to be ready, its readiness is not
+			// simulated
 			DeferCleanup(
 				th.DeleteInstance,
-				CreateOpenStackControlPlane(names.OpenStackControlplaneName, spec),
+				CreateOpenStackControlPlaneWithoutDependencyWait(names.OpenStackControlplaneName, spec),
 			)
 			Eventually(func(g Gomega) {
 				keystoneAPI := keystone.GetKeystoneAPI(names.KeystoneAPIName)
@@ -3002,9 +3023,11 @@ var _ = Describe("OpenStackOperator controller", func() {
 			// create cert secret for octavia ovn client
 			DeferCleanup(k8sClient.Delete, ctx, th.CreateCertSecret(types.NamespacedName{Name: "cert-octavia-ovndbs", Namespace: names.Namespace}))
 
+			// nova, octavia and watcher wait for services whose readiness is
+			// not simulated
 			DeferCleanup(
 				th.DeleteInstance,
-				CreateOpenStackControlPlane(names.OpenStackControlplaneName, spec),
+				CreateOpenStackControlPlaneWithoutDependencyWait(names.OpenStackControlplaneName, spec),
 			)
 			Eventually(func(g Gomega) {
 				keystoneAPI := keystone.GetKeystoneAPI(names.KeystoneAPIName)
@@ -3270,6 +3293,309 @@ var _ = Describe("OpenStackOperator controller", func() {
 		})
 
 	})
+
+	When("An OpenStackControlPlane waiting for the dependencies of its services is created", func() {
+		BeforeEach(func() {
+			DeferCleanup(
+				th.DeleteInstance,
+				CreateOpenStackControlPlane(names.OpenStackControlplaneName, GetDefaultOpenStackControlPlaneSpec()),
+			)
+		})
+
+		It("should only create keystone once galera, memcached and rabbitmq are ready", func() {
+			th.ExpectConditionWithDetails(
+				names.OpenStackControlplaneName,
+				ConditionGetterFunc(OpenStackControlPlaneConditionGetter),
+				corev1.OpenStackControlPlaneKeystoneAPIReadyCondition,
+				k8s_corev1.ConditionFalse,
+				condition.RequestedReason,
+				fmt.Sprintf(corev1.OpenStackControlPlaneServiceDependenciesWaitingMessage,
+					"Keystone", "Galera, Memcached, RabbitMQ"),
+			)
+			err := k8sClient.Get(ctx, names.KeystoneAPIName, &keystonev1.KeystoneAPI{})
+			Expect(k8s_errors.IsNotFound(err)).To(BeTrue())
+
+			SimulateInfraReady()
+
+			Eventually(func(g Gomega) {
+				keystoneAPI := keystone.GetKeystoneAPI(names.KeystoneAPIName)
+				g.Expect(keystoneAPI).Should(Not(BeNil()))
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("An OpenStackControlPlane with an unmanaged service is created", func() {
+		BeforeEach(func() {
+			spec := GetDefaultOpenStackControlPlaneSpec()
+			spec["keystone"].(map[string]interface{})["managementState"] = "Unmanaged"
+			DeferCleanup(
+				th.DeleteInstance,
+				CreateOpenStackControlPlane(names.OpenStackControlplaneName, spec),
+			)
+			SimulateInfraReady()
+		})
+
+		It("should not create the service and warn it isn't managed", func() {
+			th.ExpectConditionWithDetails(
+				names.OpenStackControlplaneName,
+				ConditionGetterFunc(OpenStackControlPlaneConditionGetter),
+				corev1.OpenStackControlPlaneServicesManagedCondition,
+				k8s_corev1.ConditionFalse,
+				condition.RequestedReason,
+				fmt.Sprintf(corev1.OpenStackControlPlaneServicesUnmanagedMessage, "Keystone"),
+			)
+			th.ExpectConditionWithDetails(
+				names.OpenStackControlplaneName,
+				ConditionGetterFunc(OpenStackControlPlaneConditionGetter),
+				corev1.OpenStackControlPlaneKeystoneAPIReadyCondition,
+				k8s_corev1.ConditionFalse,
+				condition.RequestedReason,
+				fmt.Sprintf(corev1.OpenStackControlPlaneServiceUnmanagedNotFoundMessage,
+					"Keystone", names.KeystoneAPIName.Name),
+			)
+			Consistently(func(g Gomega) {
+				err := k8sClient.Get(ctx, names.KeystoneAPIName, &keystonev1.KeystoneAPI{})
+				g.Expect(k8s_errors.IsNotFound(err)).To(BeTrue())
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("should manage the service again once Managed", func() {
+			Eventually(func(g Gomega) {
+				OSCtlplane := GetOpenStackControlPlane(names.OpenStackControlplaneName)
+				OSCtlplane.Spec.Keystone.ManagementState = corev1.ManagementStateManaged
+				g.Expect(k8sClient.Update(ctx, OSCtlplane)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				keystoneAPI := keystone.GetKeystoneAPI(names.KeystoneAPIName)
+				g.Expect(keystoneAPI).Should(Not(BeNil()))
+			}, timeout, interval).Should(Succeed())
+			Eventually(func(g Gomega) {
+				OSCtlplane := GetOpenStackControlPlane(names.OpenStackControlplaneName)
+				g.Expect(OSCtlplane.Status.Conditions.Get(corev1.OpenStackControlPlaneServicesManagedCondition)).To(BeNil())
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("An OpenStackControlPlane with a scheduling policy is created", func() {
+		BeforeEach(func() {
+			spec := GetDefaultOpenStackControlPlaneSpec()
+			spec["nodeSelector"] = map[string]interface{}{
+				"foo": "bar",
+			}
+			spec["scheduling"] = map[string]interface{}{
+				"nodeSelector": map[string]interface{}{
+					"foo": "scheduling",
+				},
+				"tolerations": []interface{}{
+					map[string]interface{}{
+						"key":      "openstack",
+						"operator": "Exists",
+					},
+				},
+				"podAntiAffinity": "Required",
+			}
+			DeferCleanup(
+				th.DeleteInstance,
+				CreateOpenStackControlPlane(names.OpenStackControlplaneName, spec),
+			)
+		})
+
+		It("applies the scheduling policy over the top-level nodeSelector", func() {
+			Eventually(func(g Gomega) {
+				db := mariadb.GetGalera(names.DBName)
+				g.Expect(*db.Spec.NodeSelector).To(Equal(map[string]string{"foo": "scheduling"}))
+			}, timeout, interval).Should(Succeed())
+			Eventually(func(g Gomega) {
+				rmq := GetRabbitMQCluster(names.RabbitMQName)
+				g.Expect(*rmq.Spec.NodeSelector).To(Equal(map[string]string{"foo": "scheduling"}))
+				g.Expect(rmq.Spec.Tolerations).To(HaveLen(1))
+				g.Expect(rmq.Spec.Tolerations[0].Key).To(Equal("openstack"))
+				g.Expect(rmq.Spec.Affinity).ToNot(BeNil())
+				g.Expect(rmq.Spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution).To(HaveLen(1))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("reports the effective scheduling of the services", func() {
+			Eventually(func(g Gomega) {
+				OSCtlplane := GetOpenStackControlPlane(names.OpenStackControlplaneName)
+				galera, exists := OSCtlplane.Status.Scheduling["galera/"+names.DBName.Name]
+				g.Expect(exists).To(BeTrue())
+				g.Expect(galera.NodeSelector).To(Equal(map[string]string{"foo": "scheduling"}))
+				g.Expect(galera.Tolerations).To(BeEmpty())
+
+				rabbitmq, exists := OSCtlplane.Status.Scheduling["rabbitmq/"+names.RabbitMQName.Name]
+				g.Expect(exists).To(BeTrue())
+				g.Expect(rabbitmq.Tolerations).To(HaveLen(1))
+				g.Expect(rabbitmq.PodAntiAffinity).To(Equal(corev1.PodAntiAffinityRequired))
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("An OpenStackControlPlane with a custom sizing profile is created", func() {
+		BeforeEach(func() {
+			th.CreateConfigMap(types.NamespacedName{
+				Name:      "sizing-profiles",
+				Namespace: namespace,
+			}, map[string]interface{}{
+				"lab": `
+default:
+  replicas: 3
+  resources:
+    requests:
+      cpu: 50m
+      memory: 128Mi
+  workers: 2
+services:
+  galera:
+    resources:
+      requests:
+        memory: 512Mi
+`,
+			})
+
+			spec := GetDefaultOpenStackControlPlaneSpec()
+			spec["sizing"] = map[string]interface{}{
+				"profile":   "lab",
+				"configMap": "sizing-profiles",
+			}
+			DeferCleanup(
+				th.DeleteInstance,
+				CreateOpenStackControlPlane(names.OpenStackControlplaneName, spec),
+			)
+			// keystone waits for galera, memcached and rabbitmq to be ready
+			SimulateInfraReady()
+		})
+
+		It("applies the profile to the services, leaving the spec unchanged", func() {
+			Eventually(func(g Gomega) {
+				keystoneAPI := keystone.GetKeystoneAPI(names.KeystoneAPIName)
+				g.Expect(*keystoneAPI.Spec.Replicas).To(Equal(int32(3)))
+				g.Expect(keystoneAPI.Spec.Resources.Requests.Cpu().String()).To(Equal("50m"))
+				g.Expect(*keystoneAPI.Spec.HttpdCustomization.ProcessNumber).To(Equal(int32(2)))
+			}, timeout, interval).Should(Succeed())
+			Eventually(func(g Gomega) {
+				db := mariadb.GetGalera(names.DBName)
+				g.Expect(*db.Spec.Replicas).To(Equal(int32(3)))
+				g.Expect(db.Spec.Resources.Requests.Memory().String()).To(Equal("512Mi"))
+			}, timeout, interval).Should(Succeed())
+
+			OSCtlplane := GetOpenStackControlPlane(names.OpenStackControlplaneName)
+			Expect(*OSCtlplane.Spec.Keystone.Template.Replicas).To(Equal(int32(1)))
+			Expect(OSCtlplane.Spec.Keystone.Template.Resources.Requests).To(BeEmpty())
+
+			// the replicas defaulted by the CRD schema are reported as replaced
+			Eventually(func(g Gomega) {
+				sizing := GetOpenStackControlPlane(names.OpenStackControlplaneName).Status.Sizing
+				g.Expect(sizing).To(HaveKey("keystone"))
+				g.Expect(*sizing["keystone"].Replicas).To(Equal(int32(3)))
+				g.Expect(*sizing["keystone"].Workers).To(Equal(int32(2)))
+				g.Expect(sizing["keystone"].ReplacedFields).To(ContainElement("replicas"))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("applies a change of profile to the services", func() {
+			Eventually(func(g Gomega) {
+				keystoneAPI := keystone.GetKeystoneAPI(names.KeystoneAPIName)
+				g.Expect(*keystoneAPI.Spec.Replicas).To(Equal(int32(3)))
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				OSCtlplane := GetOpenStackControlPlane(names.OpenStackControlplaneName)
+				OSCtlplane.Spec.Sizing = &corev1.SizingSection{Profile: corev1.SizingProfileMedium}
+				g.Expect(k8sClient.Update(ctx, OSCtlplane)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				keystoneAPI := keystone.GetKeystoneAPI(names.KeystoneAPIName)
+				g.Expect(*keystoneAPI.Spec.Replicas).To(Equal(int32(2)))
+				g.Expect(*keystoneAPI.Spec.HttpdCustomization.ProcessNumber).To(Equal(int32(3)))
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("An OpenStackControlPlane with a custom sizing profile of a missing ConfigMap is created", func() {
+		BeforeEach(func() {
+			spec := GetDefaultOpenStackControlPlaneSpec()
+			spec["sizing"] = map[string]interface{}{
+				"profile":   "lab",
+				"configMap": "sizing-profiles",
+			}
+			DeferCleanup(
+				th.DeleteInstance,
+				CreateOpenStackControlPlane(names.OpenStackControlplaneName, spec),
+			)
+		})
+
+		It("reports the missing sizing ConfigMap", func() {
+			th.ExpectCondition(
+				names.OpenStackControlplaneName,
+				ConditionGetterFunc(OpenStackControlPlaneConditionGetter),
+				corev1.OpenStackControlPlaneSizingReadyCondition,
+				k8s_corev1.ConditionFalse,
+			)
+		})
+	})
+
+	When("An OpenStackControlPlane with an autoscaled API is created", func() {
+		BeforeEach(func() {
+			spec := GetDefaultOpenStackControlPlaneSpec()
+			keystone := spec["keystone"].(map[string]interface{})
+			keystone["autoscaling"] = map[string]interface{}{
+				"minReplicas":                    1,
+				"maxReplicas":                    5,
+				"targetCPUUtilizationPercentage": 70,
+			}
+			DeferCleanup(
+				th.DeleteInstance,
+				CreateOpenStackControlPlane(names.OpenStackControlplaneName, spec),
+			)
+		})
+
+		It("creates a HorizontalPodAutoscaler for the API Deployment", func() {
+			hpaName := types.NamespacedName{Name: "keystone", Namespace: namespace}
+			Eventually(func(g Gomega) {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				g.Expect(k8sClient.Get(ctx, hpaName, hpa)).Should(Succeed())
+				g.Expect(hpa.Spec.ScaleTargetRef.Kind).To(Equal("Deployment"))
+				g.Expect(hpa.Spec.ScaleTargetRef.Name).To(Equal("keystone"))
+				// the template replicas are the floor
+				g.Expect(*hpa.Spec.MinReplicas).To(Equal(int32(1)))
+				g.Expect(hpa.Spec.MaxReplicas).To(Equal(int32(5)))
+				g.Expect(hpa.Spec.Metrics).To(HaveLen(1))
+				g.Expect(*hpa.Spec.Metrics[0].Resource.Target.AverageUtilization).To(Equal(int32(70)))
+				g.Expect(hpa.OwnerReferences).To(HaveLen(1))
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				OSCtlplane := GetOpenStackControlPlane(names.OpenStackControlplaneName)
+				keystone, exists := OSCtlplane.Status.Autoscaling["keystone"]
+				g.Expect(exists).To(BeTrue())
+				g.Expect(keystone.MinReplicas).To(Equal(int32(1)))
+				g.Expect(keystone.MaxReplicas).To(Equal(int32(5)))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("deletes the HorizontalPodAutoscaler when autoscaling is removed", func() {
+			hpaName := types.NamespacedName{Name: "keystone", Namespace: namespace}
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, hpaName, &autoscalingv2.HorizontalPodAutoscaler{})).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				OSCtlplane := GetOpenStackControlPlane(names.OpenStackControlplaneName)
+				OSCtlplane.Spec.Keystone.Autoscaling = nil
+				g.Expect(k8sClient.Update(ctx, OSCtlplane)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				err := k8sClient.Get(ctx, hpaName, &autoscalingv2.HorizontalPodAutoscaler{})
+				g.Expect(k8s_errors.IsNotFound(err)).To(BeTrue())
+				OSCtlplane := GetOpenStackControlPlane(names.OpenStackControlplaneName)
+				g.Expect(OSCtlplane.Status.Autoscaling).To(BeEmpty())
+			}, timeout, interval).Should(Succeed())
+		})
+	})
 })
 
 var _ = Describe("OpenStackOperator Webhook", func() {
@@ -4076,9 +4402,11 @@ var _ = Describe("OpenStackOperator controller nova cell deletion", func() {
 			// not sure why we must need tls for galera, on commenting it, got below error
 			// Message: "galeras.mariadb.openstack.org \"openstack\" not found",
 			spec["tls"] = GetTLSPublicSpec()
+			// nova waits for glance, neutron and placement to be ready, their
+			// readiness is not simulated
 			DeferCleanup(
 				th.DeleteInstance,
-				CreateOpenStackControlPlane(names.OpenStackControlplaneName, spec),
+				CreateOpenStackControlPlaneWithoutDependencyWait(names.OpenStackControlplaneName, spec),
 			)
 
 			// enable TLS
@@ -4128,6 +4456,9 @@ var _ = Describe("OpenStackOperator controller nova cell deletion", func() {
 				g.Expect(galeraTemplates).Should(HaveLen(2))
 				delete(galeraTemplates, names.DBCell1Name.Name)
 				OSCtlplane.Spec.Galera.Templates = &galeraTemplates
+				OSCtlplane.SetAnnotations(map[string]string{
+					corev1.ConfirmDeleteAnnotation: names.DBCell1Name.Name,
+				})
 				g.Expect(k8sClient.Update(ctx, OSCtlplane)).Should(Succeed())
 			}, timeout, interval).Should(Succeed())
 
@@ -4198,6 +4529,9 @@ var _ = Describe("OpenStackOperator controller nova cell deletion", func() {
 				g.Expect(rabbitTemplates).Should(HaveLen(3))
 				delete(rabbitTemplates, names.RabbitMQCell1Name.Name)
 				OSCtlplane.Spec.Rabbitmq.Templates = &rabbitTemplates
+				OSCtlplane.SetAnnotations(map[string]string{
+					corev1.ConfirmDeleteAnnotation: names.RabbitMQCell1Name.Name,
+				})
 				g.Expect(k8sClient.Update(ctx, OSCtlplane)).Should(Succeed())
 			}, timeout, interval).Should(Succeed())
 
@@ -4233,6 +4567,44 @@ var _ = Describe("OpenStackOperator controller nova cell deletion", func() {
 
 		})
 
+		It("cell1 galera still referenced or not confirmed is not deleted", func() {
+			Eventually(func(g Gomega) {
+				db := mariadb.GetGalera(names.DBCell1Name)
+				g.Expect(db).Should(Not(BeNil()))
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				OSCtlplane := GetOpenStackControlPlane(names.OpenStackControlplaneName)
+				galeraTemplates := *(OSCtlplane.Spec.Galera.Templates)
+				delete(galeraTemplates, names.DBCell1Name.Name)
+				OSCtlplane.Spec.Galera.Templates = &galeraTemplates
+				err := k8sClient.Update(ctx, OSCtlplane)
+				g.Expect(err).Should(HaveOccurred())
+				g.Expect(err.Error()).Should(ContainSubstring(corev1.ConfirmDeleteAnnotation))
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				OSCtlplane := GetOpenStackControlPlane(names.OpenStackControlplaneName)
+				OSCtlplane.Spec.Cinder.Template.DatabaseInstance = names.DBCell1Name.Name
+				g.Expect(k8sClient.Update(ctx, OSCtlplane)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				OSCtlplane := GetOpenStackControlPlane(names.OpenStackControlplaneName)
+				galeraTemplates := *(OSCtlplane.Spec.Galera.Templates)
+				delete(galeraTemplates, names.DBCell1Name.Name)
+				OSCtlplane.Spec.Galera.Templates = &galeraTemplates
+				OSCtlplane.SetAnnotations(map[string]string{
+					corev1.ConfirmDeleteAnnotation: names.DBCell1Name.Name,
+				})
+				err := k8sClient.Update(ctx, OSCtlplane)
+				g.Expect(err).Should(HaveOccurred())
+				g.Expect(err.Error()).Should(ContainSubstring("spec.cinder.template.databaseInstance"))
+			}, timeout, interval).Should(Succeed())
+
+			Expect(mariadb.GetGalera(names.DBCell1Name)).Should(Not(BeNil()))
+		})
+
 		When("The novncproxy k8s service is created for cell1", func() {
 			/*
 				- generate certs and routes for novncproxy
@@ -13,7 +13,9 @@ import (
 	//revive:disable-next-line:dot-imports
 	. "github.com/openstack-k8s-operators/lib-common/modules/common/test/helpers"
 	baremetalv1 "github.com/openstack-k8s-operators/openstack-baremetal-operator/api/v1beta1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"k8s.io/apimachinery/pkg/types"
@@ -1929,4 +1931,61 @@ var _ = Describe("Dataplane Deployment Test", func() {
 		})
 	})
 
+	When("A dataplaneDeployment is created with services of the same parallelGroup", func() {
+		BeforeEach(func() {
+			CreateSSHSecret(dataplaneSSHSecretName)
+			CreateCABundleSecret(caBundleSecretName)
+			CreateDataPlaneServiceFromSpec(dataplaneServiceName, map[string]interface{}{
+				"parallelGroup": "foo"})
+			CreateDataPlaneServiceFromSpec(dataplaneUpdateServiceName, map[string]interface{}{
+				"parallelGroup": "foo"})
+			CreateDataplaneService(dataplaneGlobalServiceName, true)
+
+			DeferCleanup(th.DeleteService, dataplaneServiceName)
+			DeferCleanup(th.DeleteService, dataplaneUpdateServiceName)
+			DeferCleanup(th.DeleteService, dataplaneGlobalServiceName)
+			DeferCleanup(th.DeleteInstance, CreateNetConfig(dataplaneNetConfigName, DefaultNetConfigSpec()))
+			DeferCleanup(th.DeleteInstance, CreateDNSMasq(dnsMasqName, DefaultDNSMasqSpec()))
+			SimulateDNSMasqComplete(dnsMasqName)
+			nodeSetSpec := DefaultDataPlaneNodeSetSpec(dataplaneNodeSetName.Name)
+			nodeSetSpec["preProvisioned"] = true
+			delete(nodeSetSpec, "baremetalSetTemplate")
+			DeferCleanup(th.DeleteInstance, CreateDataplaneNodeSet(dataplaneNodeSetName, nodeSetSpec))
+			SimulateIPSetComplete(dataplaneNodeName)
+			SimulateDNSDataComplete(dataplaneNodeSetName)
+			DeferCleanup(th.DeleteInstance, CreateDataplaneDeployment(dataplaneDeploymentName, DefaultDataPlaneDeploymentSpec()))
+		})
+
+		It("Should deploy the services of the group at the same time", func() {
+			getJobName := func(serviceName types.NamespacedName) types.NamespacedName {
+				aeeName, _ := dataplaneutil.GetAnsibleExecutionNameAndLabels(
+					GetService(serviceName), dataplaneDeploymentName.Name, dataplaneNodeSetName.Name)
+				return types.NamespacedName{Name: aeeName, Namespace: namespace}
+			}
+
+			// Both services of the group are started before any of them is ready
+			groupJobs := []*batchv1.Job{
+				GetAnsibleee(getJobName(dataplaneServiceName)),
+				GetAnsibleee(getJobName(dataplaneUpdateServiceName)),
+			}
+
+			// The service that follows the group waits for the whole group
+			globalJobName := getJobName(dataplaneGlobalServiceName)
+			Consistently(func(g Gomega) {
+				err := th.K8sClient.Get(th.Ctx, globalJobName, &batchv1.Job{})
+				g.Expect(k8s_errors.IsNotFound(err)).To(BeTrue())
+			}, "5s", "1s").Should(Succeed())
+
+			for _, job := range groupJobs {
+				Eventually(func(g Gomega) {
+					ansibleEE := GetAnsibleee(types.NamespacedName{Name: job.Name, Namespace: namespace})
+					ansibleEE.Status.Succeeded = 1
+					g.Expect(th.K8sClient.Status().Update(th.Ctx, ansibleEE)).To(Succeed())
+				}, th.Timeout, th.Interval).Should(Succeed())
+			}
+
+			GetAnsibleee(globalJobName)
+		})
+	})
+
 })
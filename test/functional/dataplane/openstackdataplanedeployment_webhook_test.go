@@ -6,7 +6,9 @@ import (
 	. "github.com/onsi/ginkgo/v2" //revive:disable:dot-imports
 	. "github.com/onsi/gomega"    //revive:disable:dot-imports
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
 	dataplanev1 "github.com/openstack-k8s-operators/openstack-operator/api/dataplane/v1beta1"
@@ -133,4 +135,52 @@ var _ = Describe("DataplaneDeployment Webhook", func() {
 			}).Should(BeTrue())
 		})
 	})
+
+	When("a deployment draining hosts before the update sets ansibleLimit", func() {
+		It("should reject the create request", func() {
+			Eventually(func(_ Gomega) string {
+				spec := DefaultDataPlaneDeploymentSpec()
+				spec["ansibleLimit"] = "edpm-compute-0"
+				spec["drainBeforeUpdate"] = map[string]interface{}{
+					"batchSize": 2,
+				}
+				name := types.NamespacedName{
+					Name: "edpm-drain-with-limit", Namespace: namespace}
+				obj := DefaultDataplaneDeploymentTemplate(name, spec)
+				unstructuredObj := &unstructured.Unstructured{Object: obj}
+				_, err := controllerutil.CreateOrPatch(
+					th.Ctx, th.K8sClient, unstructuredObj,
+					func() error { return nil })
+				return fmt.Sprintf("%s", err)
+			}).Should(ContainSubstring(
+				"ansibleLimit cannot be used with drainBeforeUpdate"))
+		})
+	})
+
+	When("a deployment sets an artifact sink with both a pvc and s3", func() {
+		It("should reject the create request", func() {
+			Eventually(func(_ Gomega) string {
+				spec := DefaultDataPlaneDeploymentSpec()
+				spec["artifactSink"] = map[string]interface{}{
+					"pvc": map[string]interface{}{
+						"claimName": "artifacts",
+					},
+					"s3": map[string]interface{}{
+						"endpoint":              "http://minio:9000",
+						"bucket":                "artifacts",
+						"credentialsSecretName": "minio-credentials",
+					},
+				}
+				name := types.NamespacedName{
+					Name: "edpm-artifact-sink", Namespace: namespace}
+				obj := DefaultDataplaneDeploymentTemplate(name, spec)
+				unstructuredObj := &unstructured.Unstructured{Object: obj}
+				_, err := controllerutil.CreateOrPatch(
+					th.Ctx, th.K8sClient, unstructuredObj,
+					func() error { return nil })
+				return fmt.Sprintf("%s", err)
+			}).Should(ContainSubstring(
+				"artifactSink requires exactly one of pvc or s3"))
+		})
+	})
 })
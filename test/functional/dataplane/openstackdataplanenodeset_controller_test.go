@@ -31,6 +31,8 @@ import (
 	. "github.com/openstack-k8s-operators/lib-common/modules/common/test/helpers"
 	"gopkg.in/yaml.v3"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
@@ -822,6 +824,58 @@ var _ = Describe("Dataplane NodeSet Test", func() {
 				}
 			})
 		})
+
+		When("A OpenStackDataPlaneDeployment reporting its progress is created", func() {
+			var progressName types.NamespacedName
+			BeforeEach(func() {
+				progressName = types.NamespacedName{
+					Name:      dataplaneNodeSetName.Name + "-ansibleee-progress",
+					Namespace: namespace,
+				}
+				nodeSetSpec := DefaultDataPlaneNoNodeSetSpec(tlsEnabled)
+				nodeSetSpec["services"] = []string{"foo-service"}
+				DeferCleanup(th.DeleteInstance, CreateNetConfig(dataplaneNetConfigName, DefaultNetConfigSpec()))
+				DeferCleanup(th.DeleteInstance, CreateDNSMasq(dnsMasqName, DefaultDNSMasqSpec()))
+				DeferCleanup(th.DeleteInstance, CreateDataplaneService(
+					types.NamespacedName{Name: "foo-service", Namespace: namespace}, false))
+				DeferCleanup(th.DeleteInstance, CreateDataplaneNodeSet(dataplaneNodeSetName, nodeSetSpec))
+				CreateSSHSecret(dataplaneSSHSecretName)
+				CreateCABundleSecret(caBundleSecretName)
+				SimulateDNSMasqComplete(dnsMasqName)
+				SimulateIPSetComplete(dataplaneNodeName)
+				SimulateDNSDataComplete(dataplaneNodeSetName)
+			})
+			It("Should only allow the jobs to report their progress while the deployment runs", func() {
+				th.ExpectCondition(
+					dataplaneNodeSetName,
+					ConditionGetterFunc(DataplaneConditionGetter),
+					condition.ServiceAccountReadyCondition,
+					corev1.ConditionTrue,
+				)
+				Expect(k8s_errors.IsNotFound(th.K8sClient.Get(
+					th.Ctx, progressName, &corev1.ConfigMap{}))).To(BeTrue())
+
+				deploymentSpec := DefaultDataPlaneDeploymentSpec()
+				deploymentSpec["servicesOverride"] = []string{"foo-service"}
+				deploymentSpec["reportProgress"] = true
+				deployment := CreateDataplaneDeployment(dataplaneDeploymentName, deploymentSpec)
+				Eventually(func(g Gomega) {
+					g.Expect(th.K8sClient.Get(th.Ctx, progressName, &corev1.ConfigMap{})).To(Succeed())
+					g.Expect(th.K8sClient.Get(th.Ctx, progressName, &rbacv1.Role{})).To(Succeed())
+					g.Expect(th.K8sClient.Get(th.Ctx, progressName, &rbacv1.RoleBinding{})).To(Succeed())
+				}, timeout, interval).Should(Succeed())
+
+				th.DeleteInstance(deployment)
+				Eventually(func(g Gomega) {
+					g.Expect(k8s_errors.IsNotFound(th.K8sClient.Get(
+						th.Ctx, progressName, &corev1.ConfigMap{}))).To(BeTrue())
+					g.Expect(k8s_errors.IsNotFound(th.K8sClient.Get(
+						th.Ctx, progressName, &rbacv1.Role{}))).To(BeTrue())
+					g.Expect(k8s_errors.IsNotFound(th.K8sClient.Get(
+						th.Ctx, progressName, &rbacv1.RoleBinding{}))).To(BeTrue())
+				}, timeout, interval).Should(Succeed())
+			})
+		})
 	})
 	When("TLS is not enabled explicitly its enabled by default", func() {
 		tlsEnabled := true
@@ -1,6 +1,7 @@
 package functional
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 
@@ -124,6 +125,84 @@ var _ = Describe("DataplaneNodeSet Webhook", func() {
 
 	})
 
+	When("nodePool is set in a bare-metal NodeSet", func() {
+		BeforeEach(func() {
+			nodeSetSpec := DefaultDataPlaneNoNodeSetSpec(false)
+			nodeSetSpec["preProvisioned"] = false
+			nodeSetSpec["nodes"] = map[string]interface{}{}
+			nodeSetSpec["baremetalSetTemplate"] = map[string]interface{}{
+				"domainName": "example.com",
+				"bmhLabelSelector": map[string]string{
+					"app": "test-openstack",
+				},
+			}
+			nodeSetSpec["nodePool"] = map[string]interface{}{
+				"replicas":        2,
+				"hostNamePattern": "compute-%d",
+				"bmhLabelSelector": map[string]string{
+					"pool": "compute",
+				},
+			}
+			DeferCleanup(th.DeleteInstance, CreateDataplaneNodeSet(dataplaneNodeSetName, nodeSetSpec))
+		})
+
+		It("Should generate the nodes of the pool without storing them in the spec", func() {
+			instance := GetDataplaneNodeSet(dataplaneNodeSetName)
+			Expect(instance.Spec.Nodes).To(BeEmpty())
+			Eventually(func(g Gomega) {
+				instance := GetDataplaneNodeSet(dataplaneNodeSetName)
+				g.Expect(instance.Status.NodePoolNodes).To(Equal(
+					[]string{"compute-0", "compute-1"}))
+			}, timeout, interval).Should(Succeed())
+
+			instance.Spec.AddNodePoolNodes(instance.GetNodePoolNodes())
+			Expect(instance.Spec.Nodes["compute-0"].HostName).Should(Equal("compute-0.example.com"))
+			Expect(instance.Spec.Nodes["compute-1"].BmhLabelSelector).Should(Equal(
+				map[string]string{"pool": "compute"}))
+		})
+
+		It("Should remove the node with the highest index on scale-in", func() {
+			Eventually(func(g Gomega) {
+				instance := GetDataplaneNodeSet(dataplaneNodeSetName)
+				g.Expect(instance.Status.NodePoolNodes).To(HaveLen(2))
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(_ Gomega) error {
+				instance := GetDataplaneNodeSet(dataplaneNodeSetName)
+				instance.Spec.NodePool.Replicas = 1
+				return th.K8sClient.Update(th.Ctx, instance)
+			}).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				instance := GetDataplaneNodeSet(dataplaneNodeSetName)
+				g.Expect(instance.Spec.Nodes).To(BeEmpty())
+				g.Expect(instance.Status.NodePoolNodes).To(Equal([]string{"compute-0"}))
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("nodePool is set in a pre-provisioned NodeSet", func() {
+		It("Should block the NodeSet", func() {
+			Eventually(func(_ Gomega) string {
+				nodeSetSpec := DefaultDataPlaneNoNodeSetSpec(false)
+				nodeSetSpec["preProvisioned"] = true
+				nodeSetSpec["nodePool"] = map[string]interface{}{
+					"replicas":        1,
+					"hostNamePattern": "compute-%d",
+				}
+				name := types.NamespacedName{
+					Name: "test-preprovisioned-nodepool", Namespace: namespace}
+				obj := DefaultDataplaneNodeSetTemplate(name, nodeSetSpec)
+				unstructuredObj := &unstructured.Unstructured{Object: obj}
+				_, err := controllerutil.CreateOrPatch(
+					th.Ctx, th.K8sClient, unstructuredObj,
+					func() error { return nil })
+				return fmt.Sprintf("%s", err)
+			}).Should(ContainSubstring(
+				"nodePool requires a baremetalSetTemplate"))
+		})
+	})
+
 	When("A user tries to redeclare an existing node in a new NodeSet", func() {
 		BeforeEach(func() {
 			nodeSetSpec := DefaultDataPlaneNoNodeSetSpec(false)
@@ -281,4 +360,46 @@ var _ = Describe("DataplaneNodeSet Webhook", func() {
 				dataplaneDeploymentName.Name, string(v1beta1.NodeSetDeploymentReadyCondition))))
 		})
 	})
+
+	When("A service of the NodeSet gets a stricter ansibleVarsSchema", func() {
+		var serviceName types.NamespacedName
+
+		BeforeEach(func() {
+			serviceName = types.NamespacedName{
+				Name:      "custom-schema-service",
+				Namespace: namespace,
+			}
+			DeferCleanup(th.DeleteInstance, CreateDataPlaneServiceFromSpec(serviceName, map[string]interface{}{}))
+
+			nodeSetSpec := DefaultDataPlaneNoNodeSetSpec(false)
+			nodeSetSpec["services"] = []string{serviceName.Name}
+			DeferCleanup(th.DeleteInstance, CreateDataplaneNodeSet(dataplaneNodeSetName, nodeSetSpec))
+
+			Eventually(func(g Gomega) {
+				service := GetService(serviceName)
+				service.Spec.AnsibleVarsSchema = map[string]json.RawMessage{
+					"type":     json.RawMessage(`"object"`),
+					"required": json.RawMessage(`["edpm_custom_mode"]`),
+				}
+				g.Expect(th.K8sClient.Update(th.Ctx, service)).To(Succeed())
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("Should allow the updates which don't change the NodeSet spec", func() {
+			Eventually(func(_ Gomega) error {
+				instance := GetDataplaneNodeSet(dataplaneNodeSetName)
+				instance.Labels = map[string]string{"test": "schema"}
+				return th.K8sClient.Update(th.Ctx, instance)
+			}).Should(Succeed())
+		})
+
+		It("Should block the NodeSet spec updates", func() {
+			Eventually(func(_ Gomega) string {
+				instance := GetDataplaneNodeSet(dataplaneNodeSetName)
+				instance.Spec.NodeTemplate.Ansible.AnsibleUser = "random-user"
+				err := th.K8sClient.Update(th.Ctx, instance)
+				return fmt.Sprintf("%s", err)
+			}).Should(ContainSubstring("edpm_custom_mode"))
+		})
+	})
 })
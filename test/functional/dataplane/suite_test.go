@@ -46,6 +46,7 @@ import (
 	openstackv1 "github.com/openstack-k8s-operators/openstack-operator/api/core/v1beta1"
 	dataplanev1 "github.com/openstack-k8s-operators/openstack-operator/api/dataplane/v1beta1"
 	dataplanecontrollers "github.com/openstack-k8s-operators/openstack-operator/internal/controller/dataplane"
+	deployment "github.com/openstack-k8s-operators/openstack-operator/internal/dataplane"
 
 	certmgrv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 
@@ -236,9 +237,11 @@ var _ = BeforeSuite(func() {
 	Expect(err).ToNot(HaveOccurred())
 
 	err = (&dataplanecontrollers.OpenStackDataPlaneDeploymentReconciler{
-		Client:  k8sManager.GetClient(),
-		Scheme:  k8sManager.GetScheme(),
-		Kclient: kclient,
+		Client:       k8sManager.GetClient(),
+		Scheme:       k8sManager.GetScheme(),
+		Kclient:      kclient,
+		Recorder:     k8sManager.GetEventRecorderFor("openstackdataplanedeployment-controller"),
+		EventLimiter: deployment.NewEventRateLimiter(),
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 